@@ -48,7 +48,7 @@ func main() {
 
 	// Cria o servidor HTTP
 	server := &http.Server{
-		Addr:    "0.0.0.0:5001",
+		Addr:    sc.Config.Server.Addr,
 		Handler: r,
 	}
 
@@ -63,7 +63,7 @@ func main() {
 		}
 	}()
 
-	log.Println("Servidor iniciado...")
+	log.Printf("Servidor iniciado em %s...", server.Addr)
 
 	// Esperar por um sinal de término
 	<-stop
@@ -77,6 +77,7 @@ func main() {
 	if err := server.Shutdown(ctx); err != nil {
 		log.Fatalf("Erro ao desligar o servidor: %v", err)
 	}
+	sc.Close()
 
 	log.Println("Servidor desligado.")
 }
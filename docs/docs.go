@@ -23,6 +23,26 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "Retorna as chaves públicas (RSA/Ed25519) ativas para verificação dos JWTs emitidos pela API. Chaves HMAC não são publicadas.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Chaves públicas (JWKS)",
+                "responses": {
+                    "200": {
+                        "description": "Conjunto de chaves",
+                        "schema": {
+                            "$ref": "#/definitions/JWKS"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/auth-apikey/tenant-by-apikey": {
             "get": {
                 "description": "Busca Tenant por X-API-Key",
@@ -58,6 +78,61 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/v1/auth/email/verify": {
+            "post": {
+                "description": "Consome o token de verificação (uso único) e ativa o usuário pendente",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Confirma o e-mail",
+                "parameters": [
+                    {
+                        "description": "Token de verificação",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/VerifyEmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "E-mail verificado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Parâmetros de entrada inválidos ou token inválido/expirado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erro ao verificar o e-mail",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/auth/login": {
             "post": {
                 "description": "Loga um usuário usando email e senha",
@@ -89,7 +164,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Token gerado com sucesso",
+                        "description": "Token gerado com sucesso, ou models.TwoFactorChallenge se o usuário tiver 2FA ativo",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -103,15 +178,51 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "401": {
+                        "description": "Credenciais inválidas",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Tenant suspenso (TENANT_SUSPENDED), e-mail não verificado (USER_PENDING) ou usuário desativado (USER_DISABLED), no campo code",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "423": {
+                        "description": "Conta temporariamente bloqueada (header Retry-After)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Aguarde antes de tentar novamente (header Retry-After)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/api/v1/auth/refresh": {
+        "/api/v1/auth/login/2fa": {
             "post": {
-                "description": "Renova o token usando o refreshToken",
+                "description": "Recebe o challenge_token devolvido pelo login e um código TOTP (ou código de recuperação) e emite os tokens",
                 "consumes": [
-                    "application/x-www-form-urlencoded"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
@@ -119,26 +230,63 @@ const docTemplate = `{
                 "tags": [
                     "Auth"
                 ],
-                "summary": "Renova o token",
+                "summary": "Conclui o login com o segundo fator",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Refresh Token",
-                        "name": "refreshToken",
-                        "in": "formData",
-                        "required": true
+                        "description": "Desafio e código",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/TwoFactorLoginRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Token renovado com sucesso",
+                        "description": "Token gerado com sucesso",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/Token"
                         }
                     },
                     "400": {
-                        "description": "Erro de autenticação",
+                        "description": "Parâmetros de entrada inválidos",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Desafio inválido/expirado ou código inválido",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Tenant suspenso, e-mail não verificado ou usuário desativado (campo code)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "423": {
+                        "description": "Conta temporariamente bloqueada por códigos inválidos (header Retry-After)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Aguarde antes de tentar novamente (header Retry-After)",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -149,85 +297,101 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/tenants": {
-            "get": {
-                "description": "Busca todos os Tenants",
-                "consumes": [
-                    "application/json"
+        "/api/v1/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
                 ],
+                "description": "Revoga o access token e o refresh token da sessão atual",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tenants"
+                    "Auth"
                 ],
-                "summary": "Busca todos os Tenants",
+                "summary": "Encerra a sessão atual",
                 "responses": {
                     "200": {
-                        "description": "Lista de Tenants",
+                        "description": "Logout realizado com sucesso",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/Tenant"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Usuário não autenticado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
                     "500": {
-                        "description": "Erro Interno do Servidor",
+                        "description": "Erro ao revogar a sessão",
                         "schema": {
-                            "$ref": "#/definitions/HTTPError"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/v1/auth/logout-all": {
             "post": {
-                "description": "Adiciona um novo Tenant ao sistema",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "Bearer": []
+                    }
                 ],
+                "description": "Revoga os access tokens e refresh tokens de todas as sessões do usuário autenticado",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tenants"
-                ],
-                "summary": "Cria um novo Tenant",
-                "parameters": [
-                    {
-                        "description": "Informações do Tenant",
-                        "name": "tenant",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/Tenant"
-                        }
-                    }
+                    "Auth"
                 ],
+                "summary": "Encerra todas as sessões",
                 "responses": {
-                    "201": {
-                        "description": "Tenant Criado",
+                    "200": {
+                        "description": "Todas as sessões foram encerradas",
                         "schema": {
-                            "$ref": "#/definitions/Tenant"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Erro de Formato de Solicitação",
+                    "401": {
+                        "description": "Usuário não autenticado",
                         "schema": {
-                            "$ref": "#/definitions/HTTPError"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Erro Interno do Servidor",
+                        "description": "Erro ao revogar as sessões",
                         "schema": {
-                            "$ref": "#/definitions/HTTPError"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/v1/tenants/{id}": {
-            "get": {
-                "description": "Busca Tenant por ID",
+        "/api/v1/auth/password/forgot": {
+            "post": {
+                "description": "Envia por e-mail um token de uso único para redefinir a senha. A resposta é a mesma para e-mails cadastrados ou não.",
                 "consumes": [
                     "application/json"
                 ],
@@ -235,41 +399,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tenants"
+                    "Auth"
                 ],
-                "summary": "Busca Tenant por ID",
+                "summary": "Solicita redefinição de senha",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Tenant ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "E-mail do usuário",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ForgotPasswordRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Tenant",
+                    "202": {
+                        "description": "Pedido recebido",
                         "schema": {
-                            "$ref": "#/definitions/Tenant"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid UUID format",
-                        "schema": {
-                            "$ref": "#/definitions/HTTPError"
-                        }
-                    },
-                    "404": {
-                        "description": "Tenant not found",
+                        "description": "Parâmetros de entrada inválidos",
                         "schema": {
-                            "$ref": "#/definitions/HTTPError"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Atualiza um Tenant existente com base no ID fornecido",
+            }
+        },
+        "/api/v1/auth/password/reset": {
+            "post": {
+                "description": "Consome o token de redefinição (uso único), grava a nova senha e encerra todas as sessões do usuário.",
                 "consumes": [
                     "application/json"
                 ],
@@ -277,92 +445,134 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tenants"
+                    "Auth"
                 ],
-                "summary": "Atualiza um Tenant existente",
+                "summary": "Redefine a senha",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Tenant ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Dados do Tenant",
-                        "name": "tenant",
+                        "description": "Token e nova senha",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/Tenant"
+                            "$ref": "#/definitions/ResetPasswordRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Tenant Atualizado",
+                        "description": "Senha redefinida",
                         "schema": {
-                            "$ref": "#/definitions/Tenant"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
-                        "description": "ID Inválido ou Erro de Formato de Solicitação",
+                        "description": "Parâmetros de entrada inválidos ou token inválido/expirado",
                         "schema": {
-                            "$ref": "#/definitions/HTTPError"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Senha não atende à política",
+                        "schema": {
+                            "$ref": "#/definitions/PasswordPolicyErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Erro Interno do Servidor",
+                        "description": "Erro ao redefinir a senha",
                         "schema": {
-                            "$ref": "#/definitions/HTTPError"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Exclui um Tenant com base no ID fornecido",
+            }
+        },
+        "/api/v1/auth/refresh": {
+            "post": {
+                "description": "Renova o token usando o refreshToken. Cada refresh token só pode ser usado uma vez: a resposta traz um novo refresh token da mesma família, e a reutilização de um token já consumido revoga a família inteira.",
                 "consumes": [
-                    "application/json"
+                    "application/x-www-form-urlencoded"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tenants"
+                    "Auth"
                 ],
-                "summary": "Exclui um Tenant",
+                "summary": "Renova o token",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Tenant ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Refresh Token",
+                        "name": "refreshToken",
+                        "in": "formData",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Mensagem de sucesso",
+                        "description": "Token renovado com sucesso",
                         "schema": {
-                            "$ref": "#/definitions/H"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "ID Inválido",
+                        "description": "Erro de autenticação",
                         "schema": {
-                            "$ref": "#/definitions/HTTPError"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Refresh token inválido, revogado ou já utilizado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Tenant suspenso ou usuário desativado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Erro Interno do Servidor",
+                        "description": "Erro interno do servidor",
                         "schema": {
-                            "$ref": "#/definitions/HTTPError"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Atualiza parcialmente um Tenant existente com base no ID fornecido",
+            }
+        },
+        "/api/v1/authz/explain": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Verifica, como o PolicyMiddleware, se o User pode acessar o caminho com o método informado no tenant dele. Retorna a política que permitiu o acesso ou, se negado, os roles do User e as políticas que cobrem o endpoint sem incluir o método.",
                 "consumes": [
                     "application/json"
                 ],
@@ -370,36 +580,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tenants"
+                    "Authz"
                 ],
-                "summary": "Atualiza parcialmente um Tenant existente",
+                "summary": "Explica uma decisão de acesso",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Tenant ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Dados atualizáveis do Tenant",
-                        "name": "tenant",
+                        "description": "User, caminho e método",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/Tenant"
+                            "$ref": "#/definitions/AuthzExplainInput"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Mensagem de sucesso",
+                        "description": "Resultado da verificação",
                         "schema": {
-                            "$ref": "#/definitions/H"
+                            "$ref": "#/definitions/AuthzExplanation"
                         }
                     },
                     "400": {
-                        "description": "ID Inválido ou Erro de Formato de Solicitação",
+                        "description": "Parâmetros de entrada inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Usuário não autenticado",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Usuário não encontrado",
                         "schema": {
                             "$ref": "#/definitions/HTTPError"
                         }
@@ -413,26 +628,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users": {
+        "/api/v1/endpoints": {
             "get": {
-                "description": "Busca todos os Users",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "Bearer": []
+                    }
                 ],
+                "description": "Lista todos os endpoints cadastrados para as políticas, ordenados pelo nome",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Endpoints"
                 ],
-                "summary": "Busca todos os Users",
+                "summary": "Lista os Endpoints",
                 "responses": {
                     "200": {
-                        "description": "Lista de Users",
+                        "description": "Lista de Endpoints",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/User"
+                                "$ref": "#/definitions/Endpoint"
                             }
                         }
                     },
@@ -445,7 +662,12 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Adiciona um novo User ao sistema",
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Cadastra um endpoint no padrão das rotas (ex.: /api/v1/users/:id), para uso nas políticas",
                 "consumes": [
                     "application/json"
                 ],
@@ -453,25 +675,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Endpoints"
                 ],
-                "summary": "Cria um novo User",
+                "summary": "Cria um Endpoint",
                 "parameters": [
                     {
-                        "description": "Informações do User",
-                        "name": "user",
+                        "description": "Nome do Endpoint",
+                        "name": "endpoint",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/UserCreate"
+                            "$ref": "#/definitions/EndpointInput"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "User Criado",
+                        "description": "Endpoint criado",
                         "schema": {
-                            "$ref": "#/definitions/User"
+                            "$ref": "#/definitions/Endpoint"
                         }
                     },
                     "400": {
@@ -480,6 +702,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/HTTPError"
                         }
                     },
+                    "409": {
+                        "description": "Endpoint já existe",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Erro Interno do Servidor",
                         "schema": {
@@ -489,23 +717,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users/{id}": {
+        "/api/v1/endpoints/{id}": {
             "get": {
-                "description": "Busca User por ID",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "Bearer": []
+                    }
                 ],
+                "description": "Busca um endpoint pelo ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Endpoints"
                 ],
-                "summary": "Busca User por ID",
+                "summary": "Busca Endpoint por ID",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID",
+                        "type": "integer",
+                        "description": "Endpoint ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -513,19 +743,25 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "User",
+                        "description": "Endpoint",
                         "schema": {
-                            "$ref": "#/definitions/User"
+                            "$ref": "#/definitions/Endpoint"
                         }
                     },
                     "400": {
-                        "description": "Invalid UUID format",
+                        "description": "ID Inválido",
                         "schema": {
                             "$ref": "#/definitions/HTTPError"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Endpoint não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
                         "schema": {
                             "$ref": "#/definitions/HTTPError"
                         }
@@ -533,7 +769,12 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Atualiza um User existente com base no ID fornecido",
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Renomeia o endpoint; as políticas que o usam passam a valer para o novo nome",
                 "consumes": [
                     "application/json"
                 ],
@@ -541,36 +782,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Endpoints"
                 ],
-                "summary": "Atualiza um User existente",
+                "summary": "Renomeia um Endpoint",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID",
+                        "type": "integer",
+                        "description": "Endpoint ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Dados do User",
-                        "name": "user",
+                        "description": "Novo nome do Endpoint",
+                        "name": "endpoint",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/User"
+                            "$ref": "#/definitions/EndpointInput"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User Atualizado",
+                        "description": "Endpoint atualizado",
                         "schema": {
-                            "$ref": "#/definitions/User"
+                            "$ref": "#/definitions/Endpoint"
                         }
                     },
                     "400": {
-                        "description": "ID Inválido ou Erro de Formato de Solicitação",
+                        "description": "Erro de Formato de Solicitação",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Endpoint não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Endpoint já existe",
                         "schema": {
                             "$ref": "#/definitions/HTTPError"
                         }
@@ -584,21 +837,23 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Exclui um User com base no ID fornecido",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "Bearer": []
+                    }
                 ],
+                "description": "Remove o endpoint. Endpoints usados em políticas não são removidos.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Endpoints"
                 ],
-                "summary": "Exclui um User",
+                "summary": "Remove um Endpoint",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID",
+                        "type": "integer",
+                        "description": "Endpoint ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -606,9 +861,12 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Mensagem de sucesso",
+                        "description": "Endpoint removido",
                         "schema": {
-                            "$ref": "#/definitions/H"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -617,6 +875,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/HTTPError"
                         }
                     },
+                    "404": {
+                        "description": "Endpoint não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Endpoint usado em políticas",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Erro Interno do Servidor",
                         "schema": {
@@ -624,9 +894,51 @@ const docTemplate = `{
                         }
                     }
                 }
+            }
+        },
+        "/api/v1/me": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna os dados do usuário dono do access token",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Perfil do usuário autenticado",
+                "responses": {
+                    "200": {
+                        "description": "Perfil do usuário",
+                        "schema": {
+                            "$ref": "#/definitions/User"
+                        }
+                    },
+                    "401": {
+                        "description": "Usuário não autenticado",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Usuário não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
             },
             "patch": {
-                "description": "Atualiza parcialmente um User existente com base no ID fornecido",
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Altera apenas os campos informados (name, username, thumbnail)",
                 "consumes": [
                     "application/json"
                 ],
@@ -634,36 +946,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Me"
                 ],
-                "summary": "Atualiza parcialmente um User existente",
+                "summary": "Atualiza o perfil do usuário autenticado",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Dados atualizáveis do User",
-                        "name": "user",
+                        "description": "Campos do perfil",
+                        "name": "profile",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/User"
+                            "$ref": "#/definitions/UserProfileUpdate"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Mensagem de sucesso",
+                        "description": "Perfil atualizado",
                         "schema": {
-                            "$ref": "#/definitions/H"
+                            "$ref": "#/definitions/User"
                         }
                     },
                     "400": {
-                        "description": "ID Inválido ou Erro de Formato de Solicitação",
+                        "description": "Parâmetros de entrada inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Usuário não autenticado",
                         "schema": {
                             "$ref": "#/definitions/HTTPError"
                         }
@@ -676,19 +987,3358 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "DeletedAt": {
-            "type": "object",
-            "properties": {
-                "time": {
-                    "type": "string"
-                },
-                "valid": {
-                    "description": "Valid is true if Time is not NULL",
-                    "type": "boolean"
-                }
+        },
+        "/api/v1/me/2fa/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Valida o código TOTP do segredo gerado em /enroll, ativa o 2FA e retorna os códigos de recuperação (exibidos uma única vez)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Confirma e ativa o 2FA",
+                "parameters": [
+                    {
+                        "description": "Código TOTP",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/TwoFactorCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Códigos de recuperação",
+                        "schema": {
+                            "$ref": "#/definitions/RecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Parâmetros de entrada inválidos, código inválido ou configuração não iniciada",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Usuário não autenticado",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "2FA já está ativo",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/2fa/disable": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Exige um código TOTP ou de recuperação válido. Remove o segredo e os códigos de recuperação.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Desativa o 2FA",
+                "parameters": [
+                    {
+                        "description": "Código TOTP ou de recuperação",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/TwoFactorCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "2FA desativado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Parâmetros de entrada inválidos, código inválido ou 2FA inativo",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Usuário não autenticado",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/2fa/enroll": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Gera um novo segredo TOTP e a URI otpauth:// para o aplicativo autenticador. O 2FA só é ativado após a confirmação.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Inicia a configuração do 2FA",
+                "responses": {
+                    "200": {
+                        "description": "Segredo e URI de provisionamento",
+                        "schema": {
+                            "$ref": "#/definitions/TOTPEnrollment"
+                        }
+                    },
+                    "401": {
+                        "description": "Usuário não autenticado",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "2FA já está ativo",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/2fa/recovery-codes": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Exige um código TOTP ou de recuperação válido. Os códigos anteriores deixam de valer.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Gera novos códigos de recuperação",
+                "parameters": [
+                    {
+                        "description": "Código TOTP ou de recuperação",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/TwoFactorCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Novos códigos de recuperação",
+                        "schema": {
+                            "$ref": "#/definitions/RecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Parâmetros de entrada inválidos, código inválido ou 2FA inativo",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Usuário não autenticado",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/password": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Exige a senha atual. As demais sessões do usuário são encerradas; a sessão atual é mantida.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Troca a senha do usuário autenticado",
+                "parameters": [
+                    {
+                        "description": "Senha atual e nova senha",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Senha alterada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Parâmetros de entrada inválidos ou senha atual incorreta",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Usuário não autenticado",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "422": {
+                        "description": "Nova senha não atende à política",
+                        "schema": {
+                            "$ref": "#/definitions/PasswordPolicyErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/permissions": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Lista, por endpoint, as ações permitidas pelos roles (do tenant e globais) e pelas políticas especiais do usuário no seu tenant, conforme as políticas em vigor (e não as do login). Políticas com condição também aparecem. Serve para a interface esconder o que o usuário não pode fazer; a autorização continua sendo feita a cada requisição.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Permissões do usuário autenticado",
+                "responses": {
+                    "200": {
+                        "description": "Roles e permissões por endpoint",
+                        "schema": {
+                            "$ref": "#/definitions/UserPermissions"
+                        }
+                    },
+                    "401": {
+                        "description": "Usuário não autenticado",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Lista as sessões (logins) ativas do usuário, indicando qual é a sessão atual",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Sessões ativas",
+                "responses": {
+                    "200": {
+                        "description": "Sessões ativas",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/SessionInfo"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Usuário não autenticado",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Revoga o access token e o refresh token de uma sessão do próprio usuário",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Encerra uma sessão",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da sessão",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sessão encerrada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Usuário não autenticado",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Sessão não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/roles": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Lista todos os roles, ordenados pelo nome",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Lista os Roles",
+                "responses": {
+                    "200": {
+                        "description": "Lista de Roles",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Role"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Cria um role do tenant, sem políticas. Com \"global\": true cria um role válido em todos os tenants (apenas o role master).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Cria um Role",
+                "parameters": [
+                    {
+                        "description": "Nome do Role e escopo",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/RoleInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Role criado",
+                        "schema": {
+                            "$ref": "#/definitions/Role"
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de Formato de Solicitação",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Role global exige o role master",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Nome já existe",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/roles/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Busca um role pelo ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Busca Role por ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Role",
+                        "schema": {
+                            "$ref": "#/definitions/Role"
+                        }
+                    },
+                    "400": {
+                        "description": "ID Inválido",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Role não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Renomeia o role. O role master não pode ser renomeado.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Renomeia um Role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Novo nome do Role",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/RoleInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Role atualizado",
+                        "schema": {
+                            "$ref": "#/definitions/Role"
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de Formato de Solicitação",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Role protegido ou global",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Role não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Nome já existe",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Remove o role e suas políticas. Roles atribuídos a usuários e o role master não são removidos.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Remove um Role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Role removido",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ID Inválido",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Role protegido ou global",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Role não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Role atribuído a usuários",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/roles/{id}/policies": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Lista os endpoints e as ações permitidas ao role",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Lista as políticas do Role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Políticas do Role",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/PolicyRole"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ID Inválido",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Role não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Concede ao role as ações (GET, POST, PUT, PATCH, DELETE separados por |) sobre o endpoint. As políticas do Casbin são recarregadas.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Cria uma política para o Role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Endpoint e ações",
+                        "name": "policy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/PolicyInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Política criada",
+                        "schema": {
+                            "$ref": "#/definitions/PolicyRole"
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de Formato de Solicitação, ações ou condição inválidas",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Role global ou ações que o usuário não possui",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Role ou endpoint não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Política já existe",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/roles/{id}/policies/{endpoint_id}": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Substitui as ações permitidas ao role sobre o endpoint",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Altera uma política do Role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Endpoint ID",
+                        "name": "endpoint_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ações",
+                        "name": "policy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/PolicyActionsInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Política atualizada",
+                        "schema": {
+                            "$ref": "#/definitions/PolicyRole"
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de Formato de Solicitação, ações ou condição inválidas",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Role global ou ações que o usuário não possui",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Política não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retira do role o acesso ao endpoint",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Remove uma política do Role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Endpoint ID",
+                        "name": "endpoint_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Política removida",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ID Inválido",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Política não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tenants": {
+            "get": {
+                "description": "Lista os Tenants com filtros, ordenação e paginação. Filtros: campo=valor ou campo[op]=valor, com op in (valores separados por vírgula), like (contém), gte ou lte.\nCampos: name (eq, in, like), type e status (eq, in), cpf_cnpj e email (eq, like), city (eq, in, like), state (eq, in), created_at e updated_at (gte, lte).\nOrdenação por name, type, status, created_at e updated_at (prefixo - para decrescente). O header Link traz as páginas first, prev, next e last.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenants"
+                ],
+                "summary": "Lista os Tenants",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Itens por página (1 a 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Itens a pular (não use com cursor)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor next_cursor da página anterior",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "name",
+                        "description": "Ordenação, ex.: -created_at,name",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Página de Tenants",
+                        "schema": {
+                            "$ref": "#/definitions/ListResponse-Tenant"
+                        }
+                    },
+                    "400": {
+                        "description": "Filtro, ordenação ou paginação inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adiciona um novo Tenant ao sistema",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenants"
+                ],
+                "summary": "Cria um novo Tenant",
+                "parameters": [
+                    {
+                        "description": "Informações do Tenant",
+                        "name": "tenant",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Tenant"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chave única da operação; as repetições com a mesma chave recebem a resposta original",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Tenant Criado",
+                        "schema": {
+                            "$ref": "#/definitions/Tenant"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versão do Tenant"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de Formato de Solicitação",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Idempotency-Key já usada com outro conteúdo ou ainda em andamento",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tenants/search": {
+            "get": {
+                "description": "Busca os Tenants por nome, CPF/CNPJ (com ou sem pontuação), cidade ou e-mail. Busca por prefixo, sem diferenciar acentos e maiúsculas, e tolera erros de digitação; os resultados vêm do mais ao menos relevante.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenants"
+                ],
+                "summary": "Busca Tenants por texto",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Termo de busca (2 a 100 caracteres)",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Máximo de resultados (1 a 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tenants encontrados",
+                        "schema": {
+                            "$ref": "#/definitions/SearchResponse-Tenant"
+                        }
+                    },
+                    "400": {
+                        "description": "Termo de busca inválido",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tenants/trash": {
+            "get": {
+                "description": "Lista os Tenants removidos (exclusão lógica), com os mesmos filtros de GET /api/v1/tenants, mais deleted_at (gte, lte). Por padrão, os removidos mais recentemente primeiro.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenants"
+                ],
+                "summary": "Lista os Tenants na lixeira",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Itens por página (1 a 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Itens a pular (não use com cursor)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor next_cursor da página anterior",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-deleted_at",
+                        "description": "Ordenação, ex.: -deleted_at",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Página de Tenants removidos",
+                        "schema": {
+                            "$ref": "#/definitions/ListResponse-Tenant"
+                        }
+                    },
+                    "400": {
+                        "description": "Filtro, ordenação ou paginação inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tenants/{id}": {
+            "get": {
+                "description": "Busca Tenant por ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenants"
+                ],
+                "summary": "Busca Tenant por ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tenant",
+                        "schema": {
+                            "$ref": "#/definitions/Tenant"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versão do Tenant, para o If-Match de PUT, PATCH e DELETE"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid UUID format",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant not found",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Atualiza um Tenant existente com base no ID fornecido. Com If-Match (ETag do GET), a atualização só é feita se o Tenant não tiver sido alterado desde então.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenants"
+                ],
+                "summary": "Atualiza um Tenant existente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag da versão lida",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Dados do Tenant",
+                        "name": "tenant",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Tenant"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tenant Atualizado",
+                        "schema": {
+                            "$ref": "#/definitions/Tenant"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Nova versão do Tenant"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ID Inválido ou Erro de Formato de Solicitação",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "412": {
+                        "description": "O Tenant foi alterado depois da versão do If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Exclui um Tenant com base no ID fornecido. Por padrão, o Tenant vai para a lixeira e pode ser restaurado; com hard=true (política própria, em /api/v1/tenants/:id/purge), é removido definitivamente com as suas API Keys.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenants"
+                ],
+                "summary": "Exclui um Tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Remove definitivamente",
+                        "name": "hard",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag da versão lida",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Mensagem de sucesso",
+                        "schema": {
+                            "$ref": "#/definitions/H"
+                        }
+                    },
+                    "400": {
+                        "description": "ID Inválido",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant not found",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "O Tenant ainda tem usuários ou roles",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "412": {
+                        "description": "O Tenant foi alterado depois da versão do If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Atualiza parcialmente um Tenant existente com base no ID fornecido. Com If-Match (ETag do GET), a atualização só é feita se o Tenant não tiver sido alterado desde então.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenants"
+                ],
+                "summary": "Atualiza parcialmente um Tenant existente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag da versão lida",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Dados atualizáveis do Tenant",
+                        "name": "tenant",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Tenant"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Mensagem de sucesso",
+                        "schema": {
+                            "$ref": "#/definitions/H"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Nova versão do Tenant"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ID Inválido ou Erro de Formato de Solicitação",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "412": {
+                        "description": "O Tenant foi alterado depois da versão do If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tenants/{id}/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Lista as chaves do Tenant, inclusive revogadas e expiradas. A chave em claro nunca é retornada, apenas o prefixo.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenants"
+                ],
+                "summary": "Lista as API Keys do Tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Lista de API Keys",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ApiKey"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid UUID format",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant not found",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Gera uma nova chave. A chave em claro (campo key) é exibida somente nesta resposta.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenants"
+                ],
+                "summary": "Cria uma API Key para o Tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nome, escopos e expiração",
+                        "name": "apiKey",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ApiKeyCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "API Key criada",
+                        "schema": {
+                            "$ref": "#/definitions/ApiKeyCreated"
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de Formato de Solicitação",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant not found",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tenants/{id}/api-keys/{key_id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Busca uma chave do Tenant pelo ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenants"
+                ],
+                "summary": "Busca API Key por ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API Key ID",
+                        "name": "key_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "API Key",
+                        "schema": {
+                            "$ref": "#/definitions/ApiKey"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid UUID format",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant ou API Key não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Revoga a chave imediatamente. O registro é mantido para auditoria.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenants"
+                ],
+                "summary": "Revoga uma API Key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API Key ID",
+                        "name": "key_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "API Key revogada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid UUID format",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant ou API Key não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Altera nome, escopos ou expiração. A chave em si não muda; para trocá-la, use a rotação.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenants"
+                ],
+                "summary": "Atualiza uma API Key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API Key ID",
+                        "name": "key_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Campos a alterar",
+                        "name": "apiKey",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ApiKeyUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "API Key atualizada",
+                        "schema": {
+                            "$ref": "#/definitions/ApiKey"
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de Formato de Solicitação",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant ou API Key não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tenants/{id}/api-keys/{key_id}/rotate": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Gera uma nova chave com o mesmo nome, escopos e expiração. A chave antiga continua válida por grace_period segundos (padrão 0, máximo 7 dias). A nova chave em claro é exibida somente nesta resposta.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenants"
+                ],
+                "summary": "Rotaciona uma API Key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API Key ID",
+                        "name": "key_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Período de carência da chave antiga",
+                        "name": "rotate",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/ApiKeyRotate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Nova API Key",
+                        "schema": {
+                            "$ref": "#/definitions/ApiKeyCreated"
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de Formato de Solicitação",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant ou API Key não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tenants/{id}/restore": {
+            "post": {
+                "description": "Restaura um Tenant removido. Se estiver ATIVO, a suspensão aplicada na remoção é desfeita; os usuários precisam logar novamente.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenants"
+                ],
+                "summary": "Restaura um Tenant da lixeira",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tenant restaurado",
+                        "schema": {
+                            "$ref": "#/definitions/Tenant"
+                        }
+                    },
+                    "400": {
+                        "description": "ID Inválido",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant não encontrado na lixeira",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Outro Tenant ativo usa o mesmo CPF/CNPJ ou e-mail",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users": {
+            "get": {
+                "description": "Lista os Users do tenant com filtros, ordenação e paginação. Filtros: campo=valor ou campo[op]=valor, com op in (valores separados por vírgula), like (contém), gte ou lte.\nCampos: name, username e email (eq, in, like), status (eq, in), created_at e updated_at (gte, lte).\nOrdenação por name, username, email, status, created_at e updated_at (prefixo - para decrescente). O header Link traz as páginas first, prev, next e last.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Lista os Users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Itens por página (1 a 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Itens a pular (não use com cursor)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor next_cursor da página anterior",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "name",
+                        "description": "Ordenação, ex.: -created_at,name",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Página de Users",
+                        "schema": {
+                            "$ref": "#/definitions/ListResponse-User"
+                        }
+                    },
+                    "400": {
+                        "description": "Filtro, ordenação ou paginação inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adiciona um novo User ao sistema. O User é criado PENDENTE e recebe por e-mail o link de verificação.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Cria um novo User",
+                "parameters": [
+                    {
+                        "description": "Informações do User",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UserCreate"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chave única da operação; as repetições com a mesma chave recebem a resposta original",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User Criado",
+                        "schema": {
+                            "$ref": "#/definitions/User"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versão do User"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de Formato de Solicitação",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Idempotency-Key já usada com outro conteúdo ou ainda em andamento",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "422": {
+                        "description": "Senha não atende à política",
+                        "schema": {
+                            "$ref": "#/definitions/PasswordPolicyErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/search": {
+            "get": {
+                "description": "Busca os Users do tenant por nome, username ou e-mail. Busca por prefixo, sem diferenciar acentos e maiúsculas, e tolera erros de digitação; os resultados vêm do mais ao menos relevante.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Busca Users por texto",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Termo de busca (2 a 100 caracteres)",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Máximo de resultados (1 a 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Users encontrados",
+                        "schema": {
+                            "$ref": "#/definitions/SearchResponse-User"
+                        }
+                    },
+                    "400": {
+                        "description": "Termo de busca inválido",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/trash": {
+            "get": {
+                "description": "Lista os Users removidos (exclusão lógica) do tenant, com os mesmos filtros de GET /api/v1/users, mais deleted_at (gte, lte). Por padrão, os removidos mais recentemente primeiro.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Lista os Users na lixeira",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Itens por página (1 a 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Itens a pular (não use com cursor)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor next_cursor da página anterior",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-deleted_at",
+                        "description": "Ordenação, ex.: -deleted_at",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Página de Users removidos",
+                        "schema": {
+                            "$ref": "#/definitions/ListResponse-User"
+                        }
+                    },
+                    "400": {
+                        "description": "Filtro, ordenação ou paginação inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}": {
+            "get": {
+                "description": "Busca User por ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Busca User por ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User",
+                        "schema": {
+                            "$ref": "#/definitions/User"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versão do User, para o If-Match de PUT, PATCH e DELETE"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid UUID format",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Atualiza um User existente com base no ID fornecido. Com If-Match (ETag do GET), a atualização só é feita se o User não tiver sido alterado desde então.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Atualiza um User existente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag da versão lida",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Dados do User",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User Atualizado",
+                        "schema": {
+                            "$ref": "#/definitions/User"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Nova versão do User"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ID Inválido ou Erro de Formato de Solicitação",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "412": {
+                        "description": "O User foi alterado depois da versão do If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Exclui um User com base no ID fornecido. Por padrão, o User vai para a lixeira e pode ser restaurado; com hard=true (política própria, em /api/v1/users/:id/purge), é removido definitivamente com os seus roles e políticas.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Exclui um User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Remove definitivamente",
+                        "name": "hard",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag da versão lida",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Mensagem de sucesso",
+                        "schema": {
+                            "$ref": "#/definitions/H"
+                        }
+                    },
+                    "400": {
+                        "description": "ID Inválido",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "412": {
+                        "description": "O User foi alterado depois da versão do If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Atualiza parcialmente um User existente com base no ID fornecido. Com If-Match (ETag do GET), a atualização só é feita se o User não tiver sido alterado desde então.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Atualiza parcialmente um User existente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag da versão lida",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Dados atualizáveis do User",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Mensagem de sucesso",
+                        "schema": {
+                            "$ref": "#/definitions/H"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Nova versão do User"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ID Inválido ou Erro de Formato de Solicitação",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "412": {
+                        "description": "O User foi alterado depois da versão do If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/disable": {
+            "post": {
+                "description": "Desativa o User sem removê-lo e encerra todas as suas sessões. O login passa a ser recusado com o código USER_DISABLED.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Desativa um User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User desativado",
+                        "schema": {
+                            "$ref": "#/definitions/User"
+                        }
+                    },
+                    "400": {
+                        "description": "ID Inválido",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/enable": {
+            "post": {
+                "description": "Reativa um User desativado (ou ativa um User pendente sem a verificação do e-mail)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Reativa um User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User ativo",
+                        "schema": {
+                            "$ref": "#/definitions/User"
+                        }
+                    },
+                    "400": {
+                        "description": "ID Inválido",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/policies": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Lista as políticas especiais do User, concedidas além das dos seus roles",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Lista as políticas do User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Políticas do User",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/PolicyUser"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ID Inválido",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Concede ao User as ações (GET, POST, PUT, PATCH, DELETE separados por |) sobre o endpoint. Vale apenas no tenant da requisição.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Cria uma política para o User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Endpoint e ações",
+                        "name": "policy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/PolicyInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Política criada",
+                        "schema": {
+                            "$ref": "#/definitions/PolicyUser"
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de Formato de Solicitação, ações ou condição inválidas",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Ações que o usuário não possui",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "User ou endpoint não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Política já existe",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/policies/{endpoint_id}": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Substitui as ações permitidas ao User sobre o endpoint",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Altera uma política do User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Endpoint ID",
+                        "name": "endpoint_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ações",
+                        "name": "policy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/PolicyActionsInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Política atualizada",
+                        "schema": {
+                            "$ref": "#/definitions/PolicyUser"
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de Formato de Solicitação, ações ou condição inválidas",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Ações que o usuário não possui",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "User ou política não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retira do User o acesso especial ao endpoint",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Remove uma política do User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Endpoint ID",
+                        "name": "endpoint_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Política removida",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ID Inválido",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "User ou política não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/restore": {
+            "post": {
+                "description": "Restaura um User removido do tenant, com os seus roles e políticas.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Restaura um User da lixeira",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User restaurado",
+                        "schema": {
+                            "$ref": "#/definitions/User"
+                        }
+                    },
+                    "400": {
+                        "description": "ID Inválido",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "User não encontrado na lixeira",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Outro User ativo usa o mesmo e-mail ou username",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/roles": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Lista os roles atribuídos ao User",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Lista os Roles do User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Roles do User",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Role"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ID Inválido",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Atribui o role ao User e encerra as sessões dele; o novo role vale a partir do próximo login",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Atribui um Role ao User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UserRoleInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Role atribuído",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de Formato de Solicitação",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Role global exige o role master",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "User ou Role não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Role já atribuído",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/roles/{role_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retira o role do User e encerra as sessões dele",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Retira um Role do User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Role ID",
+                        "name": "role_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Role retirado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ID Inválido",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Role global exige o role master",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "User não encontrado ou Role não atribuído",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/unlock": {
+            "post": {
+                "description": "Remove o bloqueio e o atraso causados por falhas de login do User",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Desbloqueia o login de um User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Mensagem de sucesso",
+                        "schema": {
+                            "$ref": "#/definitions/H"
+                        }
+                    },
+                    "400": {
+                        "description": "ID Inválido",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/verification-email": {
+            "post": {
+                "description": "Gera um novo link de verificação de e-mail para o User e invalida o anterior",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Reenvia a verificação de e-mail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Mensagem de sucesso",
+                        "schema": {
+                            "$ref": "#/definitions/H"
+                        }
+                    },
+                    "400": {
+                        "description": "ID Inválido",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "E-mail já verificado",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro Interno do Servidor",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "ApiKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "ApiKeyCreate": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "scopes": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "ApiKeyCreated": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "ApiKeyRotate": {
+            "type": "object",
+            "properties": {
+                "grace_period": {
+                    "type": "integer",
+                    "maximum": 604800,
+                    "minimum": 0
+                }
+            }
+        },
+        "ApiKeyUpdate": {
+            "type": "object",
+            "required": [
+                "scopes"
+            ],
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "scopes": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "AuthzExplainInput": {
+            "type": "object",
+            "required": [
+                "method",
+                "path",
+                "user_id"
+            ],
+            "properties": {
+                "method": {
+                    "type": "string",
+                    "example": "GET"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/api/v1/users/42"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "AuthzExplanation": {
+            "type": "object",
+            "properties": {
+                "allowed": {
+                    "type": "boolean"
+                },
+                "candidates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/AuthzRule"
+                    }
+                },
+                "domain": {
+                    "type": "string"
+                },
+                "matched_rule": {
+                    "$ref": "#/definitions/AuthzRule"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "AuthzRule": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "type": "string"
+                },
+                "condition": {
+                    "type": "string"
+                },
+                "domain": {
+                    "type": "string"
+                },
+                "endpoint": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                }
+            }
+        },
+        "ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string",
+                    "minLength": 8
+                }
+            }
+        },
+        "DeletedAt": {
+            "type": "object",
+            "properties": {
+                "time": {
+                    "type": "string"
+                },
+                "valid": {
+                    "description": "Valid is true if Time is not NULL",
+                    "type": "boolean"
+                }
             }
         },
         "Endpoint": {
@@ -707,13 +4357,165 @@ const docTemplate = `{
                 }
             }
         },
-        "H": {
+        "EndpointInput": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 254
+                }
+            }
+        },
+        "EndpointPermission": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "GET",
+                        "PUT"
+                    ]
+                },
+                "endpoint": {
+                    "type": "string",
+                    "example": "/api/v1/users/:id"
+                }
+            }
+        },
+        "ForgotPasswordRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "H": {
+            "type": "object",
+            "additionalProperties": {}
+        },
+        "HTTPError": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string"
+                },
+                "crv": {
+                    "type": "string"
+                },
+                "e": {
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string"
+                },
+                "kty": {
+                    "type": "string"
+                },
+                "n": {
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string"
+                },
+                "x": {
+                    "type": "string"
+                }
+            }
+        },
+        "JWKS": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/JWK"
+                    }
+                }
+            }
+        },
+        "ListResponse-Tenant": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Tenant"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/Pagination"
+                }
+            }
+        },
+        "ListResponse-User": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/User"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/Pagination"
+                }
+            }
+        },
+        "Pagination": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "PasswordPolicyErrorResponse": {
             "type": "object",
-            "additionalProperties": {}
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PasswordViolation"
+                    }
+                }
+            }
         },
-        "HTTPError": {
+        "PasswordViolation": {
             "type": "object",
             "properties": {
+                "code": {
+                    "type": "string"
+                },
                 "message": {
                     "type": "string"
                 }
@@ -730,6 +4532,46 @@ const docTemplate = `{
                 "Juridica"
             ]
         },
+        "PolicyActionsInput": {
+            "type": "object",
+            "required": [
+                "actions"
+            ],
+            "properties": {
+                "actions": {
+                    "type": "string",
+                    "maxLength": 36,
+                    "example": "GET|POST|PUT|PATCH|DELETE"
+                },
+                "condition": {
+                    "type": "string",
+                    "maxLength": 254,
+                    "example": "param.id == user.id"
+                }
+            }
+        },
+        "PolicyInput": {
+            "type": "object",
+            "required": [
+                "actions",
+                "endpoint_id"
+            ],
+            "properties": {
+                "actions": {
+                    "type": "string",
+                    "maxLength": 36,
+                    "example": "GET|POST"
+                },
+                "condition": {
+                    "type": "string",
+                    "maxLength": 254,
+                    "example": "param.id == user.id"
+                },
+                "endpoint_id": {
+                    "type": "integer"
+                }
+            }
+        },
         "PolicyRole": {
             "type": "object",
             "required": [
@@ -741,20 +4583,16 @@ const docTemplate = `{
                 "actions": {
                     "type": "string"
                 },
+                "condition": {
+                    "description": "Condição ABAC opcional, ex.: param.id == user.id",
+                    "type": "string"
+                },
                 "endpoint": {
                     "$ref": "#/definitions/Endpoint"
                 },
                 "endpoint_id": {
                     "type": "integer"
                 },
-                "role": {
-                    "description": "// constraints",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/Role"
-                        }
-                    ]
-                },
                 "role_id": {
                     "type": "integer"
                 }
@@ -771,25 +4609,48 @@ const docTemplate = `{
                 "actions": {
                     "type": "string"
                 },
+                "condition": {
+                    "description": "Condição ABAC opcional, ex.: param.id == user.id",
+                    "type": "string"
+                },
                 "endpoint": {
                     "$ref": "#/definitions/Endpoint"
                 },
                 "endpoint_id": {
                     "type": "integer"
                 },
-                "user": {
-                    "description": "// constraints",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/User"
-                        }
-                    ]
-                },
                 "user_id": {
                     "type": "string"
                 }
             }
         },
+        "RecoveryCodesResponse": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "ResetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "token"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "minLength": 8
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
         "Role": {
             "type": "object",
             "required": [
@@ -801,7 +4662,6 @@ const docTemplate = `{
                     "type": "integer"
                 },
                 "name": {
-                    "description": "Nome do recurso, único e não nulo",
                     "type": "string"
                 },
                 "policies": {
@@ -809,6 +4669,72 @@ const docTemplate = `{
                     "items": {
                         "$ref": "#/definitions/PolicyRole"
                     }
+                },
+                "tenant_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "RoleInput": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "global": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 36
+                }
+            }
+        },
+        "SearchResponse-Tenant": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Tenant"
+                    }
+                }
+            }
+        },
+        "SearchResponse-User": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/User"
+                    }
+                }
+            }
+        },
+        "SessionInfo": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "current": {
+                    "type": "boolean"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip_address": {
+                    "type": "string"
+                },
+                "refreshed_at": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
                 }
             }
         },
@@ -823,6 +4749,17 @@ const docTemplate = `{
                 "Inativo"
             ]
         },
+        "TOTPEnrollment": {
+            "type": "object",
+            "properties": {
+                "provisioning_uri": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                }
+            }
+        },
         "Tenant": {
             "type": "object",
             "required": [
@@ -838,6 +4775,7 @@ const docTemplate = `{
                     }
                 },
                 "api_key": {
+                    "description": "Preenchida apenas na resposta de criação",
                     "type": "string"
                 },
                 "cell_phone": {
@@ -896,12 +4834,20 @@ const docTemplate = `{
                 },
                 "updated_at": {
                     "type": "string"
+                },
+                "version": {
+                    "description": "Version é incrementada pelo banco a cada alteração do registro e vai no header ETag",
+                    "type": "integer"
                 }
             }
         },
         "TenantRedis": {
             "type": "object",
             "properties": {
+                "api_key_id": {
+                    "description": "ApiKeyID e Scopes identificam a chave usada na requisição.",
+                    "type": "string"
+                },
                 "cpfcnpj": {
                     "type": "string"
                 },
@@ -913,6 +4859,87 @@ const docTemplate = `{
                 },
                 "name": {
                     "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "Token": {
+            "type": "object",
+            "properties": {
+                "policies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "refreshToken": {
+                    "type": "string"
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "token": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/TokenUser"
+                }
+            }
+        },
+        "TokenUser": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "thumbnail": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "TwoFactorCodeRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "TwoFactorLoginRequest": {
+            "type": "object",
+            "required": [
+                "challenge_token",
+                "code"
+            ],
+            "properties": {
+                "challenge_token": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
                 }
             }
         },
@@ -928,6 +4955,9 @@ const docTemplate = `{
                 "email": {
                     "type": "string"
                 },
+                "email_verified_at": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
@@ -946,6 +4976,9 @@ const docTemplate = `{
                         "$ref": "#/definitions/Role"
                     }
                 },
+                "status": {
+                    "$ref": "#/definitions/UserStatus"
+                },
                 "tenant": {
                     "description": "constraints",
                     "allOf": [
@@ -957,11 +4990,18 @@ const docTemplate = `{
                 "thumbnail": {
                     "type": "string"
                 },
+                "totp_enabled": {
+                    "type": "boolean"
+                },
                 "updated_at": {
                     "type": "string"
                 },
                 "username": {
                     "type": "string"
+                },
+                "version": {
+                    "description": "Version é incrementada pelo banco a cada alteração do registro e vai no header ETag",
+                    "type": "integer"
                 }
             }
         },
@@ -984,6 +5024,77 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "UserPermissions": {
+            "type": "object",
+            "properties": {
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/EndpointPermission"
+                    }
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "UserProfileUpdate": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 254,
+                    "minLength": 1
+                },
+                "thumbnail": {
+                    "type": "string",
+                    "maxLength": 70
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 80,
+                    "minLength": 1
+                }
+            }
+        },
+        "UserRoleInput": {
+            "type": "object",
+            "required": [
+                "role_id"
+            ],
+            "properties": {
+                "role_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "UserStatus": {
+            "type": "string",
+            "enum": [
+                "PENDENTE",
+                "ATIVO",
+                "DESATIVADO"
+            ],
+            "x-enum-varnames": [
+                "UserPendente",
+                "UserAtivo",
+                "UserDesativado"
+            ]
+        },
+        "VerifyEmailRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
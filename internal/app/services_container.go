@@ -5,25 +5,36 @@ package app
 import (
 	"log"
 	"os"
-	"time"
 
 	// Import correto
 	"github.com/jeancarlosdanese/go-base-api/internal/db"
+	"github.com/jeancarlosdanese/go-base-api/internal/mailer"
 	"github.com/jeancarlosdanese/go-base-api/internal/repositories"
 	"github.com/jeancarlosdanese/go-base-api/internal/services"
+	"github.com/jeancarlosdanese/go-base-api/internal/settings"
 	"github.com/joho/godotenv"
 	"gorm.io/gorm"
 )
 
 type ServicesContainer struct {
-	CasbinService      services.CasbinServiceInterface
-	TokenService       services.TokenServiceInterface
-	TenantService      services.TenantServiceInterface
-	UserService        services.UserServiceInterface
-	RedisService       services.RedisServiceInterface
-	TokenRedisService  services.TokenRedisServiceInterface
-	ApiKeyRedisService services.ApiKeyRedisServiceInterface
-	DB                 *gorm.DB
+	Config               *settings.Config
+	CasbinService        services.CasbinServiceInterface
+	TokenService         services.TokenServiceInterface
+	TenantService        services.TenantServiceInterface
+	UserService          services.UserServiceInterface
+	RedisService         services.RedisServiceInterface
+	TokenRedisService    services.TokenRedisServiceInterface
+	ApiKeyService        services.ApiKeyServiceInterface
+	ApiKeyRedisService   services.ApiKeyRedisServiceInterface
+	PasswordResetService services.PasswordResetServiceInterface
+	TwoFactorService     services.TwoFactorServiceInterface
+	LoginAttemptService  services.LoginAttemptServiceInterface
+	TenantStatusService  services.TenantStatusServiceInterface
+	UserStatusService    services.UserStatusServiceInterface
+	SecurityService      services.SecurityServiceInterface
+	DB                   *gorm.DB
+
+	casbinService *services.CasbinService
 }
 
 func NewServicesContainer() (*ServicesContainer, error) {
@@ -36,39 +47,133 @@ func NewServicesContainer() (*ServicesContainer, error) {
 		log.Printf("Warning: %s file not found, using default values", envFile)
 	}
 
-	gormDB, err := db.NewDatabaseConnection()
+	cfg, err := settings.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	gormDB, err := db.NewDatabaseConnection(cfg.Database)
 	if err != nil {
 		return nil, err
 	}
 
 	// Inicializa o Redis
-	db.InitializeRedis()
+	db.InitializeRedis(cfg.Redis)
 	redisService := services.NewRedisService()
 	tokenRedisService := services.NewTokenRedisService(redisService)
 
-	casbinService, err := services.NewCasbinService(gormDB)
+	// O watcher propaga as alterações de políticas entre as instâncias da API.
+	casbinWatcher, err := services.NewCasbinWatcher(db.GetRedisClient(), cfg.Casbin.WatcherChannel)
+	if err != nil {
+		return nil, err
+	}
+	casbinService, err := services.NewCasbinService(gormDB, casbinWatcher, cfg.Casbin.ReloadInterval.Duration)
 	if err != nil {
+		casbinWatcher.Close()
 		return nil, err
 	}
 
-	tokenService := services.NewTokenService(os.Getenv("JWT_SECRET_KEY"), time.Hour*24, time.Hour*24*90)
+	tokenSigner, err := services.NewTokenSignerFromFiles(
+		cfg.Auth.JWT.SecretKey,
+		cfg.Auth.JWT.PrivateKeyFile,
+		cfg.Auth.JWT.KeyID,
+		cfg.Auth.JWT.VerificationKeys,
+	)
+	if err != nil {
+		return nil, err
+	}
 
-	tenantsRepo := repositories.NewTenantRepository(gormDB)
-	tenantService := services.NewTenantService(tenantsRepo)
+	tokenService := services.NewTokenService(tokenSigner, cfg.Auth.AccessTokenTTL.Duration, cfg.Auth.RefreshTokenTTL.Duration, services.TokenClaimsConfig{
+		Issuer:   cfg.Auth.JWT.Issuer,
+		Audience: cfg.Auth.JWT.Audience,
+		Leeway:   cfg.Auth.JWT.Leeway.Duration,
+	})
 
-	apiKeyRedisService := services.NewApiKeyRedisService(tenantService, redisService, time.Hour*24)
+	apiKeysRepo := repositories.NewApiKeyRepository(gormDB)
+	apiKeyService := services.NewApiKeyService(apiKeysRepo, redisService)
 
 	usersRepo := repositories.NewUserRepository(gormDB)
-	userService := services.NewUserService(usersRepo)
+	tenantStatusService := services.NewTenantStatusService(usersRepo, redisService, tokenRedisService)
+
+	tenantsRepo := repositories.NewTenantRepository(gormDB)
+	tenantService := services.NewTenantService(tenantsRepo, apiKeyService, tenantStatusService)
+	if err := tenantService.SyncSuspendedTenants(); err != nil {
+		return nil, err
+	}
+
+	securityRepo := repositories.NewSecurityRepository(gormDB)
+	securityService := services.NewSecurityService(securityRepo, casbinService, tokenRedisService)
+
+	apiKeyRedisService := services.NewApiKeyRedisService(apiKeyService, redisService, cfg.Auth.ApiKeyCacheTTL.Duration)
+
+	var breachedChecker services.BreachedPasswordChecker
+	if cfg.Auth.PasswordPolicy.BreachedPasswordsDir != "" {
+		breachedChecker = services.NewPwnedRangeDirChecker(cfg.Auth.PasswordPolicy.BreachedPasswordsDir)
+	}
+	passwordPolicyConfig := services.PasswordPolicyConfig{
+		MinLength:     cfg.Auth.PasswordPolicy.MinLength,
+		RequireUpper:  cfg.Auth.PasswordPolicy.RequireUpper,
+		RequireLower:  cfg.Auth.PasswordPolicy.RequireLower,
+		RequireDigit:  cfg.Auth.PasswordPolicy.RequireDigit,
+		RequireSymbol: cfg.Auth.PasswordPolicy.RequireSymbol,
+	}
+	if cfg.Auth.PasswordHashAlgorithm == services.PasswordAlgorithmBcrypt {
+		passwordPolicyConfig.MaxBytes = services.BcryptMaxPasswordBytes
+	}
+	passwordPolicy := services.NewPasswordPolicy(passwordPolicyConfig, breachedChecker)
+	passwordHasher := services.NewPasswordHasher(services.PasswordHasherConfig{
+		Algorithm:  cfg.Auth.PasswordHashAlgorithm,
+		BcryptCost: cfg.Auth.BcryptCost,
+		Argon2id: services.Argon2idParams{
+			Memory:      uint32(cfg.Auth.Argon2id.MemoryKiB),
+			Iterations:  uint32(cfg.Auth.Argon2id.Iterations),
+			Parallelism: uint8(cfg.Auth.Argon2id.Parallelism),
+		},
+	})
+	userService := services.NewUserService(usersRepo, passwordHasher, passwordPolicy, casbinService, tokenRedisService)
+
+	twoFactorService := services.NewTwoFactorService(usersRepo, redisService, cfg.Auth.TOTPIssuer, cfg.Auth.TwoFactorChallengeTTL.Duration)
+
+	loginAttemptService := services.NewLoginAttemptService(redisService, services.LoginAttemptConfig{
+		MaxFailures:       cfg.Auth.Login.MaxFailures,
+		FailureWindow:     cfg.Auth.Login.FailureWindow.Duration,
+		LockoutDuration:   cfg.Auth.Login.LockoutDuration.Duration,
+		BaseDelay:         cfg.Auth.Login.BaseDelay.Duration,
+		MaxDelay:          cfg.Auth.Login.MaxDelay.Duration,
+		OriginMaxFailures: cfg.Auth.Login.OriginMaxFailures,
+	})
+
+	appMailer, err := mailer.NewMailer(cfg.Mailer)
+	if err != nil {
+		return nil, err
+	}
+	passwordResetService := services.NewPasswordResetService(userService, redisService, tokenRedisService, appMailer, cfg.Auth.PasswordResetTTL.Duration, cfg.Auth.PasswordResetURL)
+	userStatusService := services.NewUserStatusService(usersRepo, redisService, tokenRedisService, appMailer, cfg.Auth.EmailVerificationTTL.Duration, cfg.Auth.EmailVerificationURL)
 
 	return &ServicesContainer{
-		CasbinService:      casbinService,
-		TokenService:       tokenService,
-		TenantService:      tenantService,
-		UserService:        userService,
-		RedisService:       redisService,
-		TokenRedisService:  tokenRedisService,
-		ApiKeyRedisService: apiKeyRedisService,
-		DB:                 gormDB,
+		Config:               cfg,
+		CasbinService:        casbinService,
+		TokenService:         tokenService,
+		TenantService:        tenantService,
+		UserService:          userService,
+		RedisService:         redisService,
+		TokenRedisService:    tokenRedisService,
+		ApiKeyService:        apiKeyService,
+		ApiKeyRedisService:   apiKeyRedisService,
+		PasswordResetService: passwordResetService,
+		TwoFactorService:     twoFactorService,
+		LoginAttemptService:  loginAttemptService,
+		TenantStatusService:  tenantStatusService,
+		UserStatusService:    userStatusService,
+		SecurityService:      securityService,
+		DB:                   gormDB,
+		casbinService:        casbinService,
 	}, nil
 }
+
+// Close libera os recursos em segundo plano do container, como o watcher e a recarga periódica do Casbin.
+func (sc *ServicesContainer) Close() {
+	if sc.casbinService != nil {
+		sc.casbinService.Close()
+	}
+}
@@ -5,8 +5,8 @@ package db
 import (
 	"fmt"
 	"log"
-	"os"
 
+	"github.com/jeancarlosdanese/go-base-api/internal/settings"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -14,13 +14,14 @@ import (
 
 // NewDatabaseConnection cria e retorna uma nova conexão do banco de dados usando GORM.
 // Esta função pode ser usada pelo Wire para injeção de dependência.
-func NewDatabaseConnection() (*gorm.DB, error) {
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=America/Sao_Paulo",
-		os.Getenv("DB_HOST"),
-		os.Getenv("DB_USER"),
-		os.Getenv("DB_PASSWORD"),
-		os.Getenv("DB_NAME"),
-		os.Getenv("DB_PORT"),
+func NewDatabaseConnection(cfg settings.DatabaseConfig) (*gorm.DB, error) {
+	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=%s",
+		cfg.Host,
+		cfg.User,
+		cfg.Password,
+		cfg.Name,
+		cfg.Port,
+		cfg.TimeZone,
 	)
 
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
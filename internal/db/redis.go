@@ -6,32 +6,26 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"os"
-	"strconv" // Importe strconv para usar Atoi
 
+	"github.com/jeancarlosdanese/go-base-api/internal/settings"
 	"github.com/redis/go-redis/v9"
 )
 
 var redisClient *redis.Client
 
 // InitializeRedis configura e inicializa a conexão Redis.
-func InitializeRedis() {
-	addr := fmt.Sprintf("%s:%s", os.Getenv("REDIS_HOST"), os.Getenv("REDIS_PORT"))
-	redisDBStr := os.Getenv("REDIS_DB")      // Obtém a variável de ambiente como uma string
-	redisDB, err := strconv.Atoi(redisDBStr) // Converte de string para int
-	if err != nil {
-		log.Fatalf("ERROR: Não foi possível converter REDIS_DB para int: %v", err)
-	}
+func InitializeRedis(cfg settings.RedisConfig) {
+	addr := fmt.Sprintf("%s:%s", cfg.Host, cfg.Port)
 
 	redisClient = redis.NewClient(&redis.Options{
 		Addr:     addr,
-		Password: os.Getenv("REDIS_PASSWORD"), // Senha, se necessário. Deixe vazio se não houver senha.
-		DB:       redisDB,                     // Usa a conversão para definir o DB.
-		PoolSize: 10,                          // Tamanho do pool de conexões.
+		Password: cfg.Password, // Senha, se necessário. Deixe vazio se não houver senha.
+		DB:       cfg.DB,
+		PoolSize: cfg.PoolSize, // Tamanho do pool de conexões.
 	})
 
 	ctx := context.Background()
-	_, err = redisClient.Ping(ctx).Result()
+	_, err := redisClient.Ping(ctx).Result()
 	if err != nil {
 		log.Fatalf("ERROR: Não foi possível conectar ao Redis: %v", err)
 	}
@@ -0,0 +1,40 @@
+// internal/domain/auth_errors/auth_errors.go
+
+package autherrors
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Erros de autenticação compartilhados entre repositórios, serviços e handlers.
+// Use errors.Is para identificá-los; as mensagens podem ser envolvidas com contexto adicional.
+var (
+	ErrUserOrOriginNotFound = errors.New("usuário ou origem não encontrado")
+	ErrInvalidPassword      = errors.New("senha inválida")
+	// ErrAccountLocked indica que a conta foi bloqueada temporariamente após falhas consecutivas de login.
+	ErrAccountLocked = errors.New("conta temporariamente bloqueada")
+	// ErrTooManyLoginAttempts indica que o login está em espera (atraso progressivo) após falhas recentes.
+	ErrTooManyLoginAttempts = errors.New("muitas tentativas de login")
+	// ErrTenantSuspended indica que o tenant do usuário ou da API Key está inativo (suspenso).
+	ErrTenantSuspended = errors.New("tenant suspenso")
+	// ErrUserPending indica que o usuário ainda não verificou o e-mail.
+	ErrUserPending = errors.New("usuário pendente de verificação de e-mail")
+	// ErrUserDisabled indica que o usuário foi desativado por um administrador.
+	ErrUserDisabled = errors.New("usuário desativado")
+)
+
+// LoginBlockedError envolve ErrAccountLocked ou ErrTooManyLoginAttempts com o tempo até a próxima tentativa permitida.
+type LoginBlockedError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *LoginBlockedError) Error() string {
+	return fmt.Sprintf("%v: tente novamente em %s", e.Err, e.RetryAfter.Round(time.Second))
+}
+
+func (e *LoginBlockedError) Unwrap() error {
+	return e.Err
+}
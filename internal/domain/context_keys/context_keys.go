@@ -15,6 +15,8 @@ const (
 	TenantIDKey   ContextKey = "TenantID"
 	UserDataKey   ContextKey = "UserData"
 	TenantDataKey ContextKey = "TenantData"
+	// IfMatchKey guarda as versões aceitas pelo header If-Match ([]int) das requisições PUT, PATCH e DELETE
+	IfMatchKey ContextKey = "IfMatch"
 )
 
 // ValidContextKeys mapeia as ações válidas para validação rápida.
@@ -22,6 +24,7 @@ var ValidContextKeys = map[ContextKey]bool{
 	TenantIDKey:   true,
 	UserDataKey:   true,
 	TenantDataKey: true,
+	IfMatchKey:    true,
 }
 
 // validateContextKey verifica se o valor do ContextKey é um dos definidos como válidos.
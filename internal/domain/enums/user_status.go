@@ -0,0 +1,48 @@
+// internal/domain/enums/user_status.go
+
+package enums
+
+import (
+	"log"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// UserStatus define os status possíveis de um usuário.
+// @name UserStatus
+type UserStatus string
+
+const (
+	// UserPendente aguarda a verificação do e-mail; não pode logar.
+	UserPendente UserStatus = "PENDENTE"
+	UserAtivo    UserStatus = "ATIVO"
+	// UserDesativado foi desativado por um administrador; o registro e o histórico são mantidos.
+	UserDesativado UserStatus = "DESATIVADO"
+)
+
+// ValidUserStatuses fornece uma maneira de validar o UserStatus.
+var ValidUserStatuses = map[UserStatus]bool{
+	UserPendente:   true,
+	UserAtivo:      true,
+	UserDesativado: true,
+}
+
+// validateUserStatus verifica se o valor do UserStatus é um dos definidos como válidos.
+func validateUserStatus(fl validator.FieldLevel) bool {
+	status, ok := fl.Field().Interface().(UserStatus)
+	if !ok {
+		log.Printf("Error: invalid data type for UserStatus field")
+		return false
+	}
+	if _, exists := ValidUserStatuses[status]; exists {
+		return true
+	}
+	log.Printf("Validation failed: %s is not a valid UserStatus", status)
+	return false
+}
+
+// IsValid verifica se o valor de UserStatus é válido.
+func (s UserStatus) IsValid() bool {
+	_, ok := ValidUserStatuses[s]
+	return ok
+}
@@ -18,4 +18,5 @@ func Initialize() {
 	Validator.RegisterValidation("actionName", validateActionType)
 	Validator.RegisterValidation("personType", validatePersonType)
 	Validator.RegisterValidation("statusType", validateStatusType)
+	Validator.RegisterValidation("userStatus", validateUserStatus)
 }
@@ -0,0 +1,63 @@
+// internal/domain/models/api_key_model.go
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// ApiKey é uma chave de acesso de um Tenant para o header X-API-Key. A chave em claro nunca é armazenada:
+// apenas o hash SHA-256 e um prefixo curto, que serve para identificá-la nas listagens.
+// @name ApiKey
+type ApiKey struct {
+	ID         uuid.UUID                   `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TenantID   uuid.UUID                   `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	Name       string                      `gorm:"type:varchar(100);not null" json:"name"`
+	Prefix     string                      `gorm:"type:varchar(16);not null" json:"prefix"`
+	KeyHash    string                      `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"`
+	Scopes     datatypes.JSONSlice[string] `gorm:"type:jsonb;not null;default:'[]'" json:"scopes" swaggertype:"array,string"`
+	ExpiresAt  *time.Time                  `gorm:"type:timestamptz" json:"expires_at"`
+	LastUsedAt *time.Time                  `gorm:"type:timestamptz" json:"last_used_at"`
+	RevokedAt  *time.Time                  `gorm:"type:timestamptz" json:"revoked_at"`
+	CreatedAt  time.Time                   `gorm:"type:timestamptz;default:now()" json:"created_at"`
+	UpdatedAt  time.Time                   `gorm:"type:timestamptz;default:now()" json:"updated_at"`
+	Tenant     *Tenant                     `gorm:"foreignKey:TenantID" json:"-"`
+}
+
+// IsActive indica se a chave ainda pode ser usada: não revogada e não expirada.
+func (k *ApiKey) IsActive(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || k.ExpiresAt.After(now))
+}
+
+// ApiKeyCreate são os dados para criar uma chave.
+// @name ApiKeyCreate
+type ApiKeyCreate struct {
+	Name      string     `json:"name" binding:"required,max=100"`
+	Scopes    []string   `json:"scopes" binding:"omitempty,max=50,dive,required,max=100"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// ApiKeyUpdate são os campos alteráveis de uma chave. Campos ausentes não são alterados.
+// @name ApiKeyUpdate
+type ApiKeyUpdate struct {
+	Name      *string    `json:"name" binding:"omitempty,min=1,max=100"`
+	Scopes    *[]string  `json:"scopes" binding:"omitempty,max=50,dive,required,max=100"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// ApiKeyRotate define por quanto tempo, em segundos, a chave antiga continua válida após a rotação.
+// Com zero (padrão), a chave antiga é revogada imediatamente.
+// @name ApiKeyRotate
+type ApiKeyRotate struct {
+	GracePeriod int `json:"grace_period" binding:"min=0,max=604800"`
+}
+
+// ApiKeyCreated traz a chave em claro, que é exibida uma única vez, na criação ou na rotação.
+// @name ApiKeyCreated
+type ApiKeyCreated struct {
+	ApiKey
+	Key string `json:"key"`
+}
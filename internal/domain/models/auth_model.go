@@ -2,6 +2,8 @@
 
 package models
 
+import "time"
+
 // LoginForm representa os dados de entrada para o login do usuário.
 type LoginForm struct {
 	Email    string `form:"email" binding:"required,email"`
@@ -28,3 +30,63 @@ type TokenUser struct {
 type RefreshTokenRequest struct {
 	RefreshToken string `form:"refreshToken" binding:"required"`
 }
+
+// ForgotPasswordRequest representa o pedido de redefinição de senha.
+type ForgotPasswordRequest struct {
+	Email string `form:"email" json:"email" binding:"required,email"`
+}
+
+// ResetPasswordRequest representa a redefinição de senha com o token recebido por e-mail.
+type ResetPasswordRequest struct {
+	Token    string `form:"token" json:"token" binding:"required"`
+	Password string `form:"password" json:"password" binding:"required,min=8"`
+}
+
+// VerifyEmailRequest representa a confirmação do e-mail com o token recebido no link de verificação.
+type VerifyEmailRequest struct {
+	Token string `form:"token" json:"token" binding:"required"`
+}
+
+// UserSession representa uma sessão de login (par access/refresh token) armazenada no Redis.
+type UserSession struct {
+	ID           string    `json:"id"`
+	UserID       string    `json:"user_id"`
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	IPAddress    string    `json:"ip_address"`
+	UserAgent    string    `json:"user_agent"`
+	CreatedAt    time.Time `json:"created_at"`
+	RefreshedAt  time.Time `json:"refreshed_at"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// SessionInfo é a visão pública de uma sessão, sem os tokens.
+// @name SessionInfo
+type SessionInfo struct {
+	ID          string    `json:"id"`
+	IPAddress   string    `json:"ip_address"`
+	UserAgent   string    `json:"user_agent"`
+	CreatedAt   time.Time `json:"created_at"`
+	RefreshedAt time.Time `json:"refreshed_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	Current     bool      `json:"current"`
+}
+
+// JWK representa uma chave pública de verificação no formato JSON Web Key (RFC 7517).
+// @name JWK
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS representa o conjunto de chaves publicado em /.well-known/jwks.json.
+// @name JWKS
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
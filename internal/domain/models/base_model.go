@@ -3,6 +3,7 @@
 package models
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,8 +16,13 @@ type BaseModel struct {
 	CreatedAt time.Time       `gorm:"type:timestamptz;default:now()" json:"created_at"`
 	UpdatedAt time.Time       `gorm:"type:timestamptz;default:now()" json:"updated_at"`
 	DeletedAt *gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+	// Version é incrementada pelo banco a cada alteração do registro e vai no header ETag
+	Version int `gorm:"not null;default:1" json:"version"`
 }
 
+// ErrVersionMismatch indica que o registro foi alterado (ou removido) depois da versão informada no If-Match.
+var ErrVersionMismatch = errors.New("o registro foi alterado por outra requisição; busque a versão atual e tente novamente")
+
 // HTTPError representa um erro HTTP
 // @name HTTPError
 type HTTPError struct {
@@ -0,0 +1,104 @@
+// internal/domain/models/list_query_model.go
+
+package models
+
+import (
+	"errors"
+	"maps"
+)
+
+// ErrInvalidCursor indica um cursor que não corresponde à ordenação da listagem.
+var ErrInvalidCursor = errors.New("cursor inválido para esta ordenação")
+
+// FilterOp é um operador de filtro das listagens.
+type FilterOp string
+
+const (
+	FilterEq   FilterOp = "eq"   // campo=valor
+	FilterIn   FilterOp = "in"   // campo[in]=a,b
+	FilterLike FilterOp = "like" // campo[like]=texto (contém, sem diferenciar maiúsculas)
+	FilterGte  FilterOp = "gte"  // campo[gte]=valor
+	FilterLte  FilterOp = "lte"  // campo[lte]=valor
+)
+
+// ListField descreve um campo que pode ser usado nos filtros e na ordenação de uma listagem.
+// Somente os campos declarados no ListSpec da entidade são aceitos.
+type ListField struct {
+	Column   string
+	Ops      []FilterOp
+	Sortable bool     // apenas colunas sempre preenchidas, pois a paginação por cursor compara os valores
+	Time     bool     // valores em RFC 3339 ou AAAA-MM-DD
+	Values   []string // valores aceitos (enums); vazio aceita qualquer valor
+}
+
+// ListSpec é a lista de campos permitidos de uma entidade e a sua ordenação padrão (ex.: "name" ou "-created_at").
+type ListSpec struct {
+	Fields      map[string]ListField
+	DefaultSort string
+}
+
+// Trash devolve o spec da lixeira: os mesmos campos, mais deleted_at, com os removidos mais recentemente primeiro.
+func (s ListSpec) Trash() ListSpec {
+	fields := maps.Clone(s.Fields)
+	fields["deleted_at"] = ListField{Column: "deleted_at", Ops: []FilterOp{FilterGte, FilterLte}, Sortable: true, Time: true}
+	return ListSpec{Fields: fields, DefaultSort: "-deleted_at"}
+}
+
+// ListFilter é um filtro já validado contra o ListSpec.
+type ListFilter struct {
+	Column string
+	Op     FilterOp
+	Values []string
+}
+
+// ListSort é uma coluna da ordenação.
+type ListSort struct {
+	Column string
+	Desc   bool
+}
+
+// ListQuery é a consulta de uma listagem: filtros, ordenação e paginação por offset ou por cursor.
+// Cursor são os valores das colunas de Sort (e do id, que desempata) do último item da página anterior;
+// quando informado, Offset é ignorado.
+type ListQuery struct {
+	Filters []ListFilter
+	Sort    []ListSort
+	Limit   int
+	Offset  int
+	Cursor  []string
+}
+
+// Page é uma página de uma listagem. Total conta todos os itens que atendem aos filtros; NextCursor fica
+// vazio na última página.
+type Page[Entity any] struct {
+	Items      []Entity
+	Total      int64
+	NextCursor []string
+}
+
+// Pagination são os metadados de paginação das respostas de listagem.
+// @name Pagination
+type Pagination struct {
+	Total      int64  `json:"total"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// ListResponse é o envelope das respostas de listagem.
+type ListResponse[Entity any] struct {
+	Data       []Entity   `json:"data"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// SearchInput são os parâmetros das buscas textuais de tenants e usuários.
+// @name SearchInput
+type SearchInput struct {
+	Q     string `form:"q" binding:"required,min=2,max=100"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+// SearchResponse é o envelope das respostas de busca, da mais à menos relevante.
+type SearchResponse[Entity any] struct {
+	Data []Entity `json:"data"`
+}
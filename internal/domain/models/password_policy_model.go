@@ -0,0 +1,17 @@
+// internal/domain/models/password_policy_model.go
+
+package models
+
+// PasswordViolation descreve uma regra da política de senha que não foi atendida.
+// @name PasswordViolation
+type PasswordViolation struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// PasswordPolicyErrorResponse é a resposta 422 para senhas que não atendem à política.
+// @name PasswordPolicyErrorResponse
+type PasswordPolicyErrorResponse struct {
+	Error      string              `json:"error"`
+	Violations []PasswordViolation `json:"violations"`
+}
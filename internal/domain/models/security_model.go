@@ -12,22 +12,36 @@ type Endpoint struct {
 	Name string `gorm:"type:varchar(254);not null;unique" validate:"required" json:"name"` // Nome do recurso, único e não nulo
 }
 
-// Role representa um papel no sistema.
+// Role representa um papel no sistema. Roles sem tenant (TenantID nulo) são globais e valem em todos os tenants;
+// os demais pertencem a um tenant e só existem nele. O nome é único entre os globais e dentro de cada tenant.
 type Role struct {
-	ID   uint   `gorm:"primarykey" validate:"required" json:"id"`
-	Name string `gorm:"type:varchar(36);not null;unique" validate:"required" json:"name"` // Nome do recurso, único e não nulo
+	ID       uint       `gorm:"primarykey" validate:"required" json:"id"`
+	TenantID *uuid.UUID `gorm:"type:uuid" json:"tenant_id"`
+	Name     string     `gorm:"type:varchar(36);not null" validate:"required" json:"name"`
 
 	Policies []*PolicyRole `gorm:"many2many:policies_roles;" json:"policies,omitempty"`
 }
 
+// GlobalDomain é o domínio do Casbin das políticas e atribuições de roles globais.
+const GlobalDomain = "*"
+
+// Domain devolve o domínio do Casbin do role: o ID do tenant ou GlobalDomain.
+func (r *Role) Domain() string {
+	if r.TenantID == nil {
+		return GlobalDomain
+	}
+	return r.TenantID.String()
+}
+
 type PolicyRole struct {
 	RoleID     uint   `gorm:"not null;primarykey;" validate:"required" json:"role_id"`
 	EndpointID uint   `gorm:"not null;primarykey;" validate:"required" json:"endpoint_id"`
 	Actions    string `gorm:"type:varchar(36);not null;" validate:"required" json:"actions"`
+	Condition  string `gorm:"type:varchar(254);default:null" json:"condition,omitempty"` // Condição ABAC opcional, ex.: param.id == user.id
 
 	// // constraints
-	Role     *Role     `gorm:"foreignKey:RoleID;constraint:OnUpdate:RESTRICT,OnDelete:RESTRICT;"`
-	Endpoint *Endpoint `gorm:"foreignKey:EndpointID;constraint:OnUpdate:RESTRICT,OnDelete:RESTRICT;"`
+	Role     *Role     `gorm:"foreignKey:RoleID;constraint:OnUpdate:RESTRICT,OnDelete:RESTRICT;" json:"-"`
+	Endpoint *Endpoint `gorm:"foreignKey:EndpointID;constraint:OnUpdate:RESTRICT,OnDelete:RESTRICT;" json:"endpoint,omitempty"`
 }
 
 // TableName especifica o nome da tabela para GORM.
@@ -40,10 +54,11 @@ type PolicyUser struct {
 	UserID     uuid.UUID `gorm:"not null;primarykey;" validate:"required" json:"user_id"`
 	EndpointID uint      `gorm:"not null;primarykey;" validate:"required" json:"endpoint_id"`
 	Actions    string    `gorm:"type:varchar(36);not null;" validate:"required" json:"actions"`
+	Condition  string    `gorm:"type:varchar(254);default:null" json:"condition,omitempty"` // Condição ABAC opcional, ex.: param.id == user.id
 
 	// // constraints
-	User     *User     `gorm:"foreignKey:UserID;constraint:OnUpdate:RESTRICT,OnDelete:RESTRICT;"`
-	Endpoint *Endpoint `gorm:"foreignKey:EndpointID;constraint:OnUpdate:RESTRICT,OnDelete:RESTRICT;"`
+	User     *User     `gorm:"foreignKey:UserID;constraint:OnUpdate:RESTRICT,OnDelete:RESTRICT;" json:"-"`
+	Endpoint *Endpoint `gorm:"foreignKey:EndpointID;constraint:OnUpdate:RESTRICT,OnDelete:RESTRICT;" json:"endpoint,omitempty"`
 }
 
 // TableName especifica o nome da tabela para GORM.
@@ -51,7 +66,7 @@ func (PolicyUser) TableName() string {
 	return "policies_users"
 }
 
-// UserRole representa permissões especiais atribuídas a um usuário para um recurso específico.
+// UserRole representa a atribuição de um role a um usuário.
 type UserRole struct {
 	UserID uuid.UUID `gorm:"not null;primarykey;" validate:"required" json:"user_id"`
 	RoleID uint      `gorm:"not null;primarykey;" validate:"required" json:"role_id"`
@@ -65,3 +80,84 @@ type UserRole struct {
 func (UserRole) TableName() string {
 	return "users_roles"
 }
+
+// RoleInput são os dados para criar ou renomear um role. Global cria um role válido em todos os tenants
+// (apenas o role master); caso contrário, o role pertence ao tenant de quem o cria.
+// @name RoleInput
+type RoleInput struct {
+	Name   string `json:"name" binding:"required,max=36"`
+	Global bool   `json:"global"`
+}
+
+// EndpointInput são os dados para cadastrar ou renomear um endpoint. O nome segue o padrão das rotas, ex.: /api/v1/users/:id.
+// @name EndpointInput
+type EndpointInput struct {
+	Name string `json:"name" binding:"required,max=254,startswith=/"`
+}
+
+// PolicyInput concede ações sobre um endpoint a um role ou usuário. Actions são métodos HTTP separados por |, ex.: GET|POST.
+// Condition, opcional, restringe a política por atributos: comparações entre user.<id|tenant_id|username|email> e
+// param.<parâmetro do endpoint>, com == ou != e unidas por &&.
+// @name PolicyInput
+type PolicyInput struct {
+	EndpointID uint   `json:"endpoint_id" binding:"required"`
+	Actions    string `json:"actions" binding:"required,max=36" example:"GET|POST"`
+	Condition  string `json:"condition" binding:"max=254" example:"param.id == user.id"`
+}
+
+// PolicyActionsInput substitui as ações e a condição de uma política existente; sem condition, a política deixa de ter condição.
+// @name PolicyActionsInput
+type PolicyActionsInput struct {
+	Actions   string `json:"actions" binding:"required,max=36" example:"GET|POST|PUT|PATCH|DELETE"`
+	Condition string `json:"condition" binding:"max=254" example:"param.id == user.id"`
+}
+
+// UserRoleInput atribui um role a um usuário.
+// @name UserRoleInput
+type UserRoleInput struct {
+	RoleID uint `json:"role_id" binding:"required"`
+}
+
+// EndpointPermission são as ações permitidas em um endpoint.
+// @name EndpointPermission
+type EndpointPermission struct {
+	Endpoint string   `json:"endpoint" example:"/api/v1/users/:id"`
+	Actions  []string `json:"actions" example:"GET,PUT"`
+}
+
+// UserPermissions é a matriz de permissões efetivas do usuário autenticado.
+// @name UserPermissions
+type UserPermissions struct {
+	Roles       []string             `json:"roles"`
+	Permissions []EndpointPermission `json:"permissions"`
+}
+
+// AuthzExplainInput identifica a requisição a ser explicada: o usuário, o caminho (ex.: /api/v1/users/42) e o método HTTP.
+// @name AuthzExplainInput
+type AuthzExplainInput struct {
+	UserID uuid.UUID `json:"user_id" binding:"required"`
+	Path   string    `json:"path" binding:"required,startswith=/" example:"/api/v1/users/42"`
+	Method string    `json:"method" binding:"required" example:"GET"`
+}
+
+// AuthzRule é uma política do Casbin: sujeito (ID do usuário ou nome do role), domínio, endpoint, ações e condição.
+// @name AuthzRule
+type AuthzRule struct {
+	Subject   string `json:"subject"`
+	Domain    string `json:"domain"`
+	Endpoint  string `json:"endpoint"`
+	Actions   string `json:"actions"`
+	Condition string `json:"condition,omitempty"`
+}
+
+// AuthzExplanation é o resultado da verificação: a política que permitiu o acesso ou, quando negado,
+// as políticas do usuário e dos seus roles para o endpoint (Candidates), que não incluem o método.
+// @name AuthzExplanation
+type AuthzExplanation struct {
+	Allowed     bool        `json:"allowed"`
+	Domain      string      `json:"domain"`
+	Roles       []string    `json:"roles"`
+	MatchedRule *AuthzRule  `json:"matched_rule,omitempty"`
+	Candidates  []AuthzRule `json:"candidates,omitempty"`
+	Reason      string      `json:"reason"`
+}
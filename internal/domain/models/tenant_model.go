@@ -3,6 +3,8 @@
 package models
 
 import (
+	"encoding/json"
+
 	"github.com/jeancarlosdanese/go-base-api/internal/domain/enums"
 	"gorm.io/datatypes"
 )
@@ -25,11 +27,36 @@ type Tenant struct {
 	Email          *string          `gorm:"type:varchar(100)" validate:"omitempty,email" json:"email"`
 	Phone          *string          `gorm:"type:varchar(15)" validate:"omitempty" json:"phone"`
 	CellPhone      *string          `gorm:"type:varchar(15)" validate:"omitempty" json:"cell_phone"`
-	ApiKey         *string          `gorm:"type:varchar(100)" validate:"omitempty" json:"api_key"`
+	ApiKey         *string          `gorm:"-" json:"api_key,omitempty"` // Preenchida apenas na resposta de criação
 	AllowedOrigins *datatypes.JSON  `gorm:"type:jsonb;unique" json:"allowed_origins"`
 	Status         enums.StatusType `gorm:"type:status_type;not null;default:'ATIVO'" validate:"required,statusType" json:"status"`
 }
 
+// Origins retorna as origens de AllowedOrigins. Um valor ausente ou inválido resulta em lista vazia.
+func (t *Tenant) Origins() []string {
+	var origins []string
+	if t.AllowedOrigins == nil || json.Unmarshal(*t.AllowedOrigins, &origins) != nil {
+		return nil
+	}
+	return origins
+}
+
+// TenantListSpec são os filtros e ordenações aceitos em GET /tenants.
+var TenantListSpec = ListSpec{
+	DefaultSort: "name",
+	Fields: map[string]ListField{
+		"name":       {Column: "name", Ops: []FilterOp{FilterEq, FilterIn, FilterLike}, Sortable: true},
+		"type":       {Column: "type", Ops: []FilterOp{FilterEq, FilterIn}, Sortable: true, Values: []string{string(enums.Fisica), string(enums.Juridica)}},
+		"status":     {Column: "status", Ops: []FilterOp{FilterEq, FilterIn}, Sortable: true, Values: []string{string(enums.Ativo), string(enums.Inativo)}},
+		"cpf_cnpj":   {Column: "cpf_cnpj", Ops: []FilterOp{FilterEq, FilterLike}},
+		"email":      {Column: "email", Ops: []FilterOp{FilterEq, FilterLike}},
+		"city":       {Column: "city", Ops: []FilterOp{FilterEq, FilterIn, FilterLike}},
+		"state":      {Column: "state", Ops: []FilterOp{FilterEq, FilterIn}},
+		"created_at": {Column: "created_at", Ops: []FilterOp{FilterGte, FilterLte}, Sortable: true, Time: true},
+		"updated_at": {Column: "updated_at", Ops: []FilterOp{FilterGte, FilterLte}, Sortable: true, Time: true},
+	},
+}
+
 // TenantRedis representa dados simplificados do Tenant para cache Redis
 // @name TenantRedis
 type TenantRedis struct {
@@ -37,4 +64,7 @@ type TenantRedis struct {
 	Name    string `json:"name"`
 	CpfCnpj string `json:"cpfcnpj"`
 	Email   string `json:"email"`
+	// ApiKeyID e Scopes identificam a chave usada na requisição.
+	ApiKeyID string   `json:"api_key_id"`
+	Scopes   []string `json:"scopes"`
 }
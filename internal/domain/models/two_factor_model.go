@@ -0,0 +1,58 @@
+// internal/domain/models/two_factor_model.go
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecoveryCode é um código de recuperação de uso único para quando o usuário não tem acesso ao autenticador.
+// Apenas o hash SHA-256 do código é armazenado.
+type RecoveryCode struct {
+	ID        uint       `gorm:"primaryKey" json:"-"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"-"`
+	CodeHash  string     `gorm:"type:varchar(64);not null" json:"-"`
+	UsedAt    *time.Time `gorm:"type:timestamptz" json:"-"`
+	CreatedAt time.Time  `gorm:"type:timestamptz;default:now()" json:"-"`
+}
+
+// TableName define o nome da tabela dos códigos de recuperação.
+func (RecoveryCode) TableName() string {
+	return "users_recovery_codes"
+}
+
+// TOTPEnrollment é retornado ao iniciar a configuração do 2FA.
+// @name TOTPEnrollment
+type TOTPEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// RecoveryCodesResponse traz os códigos de recuperação em claro; eles são exibidos uma única vez.
+// @name RecoveryCodesResponse
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// TwoFactorChallenge é retornado pelo login quando o usuário tem 2FA ativo, no lugar dos tokens.
+// @name TwoFactorChallenge
+type TwoFactorChallenge struct {
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	ChallengeToken    string `json:"challenge_token"`
+	ExpiresIn         int    `json:"expires_in"`
+}
+
+// TwoFactorLoginRequest conclui o login com o código TOTP ou um código de recuperação.
+// @name TwoFactorLoginRequest
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `form:"challenge_token" json:"challenge_token" binding:"required"`
+	Code           string `form:"code" json:"code" binding:"required"`
+}
+
+// TwoFactorCodeRequest confirma uma operação de 2FA com o código TOTP (ou de recuperação, quando permitido).
+// @name TwoFactorCodeRequest
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
@@ -4,27 +4,48 @@ package models
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/enums"
 )
 
 // User representa um usuário no sistema.
 // @name User
 type User struct {
 	BaseModel
-	TenantID        uuid.UUID     `gorm:"type:uuid;not null;uniqueIndex:uni_users_tenant_id_email" json:"-"`
-	Username        string        `gorm:"type:varchar(80);not null" json:"username"`
-	Name            string        `gorm:"type:varchar(254);not null" json:"name"`
-	Email           string        `gorm:"type:varchar(100);not null;uniqueIndex:uni_users_tenant_id_email" json:"email"`
-	Password        string        `gorm:"type:varchar(60);not null" json:"-"`
-	Thumbnail       *string       `gorm:"type:varchar(70)" json:"thumbnail"`
-	Roles           []*Role       `gorm:"many2many:users_roles;" json:"roles"`
-	SpecialPolicies []*PolicyUser `gorm:"many2many:policies_users;" json:"policies"`
+	TenantID        uuid.UUID        `gorm:"type:uuid;not null;uniqueIndex:uni_users_tenant_id_email" json:"-"`
+	Username        string           `gorm:"type:varchar(80);not null" json:"username"`
+	Name            string           `gorm:"type:varchar(254);not null" json:"name"`
+	Email           string           `gorm:"type:varchar(100);not null;uniqueIndex:uni_users_tenant_id_email" json:"email"`
+	Password        string           `gorm:"type:varchar(255);not null" json:"-"`
+	Thumbnail       *string          `gorm:"type:varchar(70)" json:"thumbnail"`
+	TOTPSecret      *string          `gorm:"column:totp_secret;type:varchar(64)" json:"-"`
+	TOTPEnabled     bool             `gorm:"column:totp_enabled;not null;default:false" json:"totp_enabled"`
+	Status          enums.UserStatus `gorm:"type:user_status;not null;default:'ATIVO'" json:"status"`
+	EmailVerifiedAt *time.Time       `json:"email_verified_at"`
+	Roles           []*Role          `gorm:"many2many:users_roles;" json:"roles"`
+	SpecialPolicies []*PolicyUser    `gorm:"many2many:policies_users;" json:"policies"`
 
 	// constraints
 	Tenant *Tenant `gorm:"foreignKey:TenantID;constraint:OnUpdate:RESTRICT,OnDelete:RESTRICT;" json:"tenant,omitempty"`
 }
 
+// UserListSpec são os filtros e ordenações aceitos em GET /users.
+var UserListSpec = ListSpec{
+	DefaultSort: "name",
+	Fields: map[string]ListField{
+		"name":       {Column: "name", Ops: []FilterOp{FilterEq, FilterIn, FilterLike}, Sortable: true},
+		"username":   {Column: "username", Ops: []FilterOp{FilterEq, FilterIn, FilterLike}, Sortable: true},
+		"email":      {Column: "email", Ops: []FilterOp{FilterEq, FilterIn, FilterLike}, Sortable: true},
+		"status":     {Column: "status", Ops: []FilterOp{FilterEq, FilterIn}, Sortable: true, Values: []string{string(enums.UserPendente), string(enums.UserAtivo), string(enums.UserDesativado)}},
+		"created_at": {Column: "created_at", Ops: []FilterOp{FilterGte, FilterLte}, Sortable: true, Time: true},
+		"updated_at": {Column: "updated_at", Ops: []FilterOp{FilterGte, FilterLte}, Sortable: true, Time: true},
+	},
+}
+
 // UserCreate é usado para receber dados do formulário de criação de usuário.
 // @name UserCreate
 type UserCreate struct {
@@ -32,7 +53,22 @@ type UserCreate struct {
 	Username string    `gorm:"type:varchar(80);not null" json:"username"`
 	Name     string    `gorm:"type:varchar(254);not null" json:"name"`
 	Email    string    `gorm:"type:varchar(100);not null;uniqueIndex:uni_users_tenant_id_email" json:"email"`
-	Password string    `gorm:"type:varchar(60);not null" json:"password"`
+	Password string    `gorm:"type:varchar(255);not null" json:"password"`
+}
+
+// UserProfileUpdate é usado pelo próprio usuário para alterar seu perfil. Campos omitidos não são alterados.
+// @name UserProfileUpdate
+type UserProfileUpdate struct {
+	Name      *string `json:"name" binding:"omitempty,min=1,max=254"`
+	Username  *string `json:"username" binding:"omitempty,min=1,max=80"`
+	Thumbnail *string `json:"thumbnail" binding:"omitempty,max=70"`
+}
+
+// ChangePasswordRequest é usado pelo próprio usuário para trocar a senha.
+// @name ChangePasswordRequest
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=8"`
 }
 
 // ExtractRoles extrai e retorna os nomes dos roles do usuário.
@@ -57,11 +93,47 @@ func (u *User) ExtractPolicies() []string {
 }
 
 type UserRedis struct {
-	ID       string   `json:"id"`
-	TenantID string   `json:"tenant_id"`
-	Name     string   `json:"name"`
-	Username string   `json:"username"`
-	Email    string   `json:"email"`
-	Roles    []string `json:"roles"`
-	Policies []string `json:"policies"`
+	ID        string   `json:"id"`
+	TenantID  string   `json:"tenant_id"`
+	SessionID string   `json:"session_id"`
+	Name      string   `json:"name"`
+	Username  string   `json:"username"`
+	Email     string   `json:"email"`
+	Roles     []string `json:"roles"`
+	Policies  []string `json:"policies"`
+}
+
+// NewPermissionMatrix agrupa políticas no formato "endpoint:AÇÕES" por endpoint, unindo as ações dos roles e
+// das políticas especiais. Os endpoints saem em ordem alfabética e as ações na ordem canônica.
+func NewPermissionMatrix(policies []string) []EndpointPermission {
+	actionsByEndpoint := make(map[string]map[enums.ActionType]bool)
+	for _, policy := range policies {
+		// O nome do endpoint pode ter ":" (ex.: /api/v1/users/:id); as ações vêm depois do último
+		sep := strings.LastIndex(policy, ":")
+		if sep <= 0 {
+			continue
+		}
+		endpoint := policy[:sep]
+		if actionsByEndpoint[endpoint] == nil {
+			actionsByEndpoint[endpoint] = make(map[enums.ActionType]bool)
+		}
+		for _, action := range strings.Split(policy[sep+1:], "|") {
+			actionsByEndpoint[endpoint][enums.ActionType(strings.TrimSpace(action))] = true
+		}
+	}
+
+	matrix := make([]EndpointPermission, 0, len(actionsByEndpoint))
+	for endpoint, selected := range actionsByEndpoint {
+		actions := make([]string, 0, len(selected))
+		for _, action := range []enums.ActionType{enums.GET, enums.POST, enums.PUT, enums.PATCH, enums.DELETE} {
+			if selected[action] {
+				actions = append(actions, string(action))
+			}
+		}
+		if len(actions) > 0 {
+			matrix = append(matrix, EndpointPermission{Endpoint: endpoint, Actions: actions})
+		}
+	}
+	sort.Slice(matrix, func(i, j int) bool { return matrix[i].Endpoint < matrix[j].Endpoint })
+	return matrix
 }
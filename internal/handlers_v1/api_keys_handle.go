@@ -0,0 +1,263 @@
+// internal/handlers_v1/api_keys_handle.go
+
+package handlers_v1
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/logging"
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+)
+
+// ApiKeysHandler expõe a gestão das chaves de API de um tenant.
+type ApiKeysHandler struct {
+	tenantService services.TenantServiceInterface
+	apiKeyService services.ApiKeyServiceInterface
+}
+
+// NewApiKeysHandler cria uma nova instância de ApiKeysHandler.
+func NewApiKeysHandler(tenantService services.TenantServiceInterface, apiKeyService services.ApiKeyServiceInterface) *ApiKeysHandler {
+	return &ApiKeysHandler{tenantService: tenantService, apiKeyService: apiKeyService}
+}
+
+// RegisterRoutes registra as rotas de /tenants/:id/api-keys.
+func (h *ApiKeysHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/:id/api-keys", h.GetAll)
+	router.POST("/:id/api-keys", h.Create)
+	router.GET("/:id/api-keys/:key_id", h.GetById)
+	router.PATCH("/:id/api-keys/:key_id", h.Update)
+	router.DELETE("/:id/api-keys/:key_id", h.Revoke)
+	router.POST("/:id/api-keys/:key_id/rotate", h.Rotate)
+}
+
+// GetAll lista as chaves do tenant.
+// @Summary Lista as API Keys do Tenant
+// @Description Lista as chaves do Tenant, inclusive revogadas e expiradas. A chave em claro nunca é retornada, apenas o prefixo.
+// @Tags Tenants
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Tenant ID"
+// @Success 200 {array} models.ApiKey "Lista de API Keys"
+// @Failure 400 {object} models.HTTPError "Invalid UUID format"
+// @Failure 404 {object} models.HTTPError "Tenant not found"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/tenants/{id}/api-keys [get]
+func (h *ApiKeysHandler) GetAll(c *gin.Context) {
+	tenantID, ok := h.getTenantID(c)
+	if !ok {
+		return
+	}
+
+	keys, err := h.apiKeyService.List(c, tenantID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, keys)
+}
+
+// Create gera uma nova chave para o tenant.
+// @Summary Cria uma API Key para o Tenant
+// @Description Gera uma nova chave. A chave em claro (campo key) é exibida somente nesta resposta.
+// @Tags Tenants
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Tenant ID"
+// @Param apiKey body models.ApiKeyCreate true "Nome, escopos e expiração"
+// @Success 201 {object} models.ApiKeyCreated "API Key criada"
+// @Failure 400 {object} models.HTTPError "Erro de Formato de Solicitação"
+// @Failure 404 {object} models.HTTPError "Tenant not found"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/tenants/{id}/api-keys [post]
+func (h *ApiKeysHandler) Create(c *gin.Context) {
+	tenantID, ok := h.getTenantID(c)
+	if !ok {
+		return
+	}
+
+	var input models.ApiKeyCreate
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	created, err := h.apiKeyService.Create(c, tenantID, input)
+	if err != nil {
+		handleApiKeyError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// GetById busca uma chave do tenant.
+// @Summary Busca API Key por ID
+// @Description Busca uma chave do Tenant pelo ID
+// @Tags Tenants
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Tenant ID"
+// @Param key_id path string true "API Key ID"
+// @Success 200 {object} models.ApiKey "API Key"
+// @Failure 400 {object} models.HTTPError "Invalid UUID format"
+// @Failure 404 {object} models.HTTPError "Tenant ou API Key não encontrado"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/tenants/{id}/api-keys/{key_id} [get]
+func (h *ApiKeysHandler) GetById(c *gin.Context) {
+	tenantID, keyID, ok := h.getTenantAndKeyID(c)
+	if !ok {
+		return
+	}
+
+	key, err := h.apiKeyService.Get(c, tenantID, keyID)
+	if err != nil {
+		handleApiKeyError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, key)
+}
+
+// Update altera nome, escopos ou expiração de uma chave.
+// @Summary Atualiza uma API Key
+// @Description Altera nome, escopos ou expiração. A chave em si não muda; para trocá-la, use a rotação.
+// @Tags Tenants
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Tenant ID"
+// @Param key_id path string true "API Key ID"
+// @Param apiKey body models.ApiKeyUpdate true "Campos a alterar"
+// @Success 200 {object} models.ApiKey "API Key atualizada"
+// @Failure 400 {object} models.HTTPError "Erro de Formato de Solicitação"
+// @Failure 404 {object} models.HTTPError "Tenant ou API Key não encontrado"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/tenants/{id}/api-keys/{key_id} [patch]
+func (h *ApiKeysHandler) Update(c *gin.Context) {
+	tenantID, keyID, ok := h.getTenantAndKeyID(c)
+	if !ok {
+		return
+	}
+
+	var input models.ApiKeyUpdate
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	key, err := h.apiKeyService.Update(c, tenantID, keyID, input)
+	if err != nil {
+		handleApiKeyError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, key)
+}
+
+// Revoke revoga uma chave.
+// @Summary Revoga uma API Key
+// @Description Revoga a chave imediatamente. O registro é mantido para auditoria.
+// @Tags Tenants
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Tenant ID"
+// @Param key_id path string true "API Key ID"
+// @Success 200 {object} map[string]string "API Key revogada"
+// @Failure 400 {object} models.HTTPError "Invalid UUID format"
+// @Failure 404 {object} models.HTTPError "Tenant ou API Key não encontrado"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/tenants/{id}/api-keys/{key_id} [delete]
+func (h *ApiKeysHandler) Revoke(c *gin.Context) {
+	tenantID, keyID, ok := h.getTenantAndKeyID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.apiKeyService.Revoke(c, tenantID, keyID); err != nil {
+		handleApiKeyError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "API Key revoked successfully"})
+}
+
+// Rotate troca uma chave por uma nova.
+// @Summary Rotaciona uma API Key
+// @Description Gera uma nova chave com o mesmo nome, escopos e expiração. A chave antiga continua válida por grace_period segundos (padrão 0, máximo 7 dias). A nova chave em claro é exibida somente nesta resposta.
+// @Tags Tenants
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Tenant ID"
+// @Param key_id path string true "API Key ID"
+// @Param rotate body models.ApiKeyRotate false "Período de carência da chave antiga"
+// @Success 201 {object} models.ApiKeyCreated "Nova API Key"
+// @Failure 400 {object} models.HTTPError "Erro de Formato de Solicitação"
+// @Failure 404 {object} models.HTTPError "Tenant ou API Key não encontrado"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/tenants/{id}/api-keys/{key_id}/rotate [post]
+func (h *ApiKeysHandler) Rotate(c *gin.Context) {
+	tenantID, keyID, ok := h.getTenantAndKeyID(c)
+	if !ok {
+		return
+	}
+
+	var input models.ApiKeyRotate
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	created, err := h.apiKeyService.Rotate(c, tenantID, keyID, time.Duration(input.GracePeriod)*time.Second)
+	if err != nil {
+		handleApiKeyError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// getTenantID lê o ID do tenant da URL e confirma que ele existe.
+func (h *ApiKeysHandler) getTenantID(c *gin.Context) (uuid.UUID, bool) {
+	tenantID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid UUID format"})
+		return uuid.Nil, false
+	}
+
+	if _, err := h.tenantService.GetByID(c, tenantID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Tenant not found"})
+		return uuid.Nil, false
+	}
+	return tenantID, true
+}
+
+func (h *ApiKeysHandler) getTenantAndKeyID(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	keyID, err := uuid.Parse(c.Param("key_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid UUID format"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	tenantID, ok := h.getTenantID(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	return tenantID, keyID, true
+}
+
+// handleApiKeyError converte os erros do ApiKeyService em respostas HTTP.
+func handleApiKeyError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrApiKeyNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrApiKeyExpiresInPast):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		logging.ErrorLogger.Printf("Erro na gestão de API Keys: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro interno do servidor"})
+	}
+}
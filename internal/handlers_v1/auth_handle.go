@@ -3,9 +3,13 @@
 package handlers_v1
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	autherrors "github.com/jeancarlosdanese/go-base-api/internal/domain/auth_errors"
+	contextkeys "github.com/jeancarlosdanese/go-base-api/internal/domain/context_keys"
 	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
 	"github.com/jeancarlosdanese/go-base-api/internal/logging"
 	"github.com/jeancarlosdanese/go-base-api/internal/services"
@@ -17,24 +21,37 @@ type AuthHandler struct {
 	userService       services.UserServiceInterface
 	tokenService      services.TokenServiceInterface
 	tokenRedisService services.TokenRedisServiceInterface
+	twoFactorService  services.TwoFactorServiceInterface
+	loginAttempts     services.LoginAttemptServiceInterface
+	tenantStatus      services.TenantStatusServiceInterface
 }
 
 // NewAuthHandler cria uma nova instância de AuthHandler.
 func NewAuthHandler(
 	userService services.UserServiceInterface,
 	tokenService services.TokenServiceInterface,
-	tokenRedisService services.TokenRedisServiceInterface) *AuthHandler {
+	tokenRedisService services.TokenRedisServiceInterface,
+	twoFactorService services.TwoFactorServiceInterface,
+	loginAttempts services.LoginAttemptServiceInterface,
+	tenantStatus services.TenantStatusServiceInterface) *AuthHandler {
 	return &AuthHandler{
 		userService:       userService,
 		tokenService:      tokenService,
 		tokenRedisService: tokenRedisService,
+		twoFactorService:  twoFactorService,
+		loginAttempts:     loginAttempts,
+		tenantStatus:      tenantStatus,
 	}
 }
 
-// RegisterRoutes registra as rotas para autenticação.
-func (h *AuthHandler) RegisterRoutes(router *gin.RouterGroup) {
+// RegisterRoutes registra as rotas para autenticação. authMiddleware é aplicado apenas às rotas que exigem
+// um access token válido (logout e logout-all).
+func (h *AuthHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
 	router.POST("/login", h.Login)
+	router.POST("/login/2fa", h.LoginTwoFactor)
 	router.POST("/refresh", h.Refresh)
+	router.POST("/logout", authMiddleware, h.Logout)
+	router.POST("/logout-all", authMiddleware, h.LogoutAll)
 }
 
 // login realiza o login do usuário e retorna um JWT.
@@ -45,8 +62,12 @@ func (h *AuthHandler) RegisterRoutes(router *gin.RouterGroup) {
 // @Produce json
 // @Param email formData string true "Email do Usuário"
 // @Param password formData string true "Senha do Usuário"
-// @Success 200 {object} map[string]interface{} "Token gerado com sucesso"
+// @Success 200 {object} map[string]interface{} "Token gerado com sucesso, ou models.TwoFactorChallenge se o usuário tiver 2FA ativo"
 // @Failure 400 {object} map[string]string "Erro de autenticação"
+// @Failure 401 {object} map[string]string "Credenciais inválidas"
+// @Failure 403 {object} map[string]string "Tenant suspenso (TENANT_SUSPENDED), e-mail não verificado (USER_PENDING) ou usuário desativado (USER_DISABLED), no campo code"
+// @Failure 423 {object} map[string]string "Conta temporariamente bloqueada (header Retry-After)"
+// @Failure 429 {object} map[string]string "Aguarde antes de tentar novamente (header Retry-After)"
 // @Router /api/v1/auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	origin := c.GetString("Origin")
@@ -77,24 +98,106 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
+	if err := h.loginAttempts.Check(loginForm.Email, origin, c.ClientIP()); err != nil {
+		utils.HandleAuthenticationError(c, err)
+		return
+	}
+
 	user, err := h.userService.Authenticate(c, loginForm.Email, loginForm.Password, origin)
 	if err != nil {
+		if errors.Is(err, autherrors.ErrUserOrOriginNotFound) || errors.Is(err, autherrors.ErrInvalidPassword) {
+			if lockErr := h.loginAttempts.RegisterFailure(loginForm.Email, origin, c.ClientIP()); lockErr != nil {
+				err = lockErr
+			}
+		}
 		utils.HandleAuthenticationError(c, err)
 		return
 	}
 
-	h.generateAndSaveTokens(c, user)
+	// Com 2FA ativo, a senha só libera o desafio; os tokens são emitidos em /auth/login/2fa, e as tentativas
+	// só são zeradas depois do segundo fator.
+	if user.TOTPEnabled {
+		challenge, err := h.twoFactorService.CreateChallenge(user, origin)
+		if err != nil {
+			logging.ErrorLogger.Printf("Falha ao criar desafio de 2FA: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao iniciar a verificação em duas etapas"})
+			return
+		}
+		c.JSON(http.StatusOK, challenge)
+		return
+	}
+
+	h.resetLoginAttempts(user.Email, origin)
+	h.generateAndSaveTokens(c, user, uuid.NewString())
+}
+
+// LoginTwoFactor conclui o login de usuários com 2FA.
+// @Summary Conclui o login com o segundo fator
+// @Description Recebe o challenge_token devolvido pelo login e um código TOTP (ou código de recuperação) e emite os tokens
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body models.TwoFactorLoginRequest true "Desafio e código"
+// @Success 200 {object} models.Token "Token gerado com sucesso"
+// @Failure 400 {object} map[string]string "Parâmetros de entrada inválidos"
+// @Failure 401 {object} map[string]string "Desafio inválido/expirado ou código inválido"
+// @Failure 403 {object} map[string]string "Tenant suspenso, e-mail não verificado ou usuário desativado (campo code)"
+// @Failure 423 {object} map[string]string "Conta temporariamente bloqueada por códigos inválidos (header Retry-After)"
+// @Failure 429 {object} map[string]string "Aguarde antes de tentar novamente (header Retry-After)"
+// @Router /api/v1/auth/login/2fa [post]
+func (h *AuthHandler) LoginTwoFactor(c *gin.Context) {
+	origin := c.GetString("Origin")
+
+	var request models.TwoFactorLoginRequest
+	if err := c.ShouldBind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetros de entrada inválidos", "details": err.Error()})
+		return
+	}
+
+	user, err := h.twoFactorService.CompleteChallenge(c, request.ChallengeToken, request.Code, origin)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrTwoFactorChallengeInvalid):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Desafio inválido ou expirado, faça o login novamente"})
+		case errors.Is(err, services.ErrInvalidTwoFactorCode):
+			// Código inválido conta como falha de login da conta, como a senha errada
+			if lockErr := h.loginAttempts.RegisterFailure(user.Email, origin, c.ClientIP()); lockErr != nil {
+				utils.HandleAuthenticationError(c, lockErr)
+				return
+			}
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Código de verificação inválido"})
+		case errors.Is(err, autherrors.ErrTenantSuspended), errors.Is(err, autherrors.ErrUserPending), errors.Is(err, autherrors.ErrUserDisabled):
+			utils.HandleAuthenticationError(c, err)
+		default:
+			logging.ErrorLogger.Printf("Erro ao concluir login com 2FA: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro interno do servidor"})
+		}
+		return
+	}
+
+	h.resetLoginAttempts(user.Email, origin)
+	h.generateAndSaveTokens(c, user, uuid.NewString())
+}
+
+// resetLoginAttempts zera as falhas de login da conta depois de um login concluído.
+func (h *AuthHandler) resetLoginAttempts(email, origin string) {
+	if err := h.loginAttempts.Reset(email, origin); err != nil {
+		logging.WarnLogger.Printf("Falha ao limpar as tentativas de login: %v", err)
+	}
 }
 
 // Refresh renova o token usando o refreshToken.
 // @Summary Renova o token
-// @Description Renova o token usando o refreshToken
+// @Description Renova o token usando o refreshToken. Cada refresh token só pode ser usado uma vez: a resposta traz um novo refresh token da mesma família, e a reutilização de um token já consumido revoga a família inteira.
 // @Tags Auth
 // @Accept x-www-form-urlencoded
 // @Produce json
 // @Param refreshToken formData string true "Refresh Token"
 // @Success 200 {object} map[string]interface{} "Token renovado com sucesso"
 // @Failure 400 {object} map[string]string "Erro de autenticação"
+// @Failure 401 {object} map[string]string "Refresh token inválido, revogado ou já utilizado"
+// @Failure 403 {object} map[string]string "Tenant suspenso ou usuário desativado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
 // @Router /api/v1/auth/refresh [post]
 // Refresh renova o token usando o refreshToken.
 func (h *AuthHandler) Refresh(c *gin.Context) {
@@ -104,36 +207,153 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 		return
 	}
 
-	userID, err := h.tokenService.RefreshTokens(refreshTokenRequest.RefreshToken)
+	claims, err := h.tokenService.RefreshTokens(refreshTokenRequest.RefreshToken)
 	if err != nil {
 		logging.WarnLogger.Printf("Erro ao renovar token: %v", err)
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Token inválido ou expirado"})
 		return
 	}
 
-	user, err := h.userService.GetOnlyByID(c, userID)
+	// Verificado antes de consumir o token, para que o cliente receba o motivo em vez de um token revogado
+	suspended, err := h.tenantStatus.IsSuspended(claims.TenantID.String())
+	if err != nil {
+		logging.ErrorLogger.Printf("Falha ao verificar o status do tenant %s: %v", claims.TenantID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro interno do servidor"})
+		return
+	}
+	if suspended {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Tenant suspenso"})
+		return
+	}
+
+	// Rotação: o refresh token atual da família é consumido e um novo é emitido na mesma família.
+	userRedis, err := h.tokenRedisService.ConsumeRefreshToken(claims.UserID.String(), claims.FamilyID, refreshTokenRequest.RefreshToken)
+	if err != nil {
+		// Falhas do Redis não significam token revogado: o cliente pode tentar de novo com o mesmo token
+		if !errors.Is(err, services.ErrRefreshTokenRevoked) && !errors.Is(err, services.ErrRefreshTokenReused) {
+			logging.ErrorLogger.Printf("Falha ao consumir o refresh token: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro interno do servidor"})
+			return
+		}
+		logging.WarnLogger.Printf("Refresh token rejeitado: %v", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Token inválido ou expirado"})
+		return
+	}
+
+	if userRedis.ID != claims.UserID.String() || userRedis.TenantID != claims.TenantID.String() {
+		logging.WarnLogger.Printf("Refresh token não pertence ao usuário/tenant da sessão: %s", claims.UserID)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Token inválido ou expirado"})
+		return
+	}
+
+	user, err := h.userService.GetOnlyByID(c, claims.UserID)
 	if err != nil {
 		logging.WarnLogger.Printf("Erro ao buscar usuário: %v", err)
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não encontrado"})
 		return
 	}
+	if err := services.CheckLoginAllowed(user); err != nil {
+		utils.HandleAuthenticationError(c, err)
+		return
+	}
+
+	h.generateAndSaveTokens(c, user, userRedis.SessionID)
+}
+
+// Logout encerra a sessão atual, revogando o access token e o refresh token.
+// @Summary Encerra a sessão atual
+// @Description Revoga o access token e o refresh token da sessão atual
+// @Tags Auth
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} map[string]string "Logout realizado com sucesso"
+// @Failure 401 {object} map[string]string "Usuário não autenticado"
+// @Failure 500 {object} map[string]string "Erro ao revogar a sessão"
+// @Router /api/v1/auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	userRedis, ok := getUserRedisFromContext(c)
+	if !ok {
+		return
+	}
+
+	if err := h.tokenRedisService.RevokeSession(userRedis.ID, userRedis.SessionID); err != nil {
+		logging.ErrorLogger.Printf("Falha ao revogar sessão do usuário %s: %v", userRedis.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao encerrar a sessão"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Logout realizado com sucesso"})
+}
+
+// LogoutAll encerra todas as sessões do usuário autenticado.
+// @Summary Encerra todas as sessões
+// @Description Revoga os access tokens e refresh tokens de todas as sessões do usuário autenticado
+// @Tags Auth
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} map[string]string "Todas as sessões foram encerradas"
+// @Failure 401 {object} map[string]string "Usuário não autenticado"
+// @Failure 500 {object} map[string]string "Erro ao revogar as sessões"
+// @Router /api/v1/auth/logout-all [post]
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	userRedis, ok := getUserRedisFromContext(c)
+	if !ok {
+		return
+	}
+
+	if err := h.tokenRedisService.RevokeAllSessions(userRedis.ID); err != nil {
+		logging.ErrorLogger.Printf("Falha ao revogar sessões do usuário %s: %v", userRedis.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao encerrar as sessões"})
+		return
+	}
 
-	h.generateAndSaveTokens(c, user)
+	c.JSON(http.StatusOK, gin.H{"message": "Todas as sessões foram encerradas"})
 }
 
-// generateAndSaveTokens gera e salva tokens para o usuário.
-func (h *AuthHandler) generateAndSaveTokens(c *gin.Context, user *models.User) {
+// getUserRedisFromContext recupera o usuário autenticado colocado no contexto pelo AuthMiddleware.
+func getUserRedisFromContext(c *gin.Context) (*models.UserRedis, bool) {
+	userData, exists := c.Get(string(contextkeys.UserDataKey))
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return nil, false
+	}
+
+	userRedis, ok := userData.(*models.UserRedis)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return nil, false
+	}
+
+	return userRedis, true
+}
+
+// generateAndSaveTokens gera e salva tokens para o usuário na sessão informada.
+func (h *AuthHandler) generateAndSaveTokens(c *gin.Context, user *models.User, sessionID string) {
 	roles := user.ExtractRoles()
 	policies := user.ExtractPolicies()
 
-	accessToken, refreshToken, err := h.tokenService.CreateTokens(user.ID, roles, policies)
+	accessToken, refreshToken, err := h.tokenService.CreateTokens(services.TokenSubject{
+		UserID:      user.ID,
+		TenantID:    user.TenantID,
+		FamilyID:    sessionID,
+		Roles:       roles,
+		Permissions: policies,
+	})
 	if err != nil {
 		logging.ErrorLogger.Printf("Falha ao gerar tokens para o usuário: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao gerar tokens"})
 		return
 	}
 
-	if err := h.tokenRedisService.SaveUserRedis(user, accessToken, refreshToken, h.tokenService.GetAccessDuration()); err != nil {
+	session := &models.UserSession{
+		ID:           sessionID,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		IPAddress:    c.ClientIP(),
+		UserAgent:    c.Request.UserAgent(),
+	}
+
+	if err := h.tokenRedisService.SaveUserRedis(user, session, h.tokenService.GetAccessDuration(), h.tokenService.GetRefreshDuration()); err != nil {
 		logging.ErrorLogger.Printf("Falha ao salvar informações do usuário no Redis: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao salvar informações do usuário no Redis"})
 		return
@@ -0,0 +1,76 @@
+// internal/handlers_v1/authz_handle.go
+
+package handlers_v1
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/logging"
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+)
+
+// AuthzHandler ajuda o suporte a entender as decisões do Casbin sobre os usuários do tenant.
+type AuthzHandler struct {
+	userService   services.UserServiceInterface
+	casbinService services.CasbinServiceInterface
+}
+
+// NewAuthzHandler cria uma nova instância de AuthzHandler.
+func NewAuthzHandler(userService services.UserServiceInterface, casbinService services.CasbinServiceInterface) *AuthzHandler {
+	return &AuthzHandler{userService: userService, casbinService: casbinService}
+}
+
+// RegisterRoutes registra as rotas de /authz.
+func (h *AuthzHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/explain", h.Explain)
+}
+
+// Explain explica se o usuário pode fazer a requisição e por quê.
+// @Summary Explica uma decisão de acesso
+// @Description Verifica, como o PolicyMiddleware, se o User pode acessar o caminho com o método informado no tenant dele. Retorna a política que permitiu o acesso ou, se negado, os roles do User e as políticas que cobrem o endpoint sem incluir o método.
+// @Tags Authz
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body models.AuthzExplainInput true "User, caminho e método"
+// @Success 200 {object} models.AuthzExplanation "Resultado da verificação"
+// @Failure 400 {object} models.HTTPError "Parâmetros de entrada inválidos"
+// @Failure 401 {object} models.HTTPError "Usuário não autenticado"
+// @Failure 404 {object} models.HTTPError "Usuário não encontrado"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/authz/explain [post]
+func (h *AuthzHandler) Explain(c *gin.Context) {
+	userRedis, ok := getUserRedisFromContext(c)
+	if !ok {
+		return
+	}
+
+	var input models.AuthzExplainInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetros de entrada inválidos", "details": err.Error()})
+		return
+	}
+
+	// Apenas usuários do mesmo tenant; o domínio é o tenant do usuário
+	user, err := h.userService.GetByID(c, input.UserID)
+	if err != nil || user.TenantID.String() != userRedis.TenantID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Usuário não encontrado"})
+		return
+	}
+
+	// Os atributos usados nas condições das políticas são os mesmos da sessão do usuário
+	subject := &models.UserRedis{ID: user.ID.String(), TenantID: user.TenantID.String(), Username: user.Username, Email: user.Email}
+	path, _, _ := strings.Cut(input.Path, "?")
+	method := strings.ToUpper(strings.TrimSpace(input.Method))
+	explanation, err := h.casbinService.Explain(subject, subject.TenantID, path, method)
+	if err != nil {
+		logging.ErrorLogger.Printf("Erro ao explicar o acesso do usuário %s a %s %s: %v", user.ID, method, path, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro interno do servidor"})
+		return
+	}
+
+	c.JSON(http.StatusOK, explanation)
+}
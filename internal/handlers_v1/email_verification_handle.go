@@ -0,0 +1,59 @@
+// internal/handlers_v1/email_verification_handle.go
+
+package handlers_v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/logging"
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+)
+
+// EmailVerificationHandler trata a confirmação do e-mail pelo link enviado aos novos usuários.
+type EmailVerificationHandler struct {
+	userStatusService services.UserStatusServiceInterface
+}
+
+// NewEmailVerificationHandler cria uma nova instância de EmailVerificationHandler.
+func NewEmailVerificationHandler(userStatusService services.UserStatusServiceInterface) *EmailVerificationHandler {
+	return &EmailVerificationHandler{userStatusService: userStatusService}
+}
+
+// RegisterRoutes registra as rotas de verificação de e-mail.
+func (h *EmailVerificationHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/verify", h.Verify)
+}
+
+// Verify confirma o e-mail usando o token recebido por e-mail.
+// @Summary Confirma o e-mail
+// @Description Consome o token de verificação (uso único) e ativa o usuário pendente
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body models.VerifyEmailRequest true "Token de verificação"
+// @Success 200 {object} map[string]string "E-mail verificado"
+// @Failure 400 {object} map[string]string "Parâmetros de entrada inválidos ou token inválido/expirado"
+// @Failure 500 {object} map[string]string "Erro ao verificar o e-mail"
+// @Router /api/v1/auth/email/verify [post]
+func (h *EmailVerificationHandler) Verify(c *gin.Context) {
+	var request models.VerifyEmailRequest
+	if err := c.ShouldBind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetros de entrada inválidos", "details": err.Error()})
+		return
+	}
+
+	if err := h.userStatusService.VerifyEmail(c, request.Token); err != nil {
+		if errors.Is(err, services.ErrEmailVerificationTokenInvalid) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Token inválido ou expirado"})
+			return
+		}
+		logging.ErrorLogger.Printf("Erro ao verificar e-mail: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao verificar o e-mail"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "E-mail verificado com sucesso"})
+}
@@ -0,0 +1,163 @@
+// internal/handlers_v1/endpoints_handle.go
+
+package handlers_v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+)
+
+// EndpointsHandler expõe o cadastro dos endpoints usados nas políticas.
+type EndpointsHandler struct {
+	securityService services.SecurityServiceInterface
+}
+
+// NewEndpointsHandler cria uma nova instância de EndpointsHandler.
+func NewEndpointsHandler(securityService services.SecurityServiceInterface) *EndpointsHandler {
+	return &EndpointsHandler{securityService: securityService}
+}
+
+// RegisterRoutes registra as rotas de /endpoints.
+func (h *EndpointsHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("", h.GetAll)
+	router.POST("", h.Create)
+	router.GET("/:id", h.GetById)
+	router.PUT("/:id", h.Update)
+	router.DELETE("/:id", h.Delete)
+}
+
+// GetAll lista os endpoints.
+// @Summary Lista os Endpoints
+// @Description Lista todos os endpoints cadastrados para as políticas, ordenados pelo nome
+// @Tags Endpoints
+// @Produce json
+// @Security Bearer
+// @Success 200 {array} models.Endpoint "Lista de Endpoints"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/endpoints [get]
+func (h *EndpointsHandler) GetAll(c *gin.Context) {
+	endpoints, err := h.securityService.ListEndpoints(c)
+	if err != nil {
+		handleSecurityError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, endpoints)
+}
+
+// Create cadastra um endpoint.
+// @Summary Cria um Endpoint
+// @Description Cadastra um endpoint no padrão das rotas (ex.: /api/v1/users/:id), para uso nas políticas
+// @Tags Endpoints
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param endpoint body models.EndpointInput true "Nome do Endpoint"
+// @Success 201 {object} models.Endpoint "Endpoint criado"
+// @Failure 400 {object} models.HTTPError "Erro de Formato de Solicitação"
+// @Failure 409 {object} models.HTTPError "Endpoint já existe"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/endpoints [post]
+func (h *EndpointsHandler) Create(c *gin.Context) {
+	var input models.EndpointInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	endpoint, err := h.securityService.CreateEndpoint(c, input)
+	if err != nil {
+		handleSecurityError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, endpoint)
+}
+
+// GetById busca um endpoint.
+// @Summary Busca Endpoint por ID
+// @Description Busca um endpoint pelo ID
+// @Tags Endpoints
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Endpoint ID"
+// @Success 200 {object} models.Endpoint "Endpoint"
+// @Failure 400 {object} models.HTTPError "ID Inválido"
+// @Failure 404 {object} models.HTTPError "Endpoint não encontrado"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/endpoints/{id} [get]
+func (h *EndpointsHandler) GetById(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	endpoint, err := h.securityService.GetEndpoint(c, id)
+	if err != nil {
+		handleSecurityError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, endpoint)
+}
+
+// Update renomeia um endpoint.
+// @Summary Renomeia um Endpoint
+// @Description Renomeia o endpoint; as políticas que o usam passam a valer para o novo nome
+// @Tags Endpoints
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Endpoint ID"
+// @Param endpoint body models.EndpointInput true "Novo nome do Endpoint"
+// @Success 200 {object} models.Endpoint "Endpoint atualizado"
+// @Failure 400 {object} models.HTTPError "Erro de Formato de Solicitação"
+// @Failure 404 {object} models.HTTPError "Endpoint não encontrado"
+// @Failure 409 {object} models.HTTPError "Endpoint já existe"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/endpoints/{id} [put]
+func (h *EndpointsHandler) Update(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var input models.EndpointInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	endpoint, err := h.securityService.RenameEndpoint(c, id, input)
+	if err != nil {
+		handleSecurityError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, endpoint)
+}
+
+// Delete remove um endpoint.
+// @Summary Remove um Endpoint
+// @Description Remove o endpoint. Endpoints usados em políticas não são removidos.
+// @Tags Endpoints
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Endpoint ID"
+// @Success 200 {object} map[string]string "Endpoint removido"
+// @Failure 400 {object} models.HTTPError "ID Inválido"
+// @Failure 404 {object} models.HTTPError "Endpoint não encontrado"
+// @Failure 409 {object} models.HTTPError "Endpoint usado em políticas"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/endpoints/{id} [delete]
+func (h *EndpointsHandler) Delete(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.securityService.DeleteEndpoint(c, id); err != nil {
+		handleSecurityError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Endpoint deleted successfully"})
+}
@@ -0,0 +1,61 @@
+// internal/handlers_v1/etag.go
+
+package handlers_v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	contextkeys "github.com/jeancarlosdanese/go-base-api/internal/domain/context_keys"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+)
+
+// setETag informa a versão do registro no header ETag ("3"), para ser devolvida no If-Match da alteração.
+func setETag(c *gin.Context, version int) {
+	c.Header("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// bindIfMatch lê o header If-Match ("3", lista de ETags ou *) e guarda as versões aceitas no contexto
+// (contextkeys.IfMatchKey), onde o repositório as usa na condição do UPDATE/DELETE. Sem o header, ou com *,
+// a alteração não é condicionada. Se nenhuma ETag puder corresponder a uma versão (ex.: ETag fraca W/"3"),
+// responde 412 e retorna false.
+func bindIfMatch(c *gin.Context) bool {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return true
+	}
+
+	var versions []int
+	for _, tag := range strings.Split(header, ",") {
+		value, err := strconv.Unquote(strings.TrimSpace(tag))
+		if err != nil {
+			continue
+		}
+		if version, err := strconv.Atoi(value); err == nil {
+			versions = append(versions, version)
+		}
+	}
+	if len(versions) == 0 {
+		respondPreconditionFailed(c)
+		return false
+	}
+
+	c.Set(string(contextkeys.IfMatchKey), versions)
+	return true
+}
+
+// respondVersionMismatch responde 412 se err for models.ErrVersionMismatch.
+func respondVersionMismatch(c *gin.Context, err error) bool {
+	if !errors.Is(err, models.ErrVersionMismatch) {
+		return false
+	}
+	respondPreconditionFailed(c)
+	return true
+}
+
+func respondPreconditionFailed(c *gin.Context) {
+	c.JSON(http.StatusPreconditionFailed, gin.H{"error": models.ErrVersionMismatch.Error()})
+}
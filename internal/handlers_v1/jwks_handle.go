@@ -0,0 +1,38 @@
+// internal/handlers_v1/jwks_handle.go
+
+package handlers_v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+)
+
+// JWKSHandler publica as chaves públicas usadas na verificação dos JWTs.
+type JWKSHandler struct {
+	tokenService services.TokenServiceInterface
+}
+
+func NewJWKSHandler(tokenService services.TokenServiceInterface) *JWKSHandler {
+	return &JWKSHandler{tokenService: tokenService}
+}
+
+// RegisterRoutes registra a rota do JWKS.
+func (h *JWKSHandler) RegisterRoutes(router gin.IRouter) {
+	router.GET("/.well-known/jwks.json", h.GetJWKS)
+}
+
+// GetJWKS retorna o conjunto de chaves públicas de verificação.
+// @Summary Chaves públicas (JWKS)
+// @Description Retorna as chaves públicas (RSA/Ed25519) ativas para verificação dos JWTs emitidos pela API. Chaves HMAC não são publicadas.
+// @Tags Auth
+// @Produce json
+// @Success 200 {object} models.JWKS "Conjunto de chaves"
+// @Router /.well-known/jwks.json [get]
+func (h *JWKSHandler) GetJWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	var jwks models.JWKS = h.tokenService.JWKS()
+	c.JSON(http.StatusOK, jwks)
+}
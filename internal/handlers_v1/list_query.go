@@ -0,0 +1,244 @@
+// internal/handlers_v1/list_query.go
+
+package handlers_v1
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+)
+
+const (
+	defaultListLimit   = 50
+	maxListLimit       = 200
+	defaultSearchLimit = 20
+)
+
+// Parâmetros de paginação e ordenação; os demais parâmetros da query string são filtros.
+var listReservedParams = map[string]bool{"limit": true, "offset": true, "cursor": true, "sort": true}
+
+// listCursor é o conteúdo (em base64) do parâmetro cursor: a ordenação em que foi gerado e os valores do último item.
+type listCursor struct {
+	Sort   string   `json:"s"`
+	Values []string `json:"v"`
+}
+
+// parseListQuery lê da query string os filtros (campo=valor ou campo[op]=valor), a ordenação (sort=-created_at,name)
+// e a paginação (limit, offset ou cursor), aceitando apenas os campos e operadores do spec.
+func parseListQuery(c *gin.Context, spec models.ListSpec) (models.ListQuery, error) {
+	params := c.Request.URL.Query()
+	query := models.ListQuery{Limit: defaultListLimit}
+
+	if limit := params.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil || value < 1 || value > maxListLimit {
+			return query, fmt.Errorf("limit deve ser um número entre 1 e %d", maxListLimit)
+		}
+		query.Limit = value
+	}
+	if offset := params.Get("offset"); offset != "" {
+		value, err := strconv.Atoi(offset)
+		if err != nil || value < 0 {
+			return query, errors.New("offset deve ser um número maior ou igual a zero")
+		}
+		query.Offset = value
+	}
+
+	sortParam := params.Get("sort")
+	if sortParam == "" {
+		sortParam = spec.DefaultSort
+	}
+	sort, err := parseListSort(sortParam, spec)
+	if err != nil {
+		return query, err
+	}
+	query.Sort = sort
+
+	if cursor := params.Get("cursor"); cursor != "" {
+		if query.Offset > 0 {
+			return query, errors.New("use offset ou cursor, não ambos")
+		}
+		values, err := decodeListCursor(cursor, sortKey(sort))
+		if err != nil {
+			return query, err
+		}
+		query.Cursor = values
+	}
+
+	for key, values := range params {
+		if listReservedParams[key] {
+			continue
+		}
+		name, op := key, models.FilterEq
+		if before, after, found := strings.Cut(key, "["); found && strings.HasSuffix(after, "]") {
+			name, op = before, models.FilterOp(strings.TrimSuffix(after, "]"))
+		}
+		field, ok := spec.Fields[name]
+		if !ok {
+			return query, fmt.Errorf("filtro não permitido: %s", name)
+		}
+		if !slices.Contains(field.Ops, op) {
+			return query, fmt.Errorf("operador %s não permitido para %s", op, name)
+		}
+		for _, value := range values {
+			filter := models.ListFilter{Column: field.Column, Op: op, Values: []string{value}}
+			if op == models.FilterIn {
+				filter.Values = strings.Split(value, ",")
+			}
+			if err := validateFilterValues(name, field, filter.Values); err != nil {
+				return query, err
+			}
+			query.Filters = append(query.Filters, filter)
+		}
+	}
+
+	return query, nil
+}
+
+func parseListSort(sortParam string, spec models.ListSpec) ([]models.ListSort, error) {
+	var sort []models.ListSort
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(sortParam, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		desc := strings.HasPrefix(name, "-")
+		name = strings.TrimPrefix(name, "-")
+		field, ok := spec.Fields[name]
+		if !ok || !field.Sortable || seen[name] {
+			return nil, fmt.Errorf("ordenação não permitida: %s", name)
+		}
+		seen[name] = true
+		sort = append(sort, models.ListSort{Column: field.Column, Desc: desc})
+	}
+	return sort, nil
+}
+
+func validateFilterValues(name string, field models.ListField, values []string) error {
+	for _, value := range values {
+		if value == "" {
+			return fmt.Errorf("valor vazio no filtro %s", name)
+		}
+		if len(field.Values) > 0 && !slices.Contains(field.Values, value) {
+			return fmt.Errorf("valor inválido no filtro %s: use %s", name, strings.Join(field.Values, ", "))
+		}
+		if field.Time {
+			if _, err := time.Parse(time.RFC3339, value); err != nil {
+				if _, err := time.Parse(time.DateOnly, value); err != nil {
+					return fmt.Errorf("data inválida no filtro %s: use RFC 3339 ou AAAA-MM-DD", name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// sortKey identifica a ordenação no cursor, para recusar um cursor gerado com outra ordenação.
+func sortKey(sort []models.ListSort) string {
+	columns := make([]string, 0, len(sort))
+	for _, s := range sort {
+		if s.Desc {
+			columns = append(columns, "-"+s.Column)
+		} else {
+			columns = append(columns, s.Column)
+		}
+	}
+	return strings.Join(columns, ",")
+}
+
+func encodeListCursor(sort string, values []string) string {
+	payload, _ := json.Marshal(listCursor{Sort: sort, Values: values})
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+func decodeListCursor(cursor, sort string) ([]string, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, models.ErrInvalidCursor
+	}
+	var decoded listCursor
+	if err := json.Unmarshal(payload, &decoded); err != nil || decoded.Sort != sort || len(decoded.Values) == 0 {
+		return nil, models.ErrInvalidCursor
+	}
+	return decoded.Values, nil
+}
+
+// respondList responde a página no envelope {data, pagination} e com o header Link (first, prev, next e last).
+// Quem pagina por cursor recebe o link next por cursor; os demais, por offset.
+func respondList[Entity any](c *gin.Context, query models.ListQuery, page *models.Page[Entity]) {
+	pagination := models.Pagination{Total: page.Total, Limit: query.Limit, Offset: query.Offset}
+	if len(query.Cursor) > 0 {
+		pagination.Offset = 0
+	}
+	if len(page.NextCursor) > 0 {
+		pagination.NextCursor = encodeListCursor(sortKey(query.Sort), page.NextCursor)
+	}
+
+	links := []string{listLink(c, "first", "offset", "0")}
+	if len(query.Cursor) == 0 && query.Offset > 0 {
+		links = append(links, listLink(c, "prev", "offset", strconv.Itoa(max(query.Offset-query.Limit, 0))))
+	}
+	if pagination.NextCursor != "" {
+		if c.Query("cursor") != "" {
+			links = append(links, listLink(c, "next", "cursor", pagination.NextCursor))
+		} else {
+			links = append(links, listLink(c, "next", "offset", strconv.Itoa(query.Offset+query.Limit)))
+		}
+	}
+	if page.Total > 0 {
+		last := (int(page.Total) - 1) / query.Limit * query.Limit
+		links = append(links, listLink(c, "last", "offset", strconv.Itoa(last)))
+	}
+	c.Header("Link", strings.Join(links, ", "))
+
+	items := page.Items
+	if items == nil {
+		items = []Entity{}
+	}
+	c.JSON(http.StatusOK, models.ListResponse[Entity]{Data: items, Pagination: pagination})
+}
+
+// listLink repete a requisição atual trocando a posição (offset ou cursor) pela informada.
+func listLink(c *gin.Context, rel, param, value string) string {
+	params := c.Request.URL.Query()
+	params.Del("offset")
+	params.Del("cursor")
+	if value != "0" {
+		params.Set(param, value)
+	}
+	link := url.URL{Path: c.Request.URL.Path, RawQuery: params.Encode()}
+	return fmt.Sprintf(`<%s>; rel="%s"`, link.String(), rel)
+}
+
+// respondListError responde os erros de parâmetros da listagem (400) e os demais (500).
+func respondListError(c *gin.Context, err error) {
+	if errors.Is(err, models.ErrInvalidCursor) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+// bindSearchInput lê os parâmetros da busca (q e limit) e responde 400 se forem inválidos.
+func bindSearchInput(c *gin.Context) (models.SearchInput, bool) {
+	var input models.SearchInput
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Informe o termo de busca q (2 a 100 caracteres) e limit entre 1 e 50"})
+		return input, false
+	}
+	if input.Limit == 0 {
+		input.Limit = defaultSearchLimit
+	}
+	return input, true
+}
@@ -0,0 +1,275 @@
+// internal/handlers_v1/me_handle.go
+
+package handlers_v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/logging"
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+)
+
+// MeHandler expõe as operações do usuário autenticado sobre a própria conta.
+// As rotas exigem apenas um access token válido, sem políticas do Casbin.
+type MeHandler struct {
+	userService       services.UserServiceInterface
+	tokenRedisService services.TokenRedisServiceInterface
+	casbinService     services.CasbinServiceInterface
+}
+
+// NewMeHandler cria uma nova instância de MeHandler.
+func NewMeHandler(userService services.UserServiceInterface, tokenRedisService services.TokenRedisServiceInterface, casbinService services.CasbinServiceInterface) *MeHandler {
+	return &MeHandler{
+		userService:       userService,
+		tokenRedisService: tokenRedisService,
+		casbinService:     casbinService,
+	}
+}
+
+// RegisterRoutes registra as rotas de /me.
+func (h *MeHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("", h.GetProfile)
+	router.PATCH("", h.UpdateProfile)
+	router.POST("/password", h.ChangePassword)
+	router.GET("/permissions", h.GetPermissions)
+	router.GET("/sessions", h.GetSessions)
+	router.DELETE("/sessions/:id", h.DeleteSession)
+}
+
+// GetProfile retorna o perfil do usuário autenticado.
+// @Summary Perfil do usuário autenticado
+// @Description Retorna os dados do usuário dono do access token
+// @Tags Me
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} models.User "Perfil do usuário"
+// @Failure 401 {object} models.HTTPError "Usuário não autenticado"
+// @Failure 404 {object} models.HTTPError "Usuário não encontrado"
+// @Router /api/v1/me [get]
+func (h *MeHandler) GetProfile(c *gin.Context) {
+	userRedis, userID, ok := getAuthenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.userService.GetByID(c, userID)
+	if err != nil {
+		logging.WarnLogger.Printf("Usuário %s do token não encontrado: %v", userRedis.ID, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Usuário não encontrado"})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// UpdateProfile altera nome, username e/ou thumbnail do usuário autenticado.
+// @Summary Atualiza o perfil do usuário autenticado
+// @Description Altera apenas os campos informados (name, username, thumbnail)
+// @Tags Me
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param profile body models.UserProfileUpdate true "Campos do perfil"
+// @Success 200 {object} models.User "Perfil atualizado"
+// @Failure 400 {object} models.HTTPError "Parâmetros de entrada inválidos"
+// @Failure 401 {object} models.HTTPError "Usuário não autenticado"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/me [patch]
+func (h *MeHandler) UpdateProfile(c *gin.Context) {
+	_, userID, ok := getAuthenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	var profile models.UserProfileUpdate
+	if err := c.ShouldBindJSON(&profile); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetros de entrada inválidos", "details": err.Error()})
+		return
+	}
+
+	user, err := h.userService.UpdateProfile(c, userID, &profile)
+	if err != nil {
+		logging.ErrorLogger.Printf("Erro ao atualizar perfil do usuário %s: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao atualizar o perfil"})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// ChangePassword troca a senha do usuário autenticado.
+// @Summary Troca a senha do usuário autenticado
+// @Description Exige a senha atual. As demais sessões do usuário são encerradas; a sessão atual é mantida.
+// @Tags Me
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body models.ChangePasswordRequest true "Senha atual e nova senha"
+// @Success 200 {object} map[string]string "Senha alterada"
+// @Failure 400 {object} models.HTTPError "Parâmetros de entrada inválidos ou senha atual incorreta"
+// @Failure 401 {object} models.HTTPError "Usuário não autenticado"
+// @Failure 422 {object} models.PasswordPolicyErrorResponse "Nova senha não atende à política"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/me/password [post]
+func (h *MeHandler) ChangePassword(c *gin.Context) {
+	userRedis, userID, ok := getAuthenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	var request models.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetros de entrada inválidos", "details": err.Error()})
+		return
+	}
+
+	if err := h.userService.ChangePassword(c, userID, request.CurrentPassword, request.NewPassword); err != nil {
+		if errors.Is(err, services.ErrInvalidCurrentPassword) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Senha atual incorreta"})
+			return
+		}
+		if respondPasswordPolicyError(c, err) {
+			return
+		}
+		logging.ErrorLogger.Printf("Erro ao trocar a senha do usuário %s: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao trocar a senha"})
+		return
+	}
+
+	if err := h.tokenRedisService.RevokeOtherSessions(userRedis.ID, userRedis.SessionID); err != nil {
+		logging.ErrorLogger.Printf("Senha alterada, mas falha ao encerrar as demais sessões do usuário %s: %v", userRedis.ID, err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Senha alterada com sucesso"})
+}
+
+// GetPermissions retorna a matriz de permissões do usuário autenticado.
+// @Summary Permissões do usuário autenticado
+// @Description Lista, por endpoint, as ações permitidas pelos roles (do tenant e globais) e pelas políticas especiais do usuário no seu tenant, conforme as políticas em vigor (e não as do login). Políticas com condição também aparecem. Serve para a interface esconder o que o usuário não pode fazer; a autorização continua sendo feita a cada requisição.
+// @Tags Me
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} models.UserPermissions "Roles e permissões por endpoint"
+// @Failure 401 {object} models.HTTPError "Usuário não autenticado"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/me/permissions [get]
+func (h *MeHandler) GetPermissions(c *gin.Context) {
+	userRedis, ok := getUserRedisFromContext(c)
+	if !ok {
+		return
+	}
+
+	permissions, err := h.casbinService.Permissions(userRedis, userRedis.TenantID)
+	if err != nil {
+		logging.ErrorLogger.Printf("Erro ao montar as permissões do usuário %s: %v", userRedis.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao consultar as permissões"})
+		return
+	}
+	c.JSON(http.StatusOK, permissions)
+}
+
+// GetSessions lista as sessões ativas do usuário autenticado.
+// @Summary Sessões ativas
+// @Description Lista as sessões (logins) ativas do usuário, indicando qual é a sessão atual
+// @Tags Me
+// @Produce json
+// @Security Bearer
+// @Success 200 {array} models.SessionInfo "Sessões ativas"
+// @Failure 401 {object} models.HTTPError "Usuário não autenticado"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/me/sessions [get]
+func (h *MeHandler) GetSessions(c *gin.Context) {
+	userRedis, ok := getUserRedisFromContext(c)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.tokenRedisService.ListSessions(userRedis.ID)
+	if err != nil {
+		logging.ErrorLogger.Printf("Erro ao listar sessões do usuário %s: %v", userRedis.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao listar as sessões"})
+		return
+	}
+
+	response := make([]models.SessionInfo, 0, len(sessions))
+	for _, session := range sessions {
+		response = append(response, models.SessionInfo{
+			ID:          session.ID,
+			IPAddress:   session.IPAddress,
+			UserAgent:   session.UserAgent,
+			CreatedAt:   session.CreatedAt,
+			RefreshedAt: session.RefreshedAt,
+			ExpiresAt:   session.ExpiresAt,
+			Current:     session.ID == userRedis.SessionID,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteSession encerra uma sessão do usuário autenticado.
+// @Summary Encerra uma sessão
+// @Description Revoga o access token e o refresh token de uma sessão do próprio usuário
+// @Tags Me
+// @Produce json
+// @Security Bearer
+// @Param id path string true "ID da sessão"
+// @Success 200 {object} map[string]string "Sessão encerrada"
+// @Failure 401 {object} models.HTTPError "Usuário não autenticado"
+// @Failure 404 {object} models.HTTPError "Sessão não encontrada"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/me/sessions/{id} [delete]
+func (h *MeHandler) DeleteSession(c *gin.Context) {
+	userRedis, ok := getUserRedisFromContext(c)
+	if !ok {
+		return
+	}
+
+	sessionID := c.Param("id")
+	sessions, err := h.tokenRedisService.ListSessions(userRedis.ID)
+	if err != nil {
+		logging.ErrorLogger.Printf("Erro ao listar sessões do usuário %s: %v", userRedis.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao encerrar a sessão"})
+		return
+	}
+
+	found := false
+	for _, session := range sessions {
+		if session.ID == sessionID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Sessão não encontrada"})
+		return
+	}
+
+	if err := h.tokenRedisService.RevokeSession(userRedis.ID, sessionID); err != nil {
+		logging.ErrorLogger.Printf("Falha ao revogar sessão %s do usuário %s: %v", sessionID, userRedis.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao encerrar a sessão"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Sessão encerrada"})
+}
+
+// getAuthenticatedUserID recupera o usuário autenticado do contexto e converte seu ID para uuid.UUID.
+func getAuthenticatedUserID(c *gin.Context) (*models.UserRedis, uuid.UUID, bool) {
+	userRedis, ok := getUserRedisFromContext(c)
+	if !ok {
+		return nil, uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(userRedis.ID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return nil, uuid.Nil, false
+	}
+
+	return userRedis, userID, true
+}
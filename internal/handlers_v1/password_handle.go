@@ -0,0 +1,109 @@
+// internal/handlers_v1/password_handle.go
+
+package handlers_v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/logging"
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+)
+
+// PasswordHandler trata o fluxo de redefinição de senha (esqueci minha senha).
+type PasswordHandler struct {
+	passwordResetService services.PasswordResetServiceInterface
+}
+
+// NewPasswordHandler cria uma nova instância de PasswordHandler.
+func NewPasswordHandler(passwordResetService services.PasswordResetServiceInterface) *PasswordHandler {
+	return &PasswordHandler{passwordResetService: passwordResetService}
+}
+
+// RegisterRoutes registra as rotas de redefinição de senha.
+func (h *PasswordHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/forgot", h.Forgot)
+	router.POST("/reset", h.Reset)
+}
+
+// Forgot solicita a redefinição de senha.
+// @Summary Solicita redefinição de senha
+// @Description Envia por e-mail um token de uso único para redefinir a senha. A resposta é a mesma para e-mails cadastrados ou não.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body models.ForgotPasswordRequest true "E-mail do usuário"
+// @Success 202 {object} map[string]string "Pedido recebido"
+// @Failure 400 {object} map[string]string "Parâmetros de entrada inválidos"
+// @Router /api/v1/auth/password/forgot [post]
+func (h *PasswordHandler) Forgot(c *gin.Context) {
+	origin := c.GetString("Origin")
+	if origin == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Origem não fornecida"})
+		return
+	}
+
+	var request models.ForgotPasswordRequest
+	if err := c.ShouldBind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetros de entrada inválidos", "details": err.Error()})
+		return
+	}
+
+	// Falhas no envio (Redis, e-mail) só acontecem para e-mails cadastrados: são registradas, mas a resposta
+	// é sempre a mesma, para não revelar quais e-mails existem.
+	if err := h.passwordResetService.RequestPasswordReset(c, request.Email, origin); err != nil {
+		logging.ErrorLogger.Printf("Erro ao solicitar redefinição de senha: %v", err)
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "Se o e-mail estiver cadastrado, você receberá as instruções para redefinir a senha"})
+}
+
+// Reset redefine a senha usando o token recebido por e-mail.
+// @Summary Redefine a senha
+// @Description Consome o token de redefinição (uso único), grava a nova senha e encerra todas as sessões do usuário.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body models.ResetPasswordRequest true "Token e nova senha"
+// @Success 200 {object} map[string]string "Senha redefinida"
+// @Failure 400 {object} map[string]string "Parâmetros de entrada inválidos ou token inválido/expirado"
+// @Failure 422 {object} models.PasswordPolicyErrorResponse "Senha não atende à política"
+// @Failure 500 {object} map[string]string "Erro ao redefinir a senha"
+// @Router /api/v1/auth/password/reset [post]
+func (h *PasswordHandler) Reset(c *gin.Context) {
+	var request models.ResetPasswordRequest
+	if err := c.ShouldBind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetros de entrada inválidos", "details": err.Error()})
+		return
+	}
+
+	if err := h.passwordResetService.ResetPassword(c, request.Token, request.Password); err != nil {
+		if errors.Is(err, services.ErrPasswordResetTokenInvalid) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Token inválido ou expirado"})
+			return
+		}
+		if respondPasswordPolicyError(c, err) {
+			return
+		}
+		logging.ErrorLogger.Printf("Erro ao redefinir senha: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao redefinir a senha"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Senha redefinida com sucesso"})
+}
+
+// respondPasswordPolicyError responde 422 com as violações quando err é um *services.PasswordPolicyError.
+func respondPasswordPolicyError(c *gin.Context, err error) bool {
+	var policyErr *services.PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		return false
+	}
+	c.JSON(http.StatusUnprocessableEntity, models.PasswordPolicyErrorResponse{
+		Error:      "A senha não atende à política de senhas",
+		Violations: policyErr.Violations,
+	})
+	return true
+}
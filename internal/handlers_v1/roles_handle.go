@@ -0,0 +1,334 @@
+// internal/handlers_v1/roles_handle.go
+
+package handlers_v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/logging"
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+)
+
+// RolesHandler expõe a administração dos roles e das suas políticas (policies_roles).
+type RolesHandler struct {
+	securityService services.SecurityServiceInterface
+}
+
+// NewRolesHandler cria uma nova instância de RolesHandler.
+func NewRolesHandler(securityService services.SecurityServiceInterface) *RolesHandler {
+	return &RolesHandler{securityService: securityService}
+}
+
+// RegisterRoutes registra as rotas de /roles.
+func (h *RolesHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("", h.GetAll)
+	router.POST("", h.Create)
+	router.GET("/:id", h.GetById)
+	router.PUT("/:id", h.Update)
+	router.DELETE("/:id", h.Delete)
+	router.GET("/:id/policies", h.GetPolicies)
+	router.POST("/:id/policies", h.CreatePolicy)
+	router.PUT("/:id/policies/:endpoint_id", h.UpdatePolicy)
+	router.DELETE("/:id/policies/:endpoint_id", h.DeletePolicy)
+}
+
+// GetAll lista os roles.
+// @Summary Lista os Roles
+// @Description Lista todos os roles, ordenados pelo nome
+// @Tags Roles
+// @Produce json
+// @Security Bearer
+// @Success 200 {array} models.Role "Lista de Roles"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/roles [get]
+func (h *RolesHandler) GetAll(c *gin.Context) {
+	roles, err := h.securityService.ListRoles(c)
+	if err != nil {
+		handleSecurityError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, roles)
+}
+
+// Create cria um role.
+// @Summary Cria um Role
+// @Description Cria um role do tenant, sem políticas. Com "global": true cria um role válido em todos os tenants (apenas o role master).
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param role body models.RoleInput true "Nome do Role e escopo"
+// @Success 201 {object} models.Role "Role criado"
+// @Failure 400 {object} models.HTTPError "Erro de Formato de Solicitação"
+// @Failure 403 {object} models.HTTPError "Role global exige o role master"
+// @Failure 409 {object} models.HTTPError "Nome já existe"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/roles [post]
+func (h *RolesHandler) Create(c *gin.Context) {
+	var input models.RoleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	role, err := h.securityService.CreateRole(c, input)
+	if err != nil {
+		handleSecurityError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, role)
+}
+
+// GetById busca um role.
+// @Summary Busca Role por ID
+// @Description Busca um role pelo ID
+// @Tags Roles
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Role ID"
+// @Success 200 {object} models.Role "Role"
+// @Failure 400 {object} models.HTTPError "ID Inválido"
+// @Failure 404 {object} models.HTTPError "Role não encontrado"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/roles/{id} [get]
+func (h *RolesHandler) GetById(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	role, err := h.securityService.GetRole(c, id)
+	if err != nil {
+		handleSecurityError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, role)
+}
+
+// Update renomeia um role.
+// @Summary Renomeia um Role
+// @Description Renomeia o role. O role master não pode ser renomeado.
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Role ID"
+// @Param role body models.RoleInput true "Novo nome do Role"
+// @Success 200 {object} models.Role "Role atualizado"
+// @Failure 400 {object} models.HTTPError "Erro de Formato de Solicitação"
+// @Failure 403 {object} models.HTTPError "Role protegido ou global"
+// @Failure 404 {object} models.HTTPError "Role não encontrado"
+// @Failure 409 {object} models.HTTPError "Nome já existe"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/roles/{id} [put]
+func (h *RolesHandler) Update(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var input models.RoleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	role, err := h.securityService.RenameRole(c, id, input)
+	if err != nil {
+		handleSecurityError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, role)
+}
+
+// Delete remove um role.
+// @Summary Remove um Role
+// @Description Remove o role e suas políticas. Roles atribuídos a usuários e o role master não são removidos.
+// @Tags Roles
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Role ID"
+// @Success 200 {object} map[string]string "Role removido"
+// @Failure 400 {object} models.HTTPError "ID Inválido"
+// @Failure 403 {object} models.HTTPError "Role protegido ou global"
+// @Failure 404 {object} models.HTTPError "Role não encontrado"
+// @Failure 409 {object} models.HTTPError "Role atribuído a usuários"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/roles/{id} [delete]
+func (h *RolesHandler) Delete(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.securityService.DeleteRole(c, id); err != nil {
+		handleSecurityError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Role deleted successfully"})
+}
+
+// GetPolicies lista as políticas do role.
+// @Summary Lista as políticas do Role
+// @Description Lista os endpoints e as ações permitidas ao role
+// @Tags Roles
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Role ID"
+// @Success 200 {array} models.PolicyRole "Políticas do Role"
+// @Failure 400 {object} models.HTTPError "ID Inválido"
+// @Failure 404 {object} models.HTTPError "Role não encontrado"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/roles/{id}/policies [get]
+func (h *RolesHandler) GetPolicies(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	policies, err := h.securityService.ListRolePolicies(c, id)
+	if err != nil {
+		handleSecurityError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, policies)
+}
+
+// CreatePolicy concede ações sobre um endpoint ao role.
+// @Summary Cria uma política para o Role
+// @Description Concede ao role as ações (GET, POST, PUT, PATCH, DELETE separados por |) sobre o endpoint. As políticas do Casbin são recarregadas.
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Role ID"
+// @Param policy body models.PolicyInput true "Endpoint e ações"
+// @Success 201 {object} models.PolicyRole "Política criada"
+// @Failure 400 {object} models.HTTPError "Erro de Formato de Solicitação, ações ou condição inválidas"
+// @Failure 403 {object} models.HTTPError "Role global ou ações que o usuário não possui"
+// @Failure 404 {object} models.HTTPError "Role ou endpoint não encontrado"
+// @Failure 409 {object} models.HTTPError "Política já existe"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/roles/{id}/policies [post]
+func (h *RolesHandler) CreatePolicy(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var input models.PolicyInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	policy, err := h.securityService.CreateRolePolicy(c, id, input)
+	if err != nil {
+		handleSecurityError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, policy)
+}
+
+// UpdatePolicy altera as ações de uma política do role.
+// @Summary Altera uma política do Role
+// @Description Substitui as ações permitidas ao role sobre o endpoint
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Role ID"
+// @Param endpoint_id path int true "Endpoint ID"
+// @Param policy body models.PolicyActionsInput true "Ações"
+// @Success 200 {object} models.PolicyRole "Política atualizada"
+// @Failure 400 {object} models.HTTPError "Erro de Formato de Solicitação, ações ou condição inválidas"
+// @Failure 403 {object} models.HTTPError "Role global ou ações que o usuário não possui"
+// @Failure 404 {object} models.HTTPError "Política não encontrada"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/roles/{id}/policies/{endpoint_id} [put]
+func (h *RolesHandler) UpdatePolicy(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+	endpointID, ok := parseUintParam(c, "endpoint_id")
+	if !ok {
+		return
+	}
+
+	var input models.PolicyActionsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	policy, err := h.securityService.UpdateRolePolicy(c, id, endpointID, input)
+	if err != nil {
+		handleSecurityError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, policy)
+}
+
+// DeletePolicy remove uma política do role.
+// @Summary Remove uma política do Role
+// @Description Retira do role o acesso ao endpoint
+// @Tags Roles
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Role ID"
+// @Param endpoint_id path int true "Endpoint ID"
+// @Success 200 {object} map[string]string "Política removida"
+// @Failure 400 {object} models.HTTPError "ID Inválido"
+// @Failure 404 {object} models.HTTPError "Política não encontrada"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/roles/{id}/policies/{endpoint_id} [delete]
+func (h *RolesHandler) DeletePolicy(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+	endpointID, ok := parseUintParam(c, "endpoint_id")
+	if !ok {
+		return
+	}
+
+	if err := h.securityService.DeleteRolePolicy(c, id, endpointID); err != nil {
+		handleSecurityError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Policy deleted successfully"})
+}
+
+// parseUintParam lê um ID inteiro da URL, respondendo 400 se for inválido.
+func parseUintParam(c *gin.Context, name string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 32)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// handleSecurityError converte os erros do SecurityService em respostas HTTP.
+func handleSecurityError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrRoleNotFound), errors.Is(err, services.ErrEndpointNotFound), errors.Is(err, services.ErrPolicyNotFound), errors.Is(err, services.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrSecurityConflict), errors.Is(err, services.ErrSecurityInUse):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidActions), errors.Is(err, services.ErrInvalidCondition):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrProtectedRole), errors.Is(err, services.ErrGlobalRole), errors.Is(err, services.ErrActionsNotGranted):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrSecurityContext):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	default:
+		logging.ErrorLogger.Printf("Erro na administração de permissões: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro interno do servidor"})
+	}
+}
@@ -3,6 +3,7 @@
 package handlers_v1
 
 import (
+	"errors"
 	"log"
 	"net/http"
 
@@ -22,33 +23,75 @@ func NewTenantsHandler(tenantService services.TenantServiceInterface) *TenantsHa
 	return &TenantsHandler{tenantService: tenantService}
 }
 
-// RegisterRoutes registra as rotas para tenants.
-func (h *TenantsHandler) RegisterRoutes(router *gin.RouterGroup) {
+// RegisterRoutes registra as rotas para tenants. createMiddlewares são aplicados apenas ao POST de criação
+// (ex.: Idempotency-Key).
+func (h *TenantsHandler) RegisterRoutes(router *gin.RouterGroup, createMiddlewares ...gin.HandlerFunc) {
 	router.GET("", h.GetAll)
+	router.GET("/search", h.Search)
+	router.GET("/trash", h.GetTrash)
 	router.GET("/:id", h.GetById)
-	router.POST("", h.Create)
+	router.POST("", append(createMiddlewares, h.Create)...)
 	router.PUT("/:id", h.Update)
 	router.PATCH("/:id", h.UpdatePatch)
 	router.DELETE("/:id", h.Delete)
+	router.POST("/:id/restore", h.Restore)
 }
 
-// getAllTenants busca todos os Tenants
-// @Summary Busca todos os Tenants
-// @Description Busca todos os Tenants
+// getAllTenants lista os Tenants
+// @Summary Lista os Tenants
+// @Description Lista os Tenants com filtros, ordenação e paginação. Filtros: campo=valor ou campo[op]=valor, com op in (valores separados por vírgula), like (contém), gte ou lte.
+// @Description Campos: name (eq, in, like), type e status (eq, in), cpf_cnpj e email (eq, like), city (eq, in, like), state (eq, in), created_at e updated_at (gte, lte).
+// @Description Ordenação por name, type, status, created_at e updated_at (prefixo - para decrescente). O header Link traz as páginas first, prev, next e last.
 // @Tags Tenants
 // @Accept  json
 // @Produce  json
-// @Success 200 {array} models.Tenant "Lista de Tenants"
+// @Param   limit  query   int     false "Itens por página (1 a 200)" default(50)
+// @Param   offset query   int     false "Itens a pular (não use com cursor)"
+// @Param   cursor query   string  false "Cursor next_cursor da página anterior"
+// @Param   sort   query   string  false "Ordenação, ex.: -created_at,name" default(name)
+// @Success 200 {object} models.ListResponse[models.Tenant] "Página de Tenants"
+// @Failure 400 {object} models.HTTPError "Filtro, ordenação ou paginação inválidos"
 // @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
 // @Router /api/v1/tenants [get]
 func (h *TenantsHandler) GetAll(c *gin.Context) {
-	// ctx := context.Background()
-	tenants, err := h.tenantService.GetAll(c)
+	query, err := parseListQuery(c, models.TenantListSpec)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := h.tenantService.GetAll(c, query)
+	if err != nil {
+		respondListError(c, err)
+		return
+	}
+	respondList(c, query, page)
+}
+
+// Search busca Tenants por texto
+// @Summary Busca Tenants por texto
+// @Description Busca os Tenants por nome, CPF/CNPJ (com ou sem pontuação), cidade ou e-mail. Busca por prefixo, sem diferenciar acentos e maiúsculas, e tolera erros de digitação; os resultados vêm do mais ao menos relevante.
+// @Tags Tenants
+// @Accept  json
+// @Produce  json
+// @Param   q     query   string  true  "Termo de busca (2 a 100 caracteres)"
+// @Param   limit query   int     false "Máximo de resultados (1 a 50)" default(20)
+// @Success 200 {object} models.SearchResponse[models.Tenant] "Tenants encontrados"
+// @Failure 400 {object} models.HTTPError "Termo de busca inválido"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/tenants/search [get]
+func (h *TenantsHandler) Search(c *gin.Context) {
+	input, ok := bindSearchInput(c)
+	if !ok {
+		return
+	}
+
+	tenants, err := h.tenantService.Search(c, input.Q, input.Limit)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, tenants)
+	c.JSON(http.StatusOK, models.SearchResponse[models.Tenant]{Data: tenants})
 }
 
 // createTenant cria um novo Tenant
@@ -58,8 +101,11 @@ func (h *TenantsHandler) GetAll(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param tenant body models.Tenant true "Informações do Tenant"
+// @Param Idempotency-Key header string false "Chave única da operação; as repetições com a mesma chave recebem a resposta original"
 // @Success 201 {object} models.Tenant "Tenant Criado"
+// @Header  201 {string} ETag "Versão do Tenant"
 // @Failure 400 {object} models.HTTPError "Erro de Formato de Solicitação"
+// @Failure 409 {object} models.HTTPError "Idempotency-Key já usada com outro conteúdo ou ainda em andamento"
 // @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
 // @Router /api/v1/tenants [post]
 func (h *TenantsHandler) Create(c *gin.Context) {
@@ -74,6 +120,7 @@ func (h *TenantsHandler) Create(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Tenant not found"})
 		return
 	}
+	setETag(c, tenant.Version)
 	c.JSON(http.StatusCreated, tenant)
 }
 
@@ -85,6 +132,7 @@ func (h *TenantsHandler) Create(c *gin.Context) {
 // @Produce  json
 // @Param   id     path    string     true        "Tenant ID"
 // @Success 200 {object} models.Tenant "Tenant"
+// @Header  200 {string} ETag "Versão do Tenant, para o If-Match de PUT, PATCH e DELETE"
 // @Failure 404 {object} models.HTTPError "Tenant not found"
 // @Failure 400 {object} models.HTTPError "Invalid UUID format"
 // @Router /api/v1/tenants/{id} [get]
@@ -100,19 +148,23 @@ func (h *TenantsHandler) GetById(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Tenant not found"})
 		return
 	}
+	setETag(c, tenant.Version)
 	c.JSON(http.StatusOK, tenant)
 }
 
 // updateTenant atualiza um tenant existente usando PUT.
 // @Summary Atualiza um Tenant existente
-// @Description Atualiza um Tenant existente com base no ID fornecido
+// @Description Atualiza um Tenant existente com base no ID fornecido. Com If-Match (ETag do GET), a atualização só é feita se o Tenant não tiver sido alterado desde então.
 // @Tags Tenants
 // @Accept  json
 // @Produce  json
 // @Param   id     path    string     true        "Tenant ID"
+// @Param   If-Match header string    false       "ETag da versão lida"
 // @Param   tenant body    models.Tenant true "Dados do Tenant"
 // @Success 200 {object} models.Tenant "Tenant Atualizado"
+// @Header  200 {string} ETag "Nova versão do Tenant"
 // @Failure 400 {object} models.HTTPError "ID Inválido ou Erro de Formato de Solicitação"
+// @Failure 412 {object} models.HTTPError "O Tenant foi alterado depois da versão do If-Match"
 // @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
 // @Router /api/v1/tenants/{id} [put]
 func (h *TenantsHandler) Update(c *gin.Context) {
@@ -131,25 +183,36 @@ func (h *TenantsHandler) Update(c *gin.Context) {
 	// Opcional: Definir o ID do tenant com o valor extraído da URL, garantindo que o recurso correto seja atualizado.
 	tenant.ID = id
 
+	if !bindIfMatch(c) {
+		return
+	}
+
 	tenantUpdated, err := h.tenantService.Update(c, id, &tenant)
 	if err != nil {
+		if respondVersionMismatch(c, err) {
+			return
+		}
 		c.JSON(http.StatusNotFound, gin.H{"error": "Tenant not found"})
 		return
 	}
 
+	setETag(c, tenantUpdated.Version)
 	c.JSON(http.StatusOK, tenantUpdated)
 }
 
 // updateTenantPatch atualiza parcialmente um tenant existente usando PATCH.
 // @Summary Atualiza parcialmente um Tenant existente
-// @Description Atualiza parcialmente um Tenant existente com base no ID fornecido
+// @Description Atualiza parcialmente um Tenant existente com base no ID fornecido. Com If-Match (ETag do GET), a atualização só é feita se o Tenant não tiver sido alterado desde então.
 // @Tags Tenants
 // @Accept  json
 // @Produce  json
 // @Param   id     path    string     true        "Tenant ID"
+// @Param   If-Match header string    false       "ETag da versão lida"
 // @Param   tenant body    models.Tenant true "Dados atualizáveis do Tenant"
 // @Success 200 {object} gin.H "Mensagem de sucesso"
+// @Header  200 {string} ETag "Nova versão do Tenant"
 // @Failure 400 {object} models.HTTPError "ID Inválido ou Erro de Formato de Solicitação"
+// @Failure 412 {object} models.HTTPError "O Tenant foi alterado depois da versão do If-Match"
 // @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
 // @Router /api/v1/tenants/{id} [patch]
 func (h *TenantsHandler) UpdatePatch(c *gin.Context) {
@@ -168,24 +231,37 @@ func (h *TenantsHandler) UpdatePatch(c *gin.Context) {
 	// Remover campos que não devem ser atualizáveis
 	// delete(updateData, "cpf_cnpj")
 
+	if !bindIfMatch(c) {
+		return
+	}
+
 	tenantPatched, err := h.tenantService.UpdatePartial(c, id, updateData)
 	if err != nil {
+		if respondVersionMismatch(c, err) {
+			return
+		}
 		c.JSON(http.StatusNotFound, gin.H{"error": "Tenant not found"})
 		return
 	}
 
+	setETag(c, tenantPatched.Version)
 	c.JSON(http.StatusOK, tenantPatched)
 }
 
 // deleteTenant exclui um tenant.
 // @Summary Exclui um Tenant
-// @Description Exclui um Tenant com base no ID fornecido
+// @Description Exclui um Tenant com base no ID fornecido. Por padrão, o Tenant vai para a lixeira e pode ser restaurado; com hard=true (política própria, em /api/v1/tenants/:id/purge), é removido definitivamente com as suas API Keys.
 // @Tags Tenants
 // @Accept  json
 // @Produce  json
 // @Param   id     path    string     true        "Tenant ID"
+// @Param   hard   query   bool       false       "Remove definitivamente"
+// @Param   If-Match header string    false       "ETag da versão lida"
 // @Success 200 {object} gin.H "Mensagem de sucesso"
 // @Failure 400 {object} models.HTTPError "ID Inválido"
+// @Failure 404 {object} models.HTTPError "Tenant not found"
+// @Failure 409 {object} models.HTTPError "O Tenant ainda tem usuários ou roles"
+// @Failure 412 {object} models.HTTPError "O Tenant foi alterado depois da versão do If-Match"
 // @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
 // @Router /api/v1/tenants/{id} [delete]
 func (h *TenantsHandler) Delete(c *gin.Context) {
@@ -194,11 +270,108 @@ func (h *TenantsHandler) Delete(c *gin.Context) {
 		log.Fatalf("Invalid UUID: %v", err)
 	}
 
+	if !bindIfMatch(c) {
+		return
+	}
+
+	if c.Query("hard") == "true" {
+		h.purge(c, id)
+		return
+	}
+
 	// ctx := context.Background()
 	if err := h.tenantService.Delete(c, id); err != nil {
+		if respondVersionMismatch(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Tenant deleted successfully"})
 }
+
+func (h *TenantsHandler) purge(c *gin.Context, id uuid.UUID) {
+	if err := h.tenantService.Purge(c, id); err != nil {
+		if respondVersionMismatch(c, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, services.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Tenant not found"})
+		case errors.Is(err, services.ErrTenantHasDependents):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Tenant permanently deleted"})
+}
+
+// GetTrash lista os Tenants removidos
+// @Summary Lista os Tenants na lixeira
+// @Description Lista os Tenants removidos (exclusão lógica), com os mesmos filtros de GET /api/v1/tenants, mais deleted_at (gte, lte). Por padrão, os removidos mais recentemente primeiro.
+// @Tags Tenants
+// @Accept  json
+// @Produce  json
+// @Param   limit  query   int     false "Itens por página (1 a 200)" default(50)
+// @Param   offset query   int     false "Itens a pular (não use com cursor)"
+// @Param   cursor query   string  false "Cursor next_cursor da página anterior"
+// @Param   sort   query   string  false "Ordenação, ex.: -deleted_at" default(-deleted_at)
+// @Success 200 {object} models.ListResponse[models.Tenant] "Página de Tenants removidos"
+// @Failure 400 {object} models.HTTPError "Filtro, ordenação ou paginação inválidos"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/tenants/trash [get]
+func (h *TenantsHandler) GetTrash(c *gin.Context) {
+	query, err := parseListQuery(c, models.TenantListSpec.Trash())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := h.tenantService.GetTrash(c, query)
+	if err != nil {
+		respondListError(c, err)
+		return
+	}
+	respondList(c, query, page)
+}
+
+// Restore restaura um Tenant removido
+// @Summary Restaura um Tenant da lixeira
+// @Description Restaura um Tenant removido. Se estiver ATIVO, a suspensão aplicada na remoção é desfeita; os usuários precisam logar novamente.
+// @Tags Tenants
+// @Accept  json
+// @Produce  json
+// @Param   id     path    string     true        "Tenant ID"
+// @Success 200 {object} models.Tenant "Tenant restaurado"
+// @Failure 400 {object} models.HTTPError "ID Inválido"
+// @Failure 404 {object} models.HTTPError "Tenant não encontrado na lixeira"
+// @Failure 409 {object} models.HTTPError "Outro Tenant ativo usa o mesmo CPF/CNPJ ou e-mail"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/tenants/{id}/restore [post]
+func (h *TenantsHandler) Restore(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid UUID format"})
+		return
+	}
+
+	tenant, err := h.tenantService.Restore(c, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Tenant não encontrado na lixeira"})
+		case errors.Is(err, services.ErrRestoreConflict):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	setETag(c, tenant.Version)
+	c.JSON(http.StatusOK, tenant)
+}
@@ -0,0 +1,174 @@
+// internal/handlers_v1/two_factor_handle.go
+
+package handlers_v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/logging"
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+)
+
+// TwoFactorHandler expõe a configuração do 2FA (TOTP) do usuário autenticado.
+type TwoFactorHandler struct {
+	twoFactorService services.TwoFactorServiceInterface
+}
+
+// NewTwoFactorHandler cria uma nova instância de TwoFactorHandler.
+func NewTwoFactorHandler(twoFactorService services.TwoFactorServiceInterface) *TwoFactorHandler {
+	return &TwoFactorHandler{twoFactorService: twoFactorService}
+}
+
+// RegisterRoutes registra as rotas de /me/2fa.
+func (h *TwoFactorHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/enroll", h.Enroll)
+	router.POST("/confirm", h.Confirm)
+	router.POST("/disable", h.Disable)
+	router.POST("/recovery-codes", h.RegenerateRecoveryCodes)
+}
+
+// Enroll inicia a configuração do 2FA.
+// @Summary Inicia a configuração do 2FA
+// @Description Gera um novo segredo TOTP e a URI otpauth:// para o aplicativo autenticador. O 2FA só é ativado após a confirmação.
+// @Tags Me
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} models.TOTPEnrollment "Segredo e URI de provisionamento"
+// @Failure 401 {object} models.HTTPError "Usuário não autenticado"
+// @Failure 409 {object} models.HTTPError "2FA já está ativo"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/me/2fa/enroll [post]
+func (h *TwoFactorHandler) Enroll(c *gin.Context) {
+	_, userID, ok := getAuthenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	enrollment, err := h.twoFactorService.BeginEnrollment(c, userID)
+	if err != nil {
+		handleTwoFactorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, enrollment)
+}
+
+// Confirm ativa o 2FA a partir do primeiro código gerado pelo aplicativo.
+// @Summary Confirma e ativa o 2FA
+// @Description Valida o código TOTP do segredo gerado em /enroll, ativa o 2FA e retorna os códigos de recuperação (exibidos uma única vez)
+// @Tags Me
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body models.TwoFactorCodeRequest true "Código TOTP"
+// @Success 200 {object} models.RecoveryCodesResponse "Códigos de recuperação"
+// @Failure 400 {object} models.HTTPError "Parâmetros de entrada inválidos, código inválido ou configuração não iniciada"
+// @Failure 401 {object} models.HTTPError "Usuário não autenticado"
+// @Failure 409 {object} models.HTTPError "2FA já está ativo"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/me/2fa/confirm [post]
+func (h *TwoFactorHandler) Confirm(c *gin.Context) {
+	_, userID, ok := getAuthenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	var request models.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetros de entrada inválidos", "details": err.Error()})
+		return
+	}
+
+	codes, err := h.twoFactorService.ConfirmEnrollment(c, userID, request.Code)
+	if err != nil {
+		handleTwoFactorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// Disable desativa o 2FA do usuário autenticado.
+// @Summary Desativa o 2FA
+// @Description Exige um código TOTP ou de recuperação válido. Remove o segredo e os códigos de recuperação.
+// @Tags Me
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body models.TwoFactorCodeRequest true "Código TOTP ou de recuperação"
+// @Success 200 {object} map[string]string "2FA desativado"
+// @Failure 400 {object} models.HTTPError "Parâmetros de entrada inválidos, código inválido ou 2FA inativo"
+// @Failure 401 {object} models.HTTPError "Usuário não autenticado"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/me/2fa/disable [post]
+func (h *TwoFactorHandler) Disable(c *gin.Context) {
+	_, userID, ok := getAuthenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	var request models.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetros de entrada inválidos", "details": err.Error()})
+		return
+	}
+
+	if err := h.twoFactorService.Disable(c, userID, request.Code); err != nil {
+		handleTwoFactorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Verificação em duas etapas desativada"})
+}
+
+// RegenerateRecoveryCodes gera novos códigos de recuperação, invalidando os anteriores.
+// @Summary Gera novos códigos de recuperação
+// @Description Exige um código TOTP ou de recuperação válido. Os códigos anteriores deixam de valer.
+// @Tags Me
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body models.TwoFactorCodeRequest true "Código TOTP ou de recuperação"
+// @Success 200 {object} models.RecoveryCodesResponse "Novos códigos de recuperação"
+// @Failure 400 {object} models.HTTPError "Parâmetros de entrada inválidos, código inválido ou 2FA inativo"
+// @Failure 401 {object} models.HTTPError "Usuário não autenticado"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/me/2fa/recovery-codes [post]
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	_, userID, ok := getAuthenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	var request models.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetros de entrada inválidos", "details": err.Error()})
+		return
+	}
+
+	codes, err := h.twoFactorService.RegenerateRecoveryCodes(c, userID, request.Code)
+	if err != nil {
+		handleTwoFactorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// handleTwoFactorError converte os erros do TwoFactorService em respostas HTTP.
+func handleTwoFactorError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrTwoFactorAlreadyEnabled):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrTwoFactorNotEnabled),
+		errors.Is(err, services.ErrTwoFactorNotEnrolled),
+		errors.Is(err, services.ErrInvalidTwoFactorCode):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		logging.ErrorLogger.Printf("Erro no 2FA: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro interno do servidor"})
+	}
+}
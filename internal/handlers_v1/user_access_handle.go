@@ -0,0 +1,273 @@
+// internal/handlers_v1/user_access_handle.go
+
+package handlers_v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+)
+
+// UserAccessHandler expõe os roles (users_roles) e as políticas especiais (policies_users) de um usuário do tenant.
+type UserAccessHandler struct {
+	userService     services.UserServiceInterface
+	securityService services.SecurityServiceInterface
+}
+
+// NewUserAccessHandler cria uma nova instância de UserAccessHandler.
+func NewUserAccessHandler(userService services.UserServiceInterface, securityService services.SecurityServiceInterface) *UserAccessHandler {
+	return &UserAccessHandler{userService: userService, securityService: securityService}
+}
+
+// RegisterRoutes registra as rotas de /users/:id/roles e /users/:id/policies.
+func (h *UserAccessHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/:id/roles", h.GetRoles)
+	router.POST("/:id/roles", h.AddRole)
+	router.DELETE("/:id/roles/:role_id", h.RemoveRole)
+	router.GET("/:id/policies", h.GetPolicies)
+	router.POST("/:id/policies", h.CreatePolicy)
+	router.PUT("/:id/policies/:endpoint_id", h.UpdatePolicy)
+	router.DELETE("/:id/policies/:endpoint_id", h.DeletePolicy)
+}
+
+// GetRoles lista os roles do usuário.
+// @Summary Lista os Roles do User
+// @Description Lista os roles atribuídos ao User
+// @Tags Users
+// @Produce json
+// @Security Bearer
+// @Param id path string true "User ID"
+// @Success 200 {array} models.Role "Roles do User"
+// @Failure 400 {object} models.HTTPError "ID Inválido"
+// @Failure 404 {object} models.HTTPError "User not found"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/users/{id}/roles [get]
+func (h *UserAccessHandler) GetRoles(c *gin.Context) {
+	userID, ok := h.getUserID(c)
+	if !ok {
+		return
+	}
+
+	roles, err := h.securityService.ListUserRoles(c, userID)
+	if err != nil {
+		handleSecurityError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, roles)
+}
+
+// AddRole atribui um role ao usuário.
+// @Summary Atribui um Role ao User
+// @Description Atribui o role ao User e encerra as sessões dele; o novo role vale a partir do próximo login
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "User ID"
+// @Param role body models.UserRoleInput true "Role"
+// @Success 201 {object} map[string]string "Role atribuído"
+// @Failure 400 {object} models.HTTPError "Erro de Formato de Solicitação"
+// @Failure 403 {object} models.HTTPError "Role global exige o role master"
+// @Failure 404 {object} models.HTTPError "User ou Role não encontrado"
+// @Failure 409 {object} models.HTTPError "Role já atribuído"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/users/{id}/roles [post]
+func (h *UserAccessHandler) AddRole(c *gin.Context) {
+	userID, ok := h.getUserID(c)
+	if !ok {
+		return
+	}
+
+	var input models.UserRoleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.securityService.AddUserRole(c, userID, input.RoleID); err != nil {
+		handleSecurityError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "Role assigned successfully"})
+}
+
+// RemoveRole retira um role do usuário.
+// @Summary Retira um Role do User
+// @Description Retira o role do User e encerra as sessões dele
+// @Tags Users
+// @Produce json
+// @Security Bearer
+// @Param id path string true "User ID"
+// @Param role_id path int true "Role ID"
+// @Success 200 {object} map[string]string "Role retirado"
+// @Failure 400 {object} models.HTTPError "ID Inválido"
+// @Failure 403 {object} models.HTTPError "Role global exige o role master"
+// @Failure 404 {object} models.HTTPError "User não encontrado ou Role não atribuído"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/users/{id}/roles/{role_id} [delete]
+func (h *UserAccessHandler) RemoveRole(c *gin.Context) {
+	roleID, ok := parseUintParam(c, "role_id")
+	if !ok {
+		return
+	}
+	userID, ok := h.getUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.securityService.RemoveUserRole(c, userID, roleID); err != nil {
+		handleSecurityError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Role removed successfully"})
+}
+
+// GetPolicies lista as políticas especiais do usuário.
+// @Summary Lista as políticas do User
+// @Description Lista as políticas especiais do User, concedidas além das dos seus roles
+// @Tags Users
+// @Produce json
+// @Security Bearer
+// @Param id path string true "User ID"
+// @Success 200 {array} models.PolicyUser "Políticas do User"
+// @Failure 400 {object} models.HTTPError "ID Inválido"
+// @Failure 404 {object} models.HTTPError "User not found"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/users/{id}/policies [get]
+func (h *UserAccessHandler) GetPolicies(c *gin.Context) {
+	userID, ok := h.getUserID(c)
+	if !ok {
+		return
+	}
+
+	policies, err := h.securityService.ListUserPolicies(c, userID)
+	if err != nil {
+		handleSecurityError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, policies)
+}
+
+// CreatePolicy concede ações sobre um endpoint ao usuário.
+// @Summary Cria uma política para o User
+// @Description Concede ao User as ações (GET, POST, PUT, PATCH, DELETE separados por |) sobre o endpoint. Vale apenas no tenant da requisição.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "User ID"
+// @Param policy body models.PolicyInput true "Endpoint e ações"
+// @Success 201 {object} models.PolicyUser "Política criada"
+// @Failure 400 {object} models.HTTPError "Erro de Formato de Solicitação, ações ou condição inválidas"
+// @Failure 403 {object} models.HTTPError "Ações que o usuário não possui"
+// @Failure 404 {object} models.HTTPError "User ou endpoint não encontrado"
+// @Failure 409 {object} models.HTTPError "Política já existe"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/users/{id}/policies [post]
+func (h *UserAccessHandler) CreatePolicy(c *gin.Context) {
+	userID, ok := h.getUserID(c)
+	if !ok {
+		return
+	}
+
+	var input models.PolicyInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	policy, err := h.securityService.CreateUserPolicy(c, userID, input)
+	if err != nil {
+		handleSecurityError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, policy)
+}
+
+// UpdatePolicy altera as ações de uma política do usuário.
+// @Summary Altera uma política do User
+// @Description Substitui as ações permitidas ao User sobre o endpoint
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "User ID"
+// @Param endpoint_id path int true "Endpoint ID"
+// @Param policy body models.PolicyActionsInput true "Ações"
+// @Success 200 {object} models.PolicyUser "Política atualizada"
+// @Failure 400 {object} models.HTTPError "Erro de Formato de Solicitação, ações ou condição inválidas"
+// @Failure 403 {object} models.HTTPError "Ações que o usuário não possui"
+// @Failure 404 {object} models.HTTPError "User ou política não encontrada"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/users/{id}/policies/{endpoint_id} [put]
+func (h *UserAccessHandler) UpdatePolicy(c *gin.Context) {
+	endpointID, ok := parseUintParam(c, "endpoint_id")
+	if !ok {
+		return
+	}
+	userID, ok := h.getUserID(c)
+	if !ok {
+		return
+	}
+
+	var input models.PolicyActionsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	policy, err := h.securityService.UpdateUserPolicy(c, userID, endpointID, input)
+	if err != nil {
+		handleSecurityError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, policy)
+}
+
+// DeletePolicy remove uma política do usuário.
+// @Summary Remove uma política do User
+// @Description Retira do User o acesso especial ao endpoint
+// @Tags Users
+// @Produce json
+// @Security Bearer
+// @Param id path string true "User ID"
+// @Param endpoint_id path int true "Endpoint ID"
+// @Success 200 {object} map[string]string "Política removida"
+// @Failure 400 {object} models.HTTPError "ID Inválido"
+// @Failure 404 {object} models.HTTPError "User ou política não encontrada"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/users/{id}/policies/{endpoint_id} [delete]
+func (h *UserAccessHandler) DeletePolicy(c *gin.Context) {
+	endpointID, ok := parseUintParam(c, "endpoint_id")
+	if !ok {
+		return
+	}
+	userID, ok := h.getUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.securityService.DeleteUserPolicy(c, userID, endpointID); err != nil {
+		handleSecurityError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Policy deleted successfully"})
+}
+
+// getUserID lê o ID do usuário da URL e confirma que ele pertence ao tenant do contexto.
+func (h *UserAccessHandler) getUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid UUID format"})
+		return uuid.Nil, false
+	}
+
+	if _, err := h.userService.GetByID(c, userID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return uuid.Nil, false
+	}
+	return userID, true
+}
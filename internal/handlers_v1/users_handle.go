@@ -3,12 +3,14 @@
 package handlers_v1
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 
 	contextkeys "github.com/jeancarlosdanese/go-base-api/internal/domain/context_keys"
 	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/logging"
 	"github.com/jeancarlosdanese/go-base-api/internal/services"
 	"github.com/jeancarlosdanese/go-base-api/internal/utils"
 
@@ -18,50 +20,103 @@ import (
 
 // UsersHandler struct holds the services that are needed.
 type UsersHandler struct {
-	userService services.UserServiceInterface
+	userService       services.UserServiceInterface
+	loginAttempts     services.LoginAttemptServiceInterface
+	userStatusService services.UserStatusServiceInterface
 }
 
-func NewUsersHandler(userService services.UserServiceInterface) *UsersHandler {
-	return &UsersHandler{userService: userService}
+func NewUsersHandler(userService services.UserServiceInterface, loginAttempts services.LoginAttemptServiceInterface, userStatusService services.UserStatusServiceInterface) *UsersHandler {
+	return &UsersHandler{userService: userService, loginAttempts: loginAttempts, userStatusService: userStatusService}
 }
 
-// RegisterRoutes registra as rotas para users.
-func (h *UsersHandler) RegisterRoutes(router *gin.RouterGroup) {
+// RegisterRoutes registra as rotas para users. createMiddlewares são aplicados apenas ao POST de criação
+// (ex.: Idempotency-Key).
+func (h *UsersHandler) RegisterRoutes(router *gin.RouterGroup, createMiddlewares ...gin.HandlerFunc) {
 	router.GET("", h.GetAll)
+	router.GET("/search", h.Search)
+	router.GET("/trash", h.GetTrash)
 	router.GET("/:id", h.GetById)
-	router.POST("", h.Create)
+	router.POST("", append(createMiddlewares, h.Create)...)
 	router.PUT("/:id", h.Update)
 	router.PATCH("/:id", h.UpdatePartial)
 	router.DELETE("/:id", h.Delete)
+	router.POST("/:id/restore", h.Restore)
+	router.POST("/:id/unlock", h.Unlock)
+	router.POST("/:id/disable", h.Disable)
+	router.POST("/:id/enable", h.Enable)
+	router.POST("/:id/verification-email", h.SendVerificationEmail)
 }
 
-// getAllUsers busca todos os Users
-// @Summary Busca todos os Users
-// @Description Busca todos os Users
+// getAllUsers lista os Users
+// @Summary Lista os Users
+// @Description Lista os Users do tenant com filtros, ordenação e paginação. Filtros: campo=valor ou campo[op]=valor, com op in (valores separados por vírgula), like (contém), gte ou lte.
+// @Description Campos: name, username e email (eq, in, like), status (eq, in), created_at e updated_at (gte, lte).
+// @Description Ordenação por name, username, email, status, created_at e updated_at (prefixo - para decrescente). O header Link traz as páginas first, prev, next e last.
 // @Tags Users
 // @Accept  json
 // @Produce  json
-// @Success 200 {array} models.User "Lista de Users"
+// @Param   limit  query   int     false "Itens por página (1 a 200)" default(50)
+// @Param   offset query   int     false "Itens a pular (não use com cursor)"
+// @Param   cursor query   string  false "Cursor next_cursor da página anterior"
+// @Param   sort   query   string  false "Ordenação, ex.: -created_at,name" default(name)
+// @Success 200 {object} models.ListResponse[models.User] "Página de Users"
+// @Failure 400 {object} models.HTTPError "Filtro, ordenação ou paginação inválidos"
 // @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
 // @Router /api/v1/users [get]
 func (h *UsersHandler) GetAll(c *gin.Context) {
-	users, err := h.userService.GetAll(c)
+	query, err := parseListQuery(c, models.UserListSpec)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := h.userService.GetAll(c, query)
+	if err != nil {
+		respondListError(c, err)
+		return
+	}
+	respondList(c, query, page)
+}
+
+// Search busca Users por texto
+// @Summary Busca Users por texto
+// @Description Busca os Users do tenant por nome, username ou e-mail. Busca por prefixo, sem diferenciar acentos e maiúsculas, e tolera erros de digitação; os resultados vêm do mais ao menos relevante.
+// @Tags Users
+// @Accept  json
+// @Produce  json
+// @Param   q     query   string  true  "Termo de busca (2 a 100 caracteres)"
+// @Param   limit query   int     false "Máximo de resultados (1 a 50)" default(20)
+// @Success 200 {object} models.SearchResponse[models.User] "Users encontrados"
+// @Failure 400 {object} models.HTTPError "Termo de busca inválido"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/users/search [get]
+func (h *UsersHandler) Search(c *gin.Context) {
+	input, ok := bindSearchInput(c)
+	if !ok {
+		return
+	}
+
+	users, err := h.userService.Search(c, input.Q, input.Limit)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, users)
+	c.JSON(http.StatusOK, models.SearchResponse[models.User]{Data: users})
 }
 
 // createUser cria um novo User
 // @Summary Cria um novo User
-// @Description Adiciona um novo User ao sistema
+// @Description Adiciona um novo User ao sistema. O User é criado PENDENTE e recebe por e-mail o link de verificação.
 // @Tags Users
 // @Accept json
 // @Produce json
 // @Param user body models.UserCreate true "Informações do User"
+// @Param Idempotency-Key header string false "Chave única da operação; as repetições com a mesma chave recebem a resposta original"
 // @Success 201 {object} models.User "User Criado"
+// @Header  201 {string} ETag "Versão do User"
 // @Failure 400 {object} models.HTTPError "Erro de Formato de Solicitação"
+// @Failure 409 {object} models.HTTPError "Idempotency-Key já usada com outro conteúdo ou ainda em andamento"
+// @Failure 422 {object} models.PasswordPolicyErrorResponse "Senha não atende à política"
 // @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
 // @Router /api/v1/users [post]
 func (h *UsersHandler) Create(c *gin.Context) {
@@ -95,10 +150,19 @@ func (h *UsersHandler) Create(c *gin.Context) {
 
 	user, err := h.userService.CreateUserWithPassword(c, &userCreate)
 	if err != nil {
+		if respondPasswordPolicyError(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	// O usuário já foi criado; o link pode ser reenviado em /users/:id/verification-email
+	if err := h.userStatusService.SendVerificationEmail(c, user); err != nil {
+		logging.ErrorLogger.Printf("Falha ao enviar a verificação de e-mail do usuário %s: %v", user.ID, err)
+	}
+
+	setETag(c, user.Version)
 	c.JSON(http.StatusCreated, user)
 }
 
@@ -110,6 +174,7 @@ func (h *UsersHandler) Create(c *gin.Context) {
 // @Produce  json
 // @Param   id     path    string     true        "User ID"
 // @Success 200 {object} models.User "User"
+// @Header  200 {string} ETag "Versão do User, para o If-Match de PUT, PATCH e DELETE"
 // @Failure 404 {object} models.HTTPError "User not found"
 // @Failure 400 {object} models.HTTPError "Invalid UUID format"
 // @Router /api/v1/users/{id} [get]
@@ -125,19 +190,23 @@ func (h *UsersHandler) GetById(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
 		return
 	}
+	setETag(c, user.Version)
 	c.JSON(http.StatusOK, user)
 }
 
 // updateUser atualiza um user existente usando PUT.
 // @Summary Atualiza um User existente
-// @Description Atualiza um User existente com base no ID fornecido
+// @Description Atualiza um User existente com base no ID fornecido. Com If-Match (ETag do GET), a atualização só é feita se o User não tiver sido alterado desde então.
 // @Tags Users
 // @Accept  json
 // @Produce  json
 // @Param   id     path    string     true        "User ID"
+// @Param   If-Match header string    false       "ETag da versão lida"
 // @Param   user body    models.User true "Dados do User"
 // @Success 200 {object} models.User "User Atualizado"
+// @Header  200 {string} ETag "Nova versão do User"
 // @Failure 400 {object} models.HTTPError "ID Inválido ou Erro de Formato de Solicitação"
+// @Failure 412 {object} models.HTTPError "O User foi alterado depois da versão do If-Match"
 // @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
 // @Router /api/v1/users/{id} [put]
 func (h *UsersHandler) Update(c *gin.Context) {
@@ -166,27 +235,49 @@ func (h *UsersHandler) Update(c *gin.Context) {
 	// Opcional: Definir o ID do user com o valor extraído da URL, garantindo que o recurso correto seja atualizado.
 	user.ID = id
 	user.TenantID = tenantUUID
+	// Status e verificação de e-mail só mudam pelos endpoints próprios: com os campos vazios, o UPDATE os ignora.
+	// A troca de e-mail desfaz a verificação no banco (trigger users_reset_email_verification).
+	user.Status = ""
+	user.EmailVerifiedAt = nil
 	fmt.Printf("UserID: %v", user)
 
+	if !bindIfMatch(c) {
+		return
+	}
+
+	current, err := h.userService.GetByID(c, id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
 	userUpdated, err := h.userService.Update(c, id, &user)
 	if err != nil {
+		if respondVersionMismatch(c, err) {
+			return
+		}
 		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
 		return
 	}
+	h.cancelEmailVerification(current, userUpdated)
 
+	setETag(c, userUpdated.Version)
 	c.JSON(http.StatusOK, userUpdated)
 }
 
 // updateUserPatch atualiza parcialmente um user existente usando PATCH.
 // @Summary Atualiza parcialmente um User existente
-// @Description Atualiza parcialmente um User existente com base no ID fornecido
+// @Description Atualiza parcialmente um User existente com base no ID fornecido. Com If-Match (ETag do GET), a atualização só é feita se o User não tiver sido alterado desde então.
 // @Tags Users
 // @Accept  json
 // @Produce  json
 // @Param   id     path    string     true        "User ID"
+// @Param   If-Match header string    false       "ETag da versão lida"
 // @Param   user body    models.User true "Dados atualizáveis do User"
 // @Success 200 {object} gin.H "Mensagem de sucesso"
+// @Header  200 {string} ETag "Nova versão do User"
 // @Failure 400 {object} models.HTTPError "ID Inválido ou Erro de Formato de Solicitação"
+// @Failure 412 {object} models.HTTPError "O User foi alterado depois da versão do If-Match"
 // @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
 // @Router /api/v1/users/{id} [patch]
 func (h *UsersHandler) UpdatePartial(c *gin.Context) {
@@ -204,25 +295,48 @@ func (h *UsersHandler) UpdatePartial(c *gin.Context) {
 
 	// Remover campos que não devem ser atualizáveis
 	// delete(updateData, "cpf_cnpj")
+	delete(updateData, "status")
+	delete(updateData, "email_verified_at")
+
+	if !bindIfMatch(c) {
+		return
+	}
+
+	var current *models.User
+	if _, ok := updateData["email"]; ok {
+		if current, err = h.userService.GetByID(c, id); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+	}
 
 	userPatched, err := h.userService.UpdatePartial(c, id, updateData)
 	if err != nil {
+		if respondVersionMismatch(c, err) {
+			return
+		}
 		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
 		return
 	}
+	h.cancelEmailVerification(current, userPatched)
 
+	setETag(c, userPatched.Version)
 	c.JSON(http.StatusOK, userPatched)
 }
 
 // deleteUser exclui um user.
 // @Summary Exclui um User
-// @Description Exclui um User com base no ID fornecido
+// @Description Exclui um User com base no ID fornecido. Por padrão, o User vai para a lixeira e pode ser restaurado; com hard=true (política própria, em /api/v1/users/:id/purge), é removido definitivamente com os seus roles e políticas.
 // @Tags Users
 // @Accept  json
 // @Produce  json
 // @Param   id     path    string     true        "User ID"
+// @Param   hard   query   bool       false       "Remove definitivamente"
+// @Param   If-Match header string    false       "ETag da versão lida"
 // @Success 200 {object} gin.H "Mensagem de sucesso"
 // @Failure 400 {object} models.HTTPError "ID Inválido"
+// @Failure 404 {object} models.HTTPError "User not found"
+// @Failure 412 {object} models.HTTPError "O User foi alterado depois da versão do If-Match"
 // @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
 // @Router /api/v1/users/{id} [delete]
 func (h *UsersHandler) Delete(c *gin.Context) {
@@ -231,10 +345,250 @@ func (h *UsersHandler) Delete(c *gin.Context) {
 		log.Fatalf("Invalid UUID: %v", err)
 	}
 
+	if !bindIfMatch(c) {
+		return
+	}
+
+	if c.Query("hard") == "true" {
+		h.purge(c, id)
+		return
+	}
+
 	if err := h.userService.Delete(c, id); err != nil {
+		if respondVersionMismatch(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "User deleted successfully"})
 }
+
+func (h *UsersHandler) purge(c *gin.Context, id uuid.UUID) {
+	if err := h.userService.Purge(c, id); err != nil {
+		if respondVersionMismatch(c, err) {
+			return
+		}
+		if errors.Is(err, services.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User permanently deleted"})
+}
+
+// GetTrash lista os Users removidos
+// @Summary Lista os Users na lixeira
+// @Description Lista os Users removidos (exclusão lógica) do tenant, com os mesmos filtros de GET /api/v1/users, mais deleted_at (gte, lte). Por padrão, os removidos mais recentemente primeiro.
+// @Tags Users
+// @Accept  json
+// @Produce  json
+// @Param   limit  query   int     false "Itens por página (1 a 200)" default(50)
+// @Param   offset query   int     false "Itens a pular (não use com cursor)"
+// @Param   cursor query   string  false "Cursor next_cursor da página anterior"
+// @Param   sort   query   string  false "Ordenação, ex.: -deleted_at" default(-deleted_at)
+// @Success 200 {object} models.ListResponse[models.User] "Página de Users removidos"
+// @Failure 400 {object} models.HTTPError "Filtro, ordenação ou paginação inválidos"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/users/trash [get]
+func (h *UsersHandler) GetTrash(c *gin.Context) {
+	query, err := parseListQuery(c, models.UserListSpec.Trash())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := h.userService.GetTrash(c, query)
+	if err != nil {
+		respondListError(c, err)
+		return
+	}
+	respondList(c, query, page)
+}
+
+// Restore restaura um User removido
+// @Summary Restaura um User da lixeira
+// @Description Restaura um User removido do tenant, com os seus roles e políticas.
+// @Tags Users
+// @Accept  json
+// @Produce  json
+// @Param   id     path    string     true        "User ID"
+// @Success 200 {object} models.User "User restaurado"
+// @Failure 400 {object} models.HTTPError "ID Inválido"
+// @Failure 404 {object} models.HTTPError "User não encontrado na lixeira"
+// @Failure 409 {object} models.HTTPError "Outro User ativo usa o mesmo e-mail ou username"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/users/{id}/restore [post]
+func (h *UsersHandler) Restore(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid UUID format"})
+		return
+	}
+
+	user, err := h.userService.Restore(c, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "User não encontrado na lixeira"})
+		case errors.Is(err, services.ErrRestoreConflict):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	setETag(c, user.Version)
+	c.JSON(http.StatusOK, user)
+}
+
+// unlockUser desbloqueia o login de um user.
+// @Summary Desbloqueia o login de um User
+// @Description Remove o bloqueio e o atraso causados por falhas de login do User
+// @Tags Users
+// @Produce  json
+// @Param   id     path    string     true        "User ID"
+// @Success 200 {object} gin.H "Mensagem de sucesso"
+// @Failure 400 {object} models.HTTPError "ID Inválido"
+// @Failure 404 {object} models.HTTPError "User not found"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/users/{id}/unlock [post]
+func (h *UsersHandler) Unlock(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid UUID format"})
+		return
+	}
+
+	user, err := h.userService.GetByID(c, id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	// O bloqueio é por origem: limpa apenas as origens do tenant do usuário, sem afetar o mesmo e-mail em outros tenants
+	origins, err := h.userService.GetLoginOrigins(c, user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.loginAttempts.Reset(user.Email, origins...); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User unlocked successfully"})
+}
+
+// disableUser desativa um user.
+// @Summary Desativa um User
+// @Description Desativa o User sem removê-lo e encerra todas as suas sessões. O login passa a ser recusado com o código USER_DISABLED.
+// @Tags Users
+// @Produce  json
+// @Param   id     path    string     true        "User ID"
+// @Success 200 {object} models.User "User desativado"
+// @Failure 400 {object} models.HTTPError "ID Inválido"
+// @Failure 404 {object} models.HTTPError "User not found"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/users/{id}/disable [post]
+func (h *UsersHandler) Disable(c *gin.Context) {
+	user, ok := h.getUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.userStatusService.Disable(c, user); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// enableUser reativa um user.
+// @Summary Reativa um User
+// @Description Reativa um User desativado (ou ativa um User pendente sem a verificação do e-mail)
+// @Tags Users
+// @Produce  json
+// @Param   id     path    string     true        "User ID"
+// @Success 200 {object} models.User "User ativo"
+// @Failure 400 {object} models.HTTPError "ID Inválido"
+// @Failure 404 {object} models.HTTPError "User not found"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/users/{id}/enable [post]
+func (h *UsersHandler) Enable(c *gin.Context) {
+	user, ok := h.getUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.userStatusService.Enable(c, user); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// sendUserVerificationEmail reenvia o link de verificação de e-mail.
+// @Summary Reenvia a verificação de e-mail
+// @Description Gera um novo link de verificação de e-mail para o User e invalida o anterior
+// @Tags Users
+// @Produce  json
+// @Param   id     path    string     true        "User ID"
+// @Success 202 {object} gin.H "Mensagem de sucesso"
+// @Failure 400 {object} models.HTTPError "ID Inválido"
+// @Failure 404 {object} models.HTTPError "User not found"
+// @Failure 409 {object} models.HTTPError "E-mail já verificado"
+// @Failure 500 {object} models.HTTPError "Erro Interno do Servidor"
+// @Router /api/v1/users/{id}/verification-email [post]
+func (h *UsersHandler) SendVerificationEmail(c *gin.Context) {
+	user, ok := h.getUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.userStatusService.SendVerificationEmail(c, user); err != nil {
+		if errors.Is(err, services.ErrEmailAlreadyVerified) {
+			c.JSON(http.StatusConflict, gin.H{"error": "E-mail já verificado"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "Verification email sent"})
+}
+
+// getUser busca o user do parâmetro :id no tenant do contexto, respondendo 400 ou 404 em caso de falha.
+func (h *UsersHandler) getUser(c *gin.Context) (*models.User, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid UUID format"})
+		return nil, false
+	}
+
+	user, err := h.userService.GetByID(c, id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return nil, false
+	}
+	return user, true
+}
+
+// cancelEmailVerification invalida o link de verificação pendente quando a alteração troca o e-mail do usuário:
+// o link foi enviado para o e-mail anterior. A verificação já registrada é desfeita no banco.
+func (h *UsersHandler) cancelEmailVerification(previous, updated *models.User) {
+	if previous == nil || previous.Email == updated.Email {
+		return
+	}
+	if err := h.userStatusService.CancelEmailVerification(updated.ID); err != nil {
+		logging.WarnLogger.Printf("E-mail do usuário %s alterado, mas falha ao invalidar o link de verificação: %v", updated.ID, err)
+	}
+}
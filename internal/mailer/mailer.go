@@ -0,0 +1,93 @@
+// internal/mailer/mailer.go
+
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/logging"
+	"github.com/jeancarlosdanese/go-base-api/internal/settings"
+)
+
+// Message representa um e-mail a ser enviado. Secret é o valor sensível do corpo (ex.: o token de um link),
+// que o LogMailer não escreve no log.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+	Secret  string
+}
+
+// Mailer define o envio de e-mails. Implementações para SMTP ou provedores externos podem ser plugadas
+// sem alterar os serviços que enviam mensagens.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// NewMailer cria o Mailer configurado em settings.MailerConfig.
+func NewMailer(cfg settings.MailerConfig) (Mailer, error) {
+	switch cfg.Driver {
+	case "", "log":
+		return NewLogMailer(cfg.From), nil
+	case "file":
+		return NewFileMailer(cfg.From, cfg.OutputDir)
+	default:
+		return nil, fmt.Errorf("driver de e-mail não suportado: %s", cfg.Driver)
+	}
+}
+
+// LogMailer escreve as mensagens no log da aplicação, sem o Secret. Indicado apenas para desenvolvimento local;
+// para abrir os links recebidos, use o FileMailer.
+type LogMailer struct {
+	From string
+}
+
+func NewLogMailer(from string) *LogMailer {
+	return &LogMailer{From: from}
+}
+
+func (m *LogMailer) Send(_ context.Context, msg Message) error {
+	logging.InfoLogger.Printf("E-mail de %s para %s\nAssunto: %s\n\n%s", m.From, msg.To, msg.Subject, redact(msg.Body, msg.Secret))
+	return nil
+}
+
+// redact troca o segredo, também na forma escapada para URL, por [REDACTED].
+func redact(body, secret string) string {
+	if secret == "" {
+		return body
+	}
+	body = strings.ReplaceAll(body, url.QueryEscape(secret), "[REDACTED]")
+	return strings.ReplaceAll(body, secret, "[REDACTED]")
+}
+
+// FileMailer grava cada mensagem como um arquivo .eml no diretório informado.
+type FileMailer struct {
+	From      string
+	OutputDir string
+}
+
+func NewFileMailer(from, outputDir string) (*FileMailer, error) {
+	if outputDir == "" {
+		return nil, fmt.Errorf("diretório de saída do FileMailer não informado")
+	}
+	if err := os.MkdirAll(outputDir, 0o750); err != nil {
+		return nil, fmt.Errorf("erro ao criar diretório de e-mails %s: %w", outputDir, err)
+	}
+	return &FileMailer{From: from, OutputDir: outputDir}, nil
+}
+
+func (m *FileMailer) Send(_ context.Context, msg Message) error {
+	now := time.Now()
+	content := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nDate: %s\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s\r\n",
+		m.From, msg.To, msg.Subject, now.Format(time.RFC1123Z), msg.Body)
+
+	name := fmt.Sprintf("%s-%s.eml", now.Format("20060102150405"), uuid.NewString())
+	return os.WriteFile(filepath.Join(m.OutputDir, name), []byte(content), 0o640)
+}
@@ -0,0 +1,129 @@
+// internal/repositories/api_keys_repository.go
+
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/logging"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ApiKeyRepository é uma interface que estende a interface Repository para operações específicas das chaves de API.
+// As consultas recebem o tenant explicitamente, pois as chaves são geridas pelas rotas /tenants/:id/api-keys.
+type ApiKeyRepository interface {
+	GormRepositoryInterface[models.ApiKey]
+	FindApiKeysByTenant(c *gin.Context, tenantID uuid.UUID) ([]models.ApiKey, error)
+	FindApiKeyByTenant(c *gin.Context, tenantID, id uuid.UUID) (*models.ApiKey, error)
+	FindActiveApiKeyByHash(keyHash, origin string) (*models.ApiKey, error)
+	UpdateApiKey(c *gin.Context, tenantID, id uuid.UUID, updateData map[string]interface{}) (*models.ApiKey, error)
+	RevokeApiKey(c *gin.Context, tenantID, id uuid.UUID) error
+	RotateApiKey(c *gin.Context, oldKey *models.ApiKey, newKey *models.ApiKey, oldValidUntil time.Time) error
+	TouchApiKeyLastUsed(id uuid.UUID) error
+}
+
+// NewApiKeyRepository cria uma nova instância de um repositório que implementa ApiKeyRepository.
+func NewApiKeyRepository(db *gorm.DB) ApiKeyRepository {
+	return NewGormRepository[models.ApiKey](db).(ApiKeyRepository)
+}
+
+func (r *GormRepository[Entity]) FindApiKeysByTenant(c *gin.Context, tenantID uuid.UUID) ([]models.ApiKey, error) {
+	var keys []models.ApiKey
+	err := r.DB.WithContext(c).
+		Where("tenant_id = ?", tenantID).
+		Order("created_at").
+		Find(&keys).Error
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (r *GormRepository[Entity]) FindApiKeyByTenant(c *gin.Context, tenantID, id uuid.UUID) (*models.ApiKey, error) {
+	var key models.ApiKey
+	err := r.DB.WithContext(c).
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		Take(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// FindActiveApiKeyByHash busca uma chave não revogada e não expirada, de um tenant existente que aceite a origem informada.
+// O Tenant é carregado junto com a chave.
+func (r *GormRepository[Entity]) FindActiveApiKeyByHash(keyHash, origin string) (*models.ApiKey, error) {
+	var key models.ApiKey
+	formattedOrigin := fmt.Sprintf(`["%s"]`, origin)
+	err := r.DB.
+		Joins("Tenant").
+		Where("api_keys.key_hash = ? AND api_keys.revoked_at IS NULL AND (api_keys.expires_at IS NULL OR api_keys.expires_at > now())", keyHash).
+		Where(`"Tenant".deleted_at IS NULL AND "Tenant".allowed_origins @> ?`, formattedOrigin).
+		Take(&key).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logging.InfoLogger.Printf("API Key ou origem não encontrada para origem: %s", origin)
+			return nil, errors.New("tenant ou origem não encontrado")
+		}
+		logging.ErrorLogger.Printf("Erro ao buscar API Key por hash e origem: %v", err)
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+func (r *GormRepository[Entity]) UpdateApiKey(c *gin.Context, tenantID, id uuid.UUID, updateData map[string]interface{}) (*models.ApiKey, error) {
+	var key models.ApiKey
+	updateData["updated_at"] = gorm.Expr("now()")
+	result := r.DB.WithContext(c).
+		Model(&key).
+		Clauses(clause.Returning{}).
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		Updates(updateData)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &key, nil
+}
+
+// RevokeApiKey marca a chave como revogada. O registro é mantido para auditoria.
+func (r *GormRepository[Entity]) RevokeApiKey(c *gin.Context, tenantID, id uuid.UUID) error {
+	result := r.DB.WithContext(c).Model(&models.ApiKey{}).
+		Where("tenant_id = ? AND id = ? AND revoked_at IS NULL", tenantID, id).
+		Updates(map[string]interface{}{"revoked_at": gorm.Expr("now()"), "updated_at": gorm.Expr("now()")})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// RotateApiKey grava a nova chave e, na mesma transação, limita a validade da chave antiga a oldValidUntil.
+func (r *GormRepository[Entity]) RotateApiKey(c *gin.Context, oldKey *models.ApiKey, newKey *models.ApiKey, oldValidUntil time.Time) error {
+	return r.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(newKey).Error; err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{"expires_at": oldValidUntil, "updated_at": gorm.Expr("now()")}
+		if !oldValidUntil.After(time.Now()) {
+			updates = map[string]interface{}{"revoked_at": gorm.Expr("now()"), "updated_at": gorm.Expr("now()")}
+		}
+		return tx.Model(&models.ApiKey{}).Where("id = ?", oldKey.ID).Updates(updates).Error
+	})
+}
+
+func (r *GormRepository[Entity]) TouchApiKeyLastUsed(id uuid.UUID) error {
+	return r.DB.Model(&models.ApiKey{}).Where("id = ?", id).UpdateColumn("last_used_at", gorm.Expr("now()")).Error
+}
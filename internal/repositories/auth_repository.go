@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	contextkeys "github.com/jeancarlosdanese/go-base-api/internal/domain/context_keys"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -51,8 +52,10 @@ func (r *GormAuthRepository[Entity]) Update(c *gin.Context, id uuid.UUID, entity
 		return nil, err // Retorna erro se o tenant não for encontrado
 	}
 
-	// Atualiza apenas os campos que foram realmente passados na requisição
-	if err := tx.Model(&existing).Updates(entity).Error; err != nil {
+	// Atualiza apenas os campos que foram realmente passados na requisição, na versão do If-Match (se houver);
+	// o Returning traz a nova versão
+	query, checked := whereVersion(c, tx.Model(&existing).Clauses(clause.Returning{}))
+	if err := versionError(query.Updates(entity), checked); err != nil {
 		return nil, err
 	}
 
@@ -67,13 +70,12 @@ func (r *GormAuthRepository[Entity]) UpdatePartial(c *gin.Context, id uuid.UUID,
 
 	var entity Entity // Cria uma referência para o tipo Entity
 
-	err := r.DB.WithContext(c).
+	query, checked := whereVersion(c, r.DB.WithContext(c).
 		Model(&entity).
 		Clauses(clause.Returning{}).
 		Where("tenant_id = ?", tenantID).
-		Where("id = ?", id).
-		Updates(updateData).Error
-	if err != nil {
+		Where("id = ?", id))
+	if err := versionError(query.Updates(updateData), checked); err != nil {
 		return nil, err
 	}
 	return &entity, nil
@@ -86,21 +88,18 @@ func (r *GormAuthRepository[Entity]) Delete(c *gin.Context, id uuid.UUID) error
 	}
 
 	entity := new(Entity)
-	return r.DB.WithContext(c).Where("tenant_id = ?", tenantID).Where("id = ?", id).Delete(entity).Error
+	query, checked := whereVersion(c, r.DB.WithContext(c).Where("tenant_id = ?", tenantID).Where("id = ?", id))
+	return versionError(query.Delete(entity), checked)
 }
 
-func (r *GormAuthRepository[Entity]) GetAll(c *gin.Context) ([]Entity, error) {
+// GetAll retorna a página da listagem do tenant do contexto com os filtros, a ordenação e a paginação de query.
+func (r *GormAuthRepository[Entity]) GetAll(c *gin.Context, query models.ListQuery) (*models.Page[Entity], error) {
 	tenantID, exists := c.Get(string(contextkeys.TenantIDKey))
 	if !exists {
 		return nil, fmt.Errorf("tenant não encontado")
 	}
 
-	var entities []Entity
-	err := r.DB.WithContext(c).Where("tenant_id = ?", tenantID).Find(&entities).Error
-	if err != nil {
-		return nil, err
-	}
-	return entities, nil
+	return listEntities[Entity](r.DB.WithContext(c).Where("tenant_id = ?", tenantID), query)
 }
 
 func (r *GormAuthRepository[Entity]) GetByID(c *gin.Context, id uuid.UUID) (*Entity, error) {
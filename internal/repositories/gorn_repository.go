@@ -5,6 +5,7 @@ package repositories
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -15,7 +16,7 @@ type GormRepositoryInterface[Entity any] interface {
 	Update(c *gin.Context, id uuid.UUID, entity *Entity) (*Entity, error)
 	UpdatePartial(c *gin.Context, id uuid.UUID, updateData map[string]interface{}) (*Entity, error)
 	Delete(c *gin.Context, id uuid.UUID) error
-	GetAll(c *gin.Context) ([]Entity, error)
+	GetAll(c *gin.Context, query models.ListQuery) (*models.Page[Entity], error)
 	GetByID(c *gin.Context, id uuid.UUID) (*Entity, error)
 }
 
@@ -47,8 +48,10 @@ func (r *GormRepository[Entity]) Update(c *gin.Context, id uuid.UUID, entity *En
 		return nil, err // Retorna erro se o tenant não for encontrado
 	}
 
-	// Atualiza apenas os campos que foram realmente passados na requisição
-	if err := tx.Model(&existing).Updates(entity).Error; err != nil {
+	// Atualiza apenas os campos que foram realmente passados na requisição, na versão do If-Match (se houver);
+	// o Returning traz a nova versão
+	query, checked := whereVersion(c, tx.Model(&existing).Clauses(clause.Returning{}))
+	if err := versionError(query.Updates(entity), checked); err != nil {
 		return nil, err
 	}
 
@@ -58,12 +61,11 @@ func (r *GormRepository[Entity]) Update(c *gin.Context, id uuid.UUID, entity *En
 func (r *GormRepository[Entity]) UpdatePartial(c *gin.Context, id uuid.UUID, updateData map[string]interface{}) (*Entity, error) {
 	var entity Entity // Cria uma referência para o tipo Entity
 
-	err := r.DB.WithContext(c).
+	query, checked := whereVersion(c, r.DB.WithContext(c).
 		Model(&entity).
 		Clauses(clause.Returning{}).
-		Where("id = ?", id).
-		Updates(updateData).Error
-	if err != nil {
+		Where("id = ?", id))
+	if err := versionError(query.Updates(updateData), checked); err != nil {
 		return nil, err
 	}
 	return &entity, nil
@@ -71,16 +73,13 @@ func (r *GormRepository[Entity]) UpdatePartial(c *gin.Context, id uuid.UUID, upd
 
 func (r *GormRepository[Entity]) Delete(c *gin.Context, id uuid.UUID) error {
 	entity := new(Entity) // Cria uma referência para o tipo Entity
-	return r.DB.WithContext(c).Where("id = ?", id).Delete(entity).Error
+	query, checked := whereVersion(c, r.DB.WithContext(c).Where("id = ?", id))
+	return versionError(query.Delete(entity), checked)
 }
 
-func (r *GormRepository[Entity]) GetAll(c *gin.Context) ([]Entity, error) {
-	var entities []Entity
-	err := r.DB.WithContext(c).Find(&entities).Error
-	if err != nil {
-		return nil, err
-	}
-	return entities, nil
+// GetAll retorna a página da listagem com os filtros, a ordenação e a paginação de query.
+func (r *GormRepository[Entity]) GetAll(c *gin.Context, query models.ListQuery) (*models.Page[Entity], error) {
+	return listEntities[Entity](r.DB.WithContext(c), query)
 }
 
 func (r *GormRepository[Entity]) GetByID(c *gin.Context, id uuid.UUID) (*Entity, error) {
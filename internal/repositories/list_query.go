@@ -0,0 +1,128 @@
+// internal/repositories/list_query.go
+
+package repositories
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// listEntities executa a ListQuery sobre db (já restrito ao tenant, quando for o caso). As colunas vêm do
+// ListSpec da entidade, nunca da requisição, por isso podem ser usadas diretamente no SQL.
+func listEntities[Entity any](db *gorm.DB, query models.ListQuery) (*models.Page[Entity], error) {
+	// O id desempata a ordenação, para que a paginação seja estável
+	sort := append(append([]models.ListSort{}, query.Sort...), models.ListSort{Column: "id"})
+	if len(query.Cursor) > 0 && len(query.Cursor) != len(sort) {
+		return nil, models.ErrInvalidCursor
+	}
+
+	filtered := applyListFilters(db.Model(new(Entity)), query.Filters)
+
+	var total int64
+	if err := filtered.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	pageQuery := filtered.Session(&gorm.Session{})
+	if len(query.Cursor) > 0 {
+		condition, args := keysetCondition(sort, query.Cursor)
+		pageQuery = pageQuery.Where(condition, args...)
+	} else if query.Offset > 0 {
+		pageQuery = pageQuery.Offset(query.Offset)
+	}
+	for _, s := range sort {
+		pageQuery = pageQuery.Order(clause.OrderByColumn{Column: clause.Column{Name: s.Column}, Desc: s.Desc})
+	}
+
+	// Um item a mais indica se há próxima página
+	var entities []Entity
+	if err := pageQuery.Limit(query.Limit + 1).Find(&entities).Error; err != nil {
+		return nil, err
+	}
+
+	page := &models.Page[Entity]{Items: entities, Total: total}
+	if len(entities) > query.Limit {
+		page.Items = entities[:query.Limit]
+		cursor, err := cursorValues(db, &page.Items[query.Limit-1], sort)
+		if err != nil {
+			return nil, err
+		}
+		page.NextCursor = cursor
+	}
+	return page, nil
+}
+
+func applyListFilters(db *gorm.DB, filters []models.ListFilter) *gorm.DB {
+	for _, filter := range filters {
+		switch filter.Op {
+		case models.FilterEq:
+			db = db.Where(filter.Column+" = ?", filter.Values[0])
+		case models.FilterIn:
+			db = db.Where(filter.Column+" IN ?", filter.Values)
+		case models.FilterLike:
+			db = db.Where(filter.Column+" ILIKE ?", "%"+escapeLike(filter.Values[0])+"%")
+		case models.FilterGte:
+			db = db.Where(filter.Column+" >= ?", filter.Values[0])
+		case models.FilterLte:
+			db = db.Where(filter.Column+" <= ?", filter.Values[0])
+		}
+	}
+	return db
+}
+
+// escapeLike faz com que %, _ e \ do texto buscado sejam comparados literalmente.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+// keysetCondition monta a condição "depois do cursor" para uma ordenação com direções mistas:
+// (a > va) OR (a = va AND b < vb) OR (a = va AND b = vb AND id > vid).
+func keysetCondition(sort []models.ListSort, cursor []string) (string, []interface{}) {
+	var clauses []string
+	var args []interface{}
+	for i, s := range sort {
+		parts := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			parts = append(parts, sort[j].Column+" = ?")
+			args = append(args, cursor[j])
+		}
+		operator := " > ?"
+		if s.Desc {
+			operator = " < ?"
+		}
+		parts = append(parts, s.Column+operator)
+		args = append(args, cursor[i])
+		clauses = append(clauses, "("+strings.Join(parts, " AND ")+")")
+	}
+	return "(" + strings.Join(clauses, " OR ") + ")", args
+}
+
+// cursorValues lê do item os valores das colunas da ordenação, no formato aceito pelo Postgres.
+func cursorValues[Entity any](db *gorm.DB, entity *Entity, sort []models.ListSort) ([]string, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(entity); err != nil {
+		return nil, err
+	}
+
+	values := make([]string, 0, len(sort))
+	for _, s := range sort {
+		field := stmt.Schema.LookUpField(s.Column)
+		if field == nil {
+			return nil, fmt.Errorf("coluna %s não encontrada em %s", s.Column, stmt.Schema.Name)
+		}
+		value, _ := field.ValueOf(db.Statement.Context, reflect.ValueOf(entity).Elem())
+		switch v := value.(type) {
+		case time.Time:
+			values = append(values, v.Format(time.RFC3339Nano))
+		default:
+			values = append(values, fmt.Sprint(v))
+		}
+	}
+	return values, nil
+}
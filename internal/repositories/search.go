@@ -0,0 +1,60 @@
+// internal/repositories/search.go
+
+package repositories
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Documentos (CPF/CNPJ) digitados com pontuação, ex.: 12.345.678/0001-90
+var documentTerm = regexp.MustCompile(`^[0-9][0-9./-]*$`)
+
+// searchTSQuery monta uma tsquery de prefixos (joão silva -> joão:* & silva:*) com apenas letras e dígitos,
+// para que o texto digitado não seja interpretado como sintaxe de tsquery. Acentos são removidos no banco.
+func searchTSQuery(term string) string {
+	var lexemes []string
+	for _, word := range strings.Fields(term) {
+		if documentTerm.MatchString(word) {
+			word = strings.Map(func(r rune) rune {
+				if unicode.IsDigit(r) {
+					return r
+				}
+				return -1
+			}, word)
+		}
+		for _, lexeme := range strings.FieldsFunc(word, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			lexemes = append(lexemes, lexeme+":*")
+		}
+	}
+	return strings.Join(lexemes, " & ")
+}
+
+// searchEntities busca nas colunas geradas search_vector (busca textual) e search_text (trigramas, para erros
+// de digitação) e ordena pela soma das duas relevâncias. db já vem restrito ao tenant, quando for o caso.
+func searchEntities[Entity any](db *gorm.DB, term string, limit int) ([]Entity, error) {
+	entities := []Entity{}
+	tsQuery := searchTSQuery(term)
+	if tsQuery == "" {
+		return entities, nil
+	}
+
+	err := db.Model(new(Entity)).
+		Where("(search_vector @@ to_tsquery('simple', public.immutable_unaccent(?)) OR public.immutable_unaccent(lower(?)) <% search_text)", tsQuery, term).
+		Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:  "ts_rank(search_vector, to_tsquery('simple', public.immutable_unaccent(?))) + word_similarity(public.immutable_unaccent(lower(?)), search_text) DESC, id",
+			Vars: []interface{}{tsQuery, term},
+		}}).
+		Limit(limit).
+		Find(&entities).Error
+	if err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
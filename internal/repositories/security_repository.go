@@ -0,0 +1,265 @@
+// internal/repositories/security_repository.go
+
+package repositories
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+// SecurityRepository reúne as tabelas que alimentam a view casbin_rules_view: roles, endpoints,
+// policies_roles, policies_users e users_roles. Endpoints são globais; roles são globais (tenant_id nulo) ou de um tenant.
+// Ambos usam IDs inteiros.
+// Violações de chave única e de chave estrangeira são traduzidas para gorm.ErrDuplicatedKey e gorm.ErrForeignKeyViolated.
+type SecurityRepository interface {
+	FindRoles(c *gin.Context, tenantID uuid.UUID) ([]models.Role, error)
+	FindRoleByID(c *gin.Context, id uint) (*models.Role, error)
+	CreateRole(c *gin.Context, role *models.Role) error
+	UpdateRoleName(c *gin.Context, id uint, name string) (*models.Role, error)
+	DeleteRole(c *gin.Context, id uint) error
+
+	FindEndpoints(c *gin.Context) ([]models.Endpoint, error)
+	FindEndpointByID(c *gin.Context, id uint) (*models.Endpoint, error)
+	CreateEndpoint(c *gin.Context, endpoint *models.Endpoint) error
+	UpdateEndpointName(c *gin.Context, id uint, name string) (*models.Endpoint, error)
+	DeleteEndpoint(c *gin.Context, id uint) error
+
+	FindRolePolicies(c *gin.Context, roleID uint) ([]models.PolicyRole, error)
+	CreateRolePolicy(c *gin.Context, policy *models.PolicyRole) error
+	UpdateRolePolicy(c *gin.Context, roleID, endpointID uint, actions, condition string) error
+	DeleteRolePolicy(c *gin.Context, roleID, endpointID uint) error
+
+	FindTenantUser(c *gin.Context, tenantID, userID uuid.UUID) (*models.User, error)
+	FindUserPolicies(c *gin.Context, userID uuid.UUID) ([]models.PolicyUser, error)
+	CreateUserPolicy(c *gin.Context, policy *models.PolicyUser) error
+	UpdateUserPolicy(c *gin.Context, userID uuid.UUID, endpointID uint, actions, condition string) error
+	DeleteUserPolicy(c *gin.Context, userID uuid.UUID, endpointID uint) error
+
+	FindUserRoles(c *gin.Context, userID uuid.UUID) ([]models.Role, error)
+	AddUserRole(c *gin.Context, userID uuid.UUID, roleID uint) error
+	RemoveUserRole(c *gin.Context, userID uuid.UUID, roleID uint) error
+}
+
+// NewSecurityRepository cria uma nova instância de um repositório que implementa SecurityRepository.
+func NewSecurityRepository(db *gorm.DB) SecurityRepository {
+	return NewGormRepository[models.Role](db).(SecurityRepository)
+}
+
+// FindRoles lista os roles globais e os do tenant informado.
+func (r *GormRepository[Entity]) FindRoles(c *gin.Context, tenantID uuid.UUID) ([]models.Role, error) {
+	var roles []models.Role
+	err := r.DB.WithContext(c).
+		Where("tenant_id IS NULL OR tenant_id = ?", tenantID).
+		Order("tenant_id NULLS FIRST, name").
+		Find(&roles).Error
+	if err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (r *GormRepository[Entity]) FindRoleByID(c *gin.Context, id uint) (*models.Role, error) {
+	var role models.Role
+	if err := r.DB.WithContext(c).Take(&role, id).Error; err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *GormRepository[Entity]) CreateRole(c *gin.Context, role *models.Role) error {
+	return r.translateError(r.DB.WithContext(c).Omit("Policies").Create(role).Error)
+}
+
+func (r *GormRepository[Entity]) UpdateRoleName(c *gin.Context, id uint, name string) (*models.Role, error) {
+	result := r.DB.WithContext(c).Model(&models.Role{}).Where("id = ?", id).Update("name", name)
+	if result.Error != nil {
+		return nil, r.translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &models.Role{ID: id, Name: name}, nil
+}
+
+// DeleteRole remove o role e suas políticas na mesma transação. Um role ainda atribuído a usuários não é removido
+// (gorm.ErrForeignKeyViolated).
+func (r *GormRepository[Entity]) DeleteRole(c *gin.Context, id uint) error {
+	err := r.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("role_id = ?", id).Delete(&models.PolicyRole{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.Role{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	return r.translateError(err)
+}
+
+func (r *GormRepository[Entity]) FindEndpoints(c *gin.Context) ([]models.Endpoint, error) {
+	var endpoints []models.Endpoint
+	if err := r.DB.WithContext(c).Order("name").Find(&endpoints).Error; err != nil {
+		return nil, err
+	}
+	return endpoints, nil
+}
+
+func (r *GormRepository[Entity]) FindEndpointByID(c *gin.Context, id uint) (*models.Endpoint, error) {
+	var endpoint models.Endpoint
+	if err := r.DB.WithContext(c).Take(&endpoint, id).Error; err != nil {
+		return nil, err
+	}
+	return &endpoint, nil
+}
+
+func (r *GormRepository[Entity]) CreateEndpoint(c *gin.Context, endpoint *models.Endpoint) error {
+	return r.translateError(r.DB.WithContext(c).Create(endpoint).Error)
+}
+
+func (r *GormRepository[Entity]) UpdateEndpointName(c *gin.Context, id uint, name string) (*models.Endpoint, error) {
+	result := r.DB.WithContext(c).Model(&models.Endpoint{}).Where("id = ?", id).Update("name", name)
+	if result.Error != nil {
+		return nil, r.translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &models.Endpoint{ID: id, Name: name}, nil
+}
+
+// DeleteEndpoint remove o endpoint. Um endpoint ainda usado em políticas não é removido (gorm.ErrForeignKeyViolated).
+func (r *GormRepository[Entity]) DeleteEndpoint(c *gin.Context, id uint) error {
+	result := r.DB.WithContext(c).Delete(&models.Endpoint{}, id)
+	if result.Error != nil {
+		return r.translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *GormRepository[Entity]) FindRolePolicies(c *gin.Context, roleID uint) ([]models.PolicyRole, error) {
+	var policies []models.PolicyRole
+	err := r.DB.WithContext(c).
+		Preload("Endpoint").
+		Where("role_id = ?", roleID).
+		Order("endpoint_id").
+		Find(&policies).Error
+	if err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+func (r *GormRepository[Entity]) CreateRolePolicy(c *gin.Context, policy *models.PolicyRole) error {
+	return r.translateError(r.DB.WithContext(c).Omit("Role", "Endpoint").Create(policy).Error)
+}
+
+func (r *GormRepository[Entity]) UpdateRolePolicy(c *gin.Context, roleID, endpointID uint, actions, condition string) error {
+	result := r.DB.WithContext(c).Model(&models.PolicyRole{}).
+		Where("role_id = ? AND endpoint_id = ?", roleID, endpointID).
+		Updates(map[string]interface{}{"actions": actions, "condition": nullableCondition(condition)})
+	return rowsAffectedError(result)
+}
+
+func (r *GormRepository[Entity]) DeleteRolePolicy(c *gin.Context, roleID, endpointID uint) error {
+	result := r.DB.WithContext(c).
+		Where("role_id = ? AND endpoint_id = ?", roleID, endpointID).
+		Delete(&models.PolicyRole{})
+	return rowsAffectedError(result)
+}
+
+// FindTenantUser busca o usuário no tenant informado; usuários de outros tenants não são encontrados.
+func (r *GormRepository[Entity]) FindTenantUser(c *gin.Context, tenantID, userID uuid.UUID) (*models.User, error) {
+	var user models.User
+	if err := r.DB.WithContext(c).Where("id = ? AND tenant_id = ?", userID, tenantID).Take(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *GormRepository[Entity]) FindUserPolicies(c *gin.Context, userID uuid.UUID) ([]models.PolicyUser, error) {
+	var policies []models.PolicyUser
+	err := r.DB.WithContext(c).
+		Preload("Endpoint").
+		Where("user_id = ?", userID).
+		Order("endpoint_id").
+		Find(&policies).Error
+	if err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+func (r *GormRepository[Entity]) CreateUserPolicy(c *gin.Context, policy *models.PolicyUser) error {
+	return r.translateError(r.DB.WithContext(c).Omit("User", "Endpoint").Create(policy).Error)
+}
+
+func (r *GormRepository[Entity]) UpdateUserPolicy(c *gin.Context, userID uuid.UUID, endpointID uint, actions, condition string) error {
+	result := r.DB.WithContext(c).Model(&models.PolicyUser{}).
+		Where("user_id = ? AND endpoint_id = ?", userID, endpointID).
+		Updates(map[string]interface{}{"actions": actions, "condition": nullableCondition(condition)})
+	return rowsAffectedError(result)
+}
+
+func (r *GormRepository[Entity]) DeleteUserPolicy(c *gin.Context, userID uuid.UUID, endpointID uint) error {
+	result := r.DB.WithContext(c).
+		Where("user_id = ? AND endpoint_id = ?", userID, endpointID).
+		Delete(&models.PolicyUser{})
+	return rowsAffectedError(result)
+}
+
+func (r *GormRepository[Entity]) FindUserRoles(c *gin.Context, userID uuid.UUID) ([]models.Role, error) {
+	var roles []models.Role
+	err := r.DB.WithContext(c).
+		Joins("JOIN users_roles ON users_roles.role_id = roles.id").
+		Where("users_roles.user_id = ?", userID).
+		Order("roles.name").
+		Find(&roles).Error
+	if err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (r *GormRepository[Entity]) AddUserRole(c *gin.Context, userID uuid.UUID, roleID uint) error {
+	userRole := models.UserRole{UserID: userID, RoleID: roleID}
+	return r.translateError(r.DB.WithContext(c).Omit("User", "Role").Create(&userRole).Error)
+}
+
+func (r *GormRepository[Entity]) RemoveUserRole(c *gin.Context, userID uuid.UUID, roleID uint) error {
+	result := r.DB.WithContext(c).
+		Where("user_id = ? AND role_id = ?", userID, roleID).
+		Delete(&models.UserRole{})
+	return rowsAffectedError(result)
+}
+
+// translateError aplica translateDBError com a conexão do repositório.
+func (r *GormRepository[Entity]) translateError(err error) error {
+	return translateDBError(r.DB, err)
+}
+
+// nullableCondition grava a política sem condição como NULL.
+func nullableCondition(condition string) interface{} {
+	if condition == "" {
+		return nil
+	}
+	return condition
+}
+
+func rowsAffectedError(result *gorm.DB) error {
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+// internal/repositories/soft_delete.go
+
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Lixeira das entidades com exclusão lógica (deleted_at). db já vem restrito ao tenant, quando for o caso.
+
+// listTrash lista os registros removidos logicamente, com a ListQuery da lixeira.
+func listTrash[Entity any](db *gorm.DB, query models.ListQuery) (*models.Page[Entity], error) {
+	return listEntities[Entity](db.Unscoped().Where("deleted_at IS NOT NULL"), query)
+}
+
+// restoreEntity limpa o deleted_at do registro removido. Se um registro ativo já usar os mesmos dados únicos
+// (ex.: e-mail), retorna gorm.ErrDuplicatedKey; se o registro não estiver na lixeira, gorm.ErrRecordNotFound.
+func restoreEntity[Entity any](db *gorm.DB, id uuid.UUID) (*Entity, error) {
+	var entity Entity
+	result := db.Unscoped().
+		Model(&entity).
+		Clauses(clause.Returning{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if err := translateDBError(db, rowsAffectedError(result)); err != nil {
+		return nil, err
+	}
+	return &entity, nil
+}
+
+// translateDBError converte os erros do banco nos erros do GORM (chave duplicada, chave estrangeira),
+// sem ativar TranslateError para toda a conexão.
+func translateDBError(db *gorm.DB, err error) error {
+	if err == nil {
+		return nil
+	}
+	if translator, ok := db.Dialector.(gorm.ErrorTranslator); ok {
+		return translator.Translate(err)
+	}
+	return err
+}
@@ -3,18 +3,21 @@
 package repositories
 
 import (
-	"errors"
-	"fmt"
-
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/enums"
 	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
-	"github.com/jeancarlosdanese/go-base-api/internal/logging"
 	"gorm.io/gorm"
 )
 
 // TenantRepository é uma interface que estende a interface Repository para operações específicas do Tenant.
 type TenantRepository interface {
 	GormRepositoryInterface[models.Tenant]
-	FindByApiKey(apiKey, origin string) (*models.Tenant, error)
+	FindTenantIDsByStatus(status enums.StatusType) ([]uuid.UUID, error)
+	SearchTenants(c *gin.Context, term string, limit int) ([]models.Tenant, error)
+	FindDeletedTenants(c *gin.Context, query models.ListQuery) (*models.Page[models.Tenant], error)
+	RestoreTenant(c *gin.Context, id uuid.UUID) (*models.Tenant, error)
+	PurgeTenant(c *gin.Context, id uuid.UUID) error
 }
 
 // NewTenantRepository cria uma nova instância de um repositório que implementa TenantRepository.
@@ -22,20 +25,38 @@ func NewTenantRepository(db *gorm.DB) TenantRepository {
 	return NewGormRepository[models.Tenant](db).(TenantRepository) // Retornando diretamente a interface
 }
 
-func (r *GormRepository[Entity]) FindByApiKey(apiKey, origin string) (*models.Tenant, error) {
-	var tenant *models.Tenant
-	formattedOrigin := fmt.Sprintf(`["%s"]`, origin)
-	err := r.DB.
-		Where("api_key = ? AND allowed_origins @> ?", apiKey, formattedOrigin).
-		Take(&tenant).Error
+// FindTenantIDsByStatus retorna os IDs dos tenants com o status informado. Usado na inicialização, sem contexto de requisição.
+func (r *GormRepository[Entity]) FindTenantIDsByStatus(status enums.StatusType) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.DB.Model(&models.Tenant{}).Where("status = ?", status).Pluck("id", &ids).Error
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			logging.InfoLogger.Printf("Tenant ou origem não encontrado: %s, %s", apiKey, origin)
-			return nil, errors.New("tenant ou origem não encontrado")
-		}
-		logging.ErrorLogger.Printf("Erro ao buscar Tenant por apiKey e origem: %v", err)
 		return nil, err
 	}
+	return ids, nil
+}
 
-	return tenant, nil
+// SearchTenants busca tenants por nome, CPF/CNPJ, cidade ou e-mail, do mais ao menos relevante.
+func (r *GormRepository[Entity]) SearchTenants(c *gin.Context, term string, limit int) ([]models.Tenant, error) {
+	return searchEntities[models.Tenant](r.DB.WithContext(c), term, limit)
+}
+
+// FindDeletedTenants lista os tenants removidos logicamente (lixeira).
+func (r *GormRepository[Entity]) FindDeletedTenants(c *gin.Context, query models.ListQuery) (*models.Page[models.Tenant], error) {
+	return listTrash[models.Tenant](r.DB.WithContext(c), query)
+}
+
+// RestoreTenant restaura o tenant removido logicamente.
+func (r *GormRepository[Entity]) RestoreTenant(c *gin.Context, id uuid.UUID) (*models.Tenant, error) {
+	return restoreEntity[models.Tenant](r.DB.WithContext(c), id)
+}
+
+// PurgeTenant remove definitivamente o tenant, removido logicamente ou não. As API Keys são removidas em cascata;
+// um tenant que ainda tem usuários ou roles, mesmo na lixeira, não é removido (gorm.ErrForeignKeyViolated).
+func (r *GormRepository[Entity]) PurgeTenant(c *gin.Context, id uuid.UUID) error {
+	query, checked := whereVersion(c, r.DB.WithContext(c).Unscoped().Where("id = ?", id))
+	result := query.Delete(&models.Tenant{})
+	if err := versionError(result, checked); err != nil {
+		return r.translateError(err)
+	}
+	return rowsAffectedError(result)
 }
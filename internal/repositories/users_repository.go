@@ -8,6 +8,9 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	autherrors "github.com/jeancarlosdanese/go-base-api/internal/domain/auth_errors"
+	contextkeys "github.com/jeancarlosdanese/go-base-api/internal/domain/context_keys"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/enums"
 	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
 	"github.com/jeancarlosdanese/go-base-api/internal/logging"
 	"gorm.io/gorm"
@@ -18,6 +21,18 @@ type UserRepository interface {
 	AuthRepositoryInterface[models.User]
 	FindByEmail(c *gin.Context, email, origin string) (*models.User, error)
 	GetOnlyByID(c *gin.Context, id uuid.UUID) (*models.User, error)
+	UpdatePassword(c *gin.Context, id uuid.UUID, hashedPassword string) error
+	FindByIDWithPolicies(c *gin.Context, id uuid.UUID) (*models.User, error)
+	UpdateTOTP(c *gin.Context, id uuid.UUID, secret *string, enabled bool) error
+	ReplaceRecoveryCodes(c *gin.Context, userID uuid.UUID, codeHashes []string) error
+	UseRecoveryCode(c *gin.Context, userID uuid.UUID, codeHash string) (bool, error)
+	FindUserIDsByTenant(c *gin.Context, tenantID uuid.UUID) ([]uuid.UUID, error)
+	UpdateUserStatus(c *gin.Context, id uuid.UUID, status enums.UserStatus) error
+	MarkEmailVerified(c *gin.Context, id uuid.UUID, email string) error
+	SearchUsers(c *gin.Context, term string, limit int) ([]models.User, error)
+	FindDeletedUsers(c *gin.Context, query models.ListQuery) (*models.Page[models.User], error)
+	RestoreUser(c *gin.Context, id uuid.UUID) (*models.User, error)
+	PurgeUser(c *gin.Context, id uuid.UUID) error
 }
 
 // NewUserRepository cria uma nova instância de um repositório que implementa UserRepository.
@@ -31,12 +46,13 @@ func (r *GormAuthRepository[Entity]) FindByEmail(c *gin.Context, email, origin s
 	formattedOrigin := fmt.Sprintf(`["%s"]`, origin)
 	err := r.DB.WithContext(c).
 		Preload("Roles.Policies.Endpoint").
-		Where("email = ? AND EXISTS (SELECT 1 FROM tenants WHERE tenants.id = users.tenant_id AND allowed_origins @> ?)", email, formattedOrigin).
+		Preload("Tenant").
+		Where("email = ? AND EXISTS (SELECT 1 FROM tenants WHERE tenants.id = users.tenant_id AND tenants.deleted_at IS NULL AND allowed_origins @> ?)", email, formattedOrigin).
 		Take(&user).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			logging.InfoLogger.Printf("Usuário ou origem não encontrado para origem: %s", origin)
-			return nil, errors.New("usuário ou origem não encontrado")
+			return nil, autherrors.ErrUserOrOriginNotFound
 		}
 		logging.ErrorLogger.Printf("Erro interno na busca de usuário")
 		return nil, err
@@ -64,3 +80,179 @@ func (r *GormAuthRepository[Entity]) GetOnlyByID(c *gin.Context, id uuid.UUID) (
 	}
 	return &entity, nil
 }
+
+// UpdatePassword grava o novo hash de senha do usuário. Não depende do tenant do contexto, pois é usado
+// também em fluxos não autenticados (redefinição de senha).
+func (r *GormAuthRepository[Entity]) UpdatePassword(c *gin.Context, id uuid.UUID, hashedPassword string) error {
+	result := r.DB.WithContext(c).Model(&models.User{}).Where("id = ?", id).Update("password", hashedPassword)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// FindByIDWithPolicies busca o usuário pelo ID com tenant, roles e políticas especiais, como em FindByEmail.
+// Usado para concluir o login em duas etapas, quando o tenant ainda não está no contexto.
+func (r *GormAuthRepository[Entity]) FindByIDWithPolicies(c *gin.Context, id uuid.UUID) (*models.User, error) {
+	var user models.User
+	err := r.DB.WithContext(c).
+		Preload("Roles.Policies.Endpoint").
+		Preload("Tenant").
+		Where("id = ?", id).
+		Take(&user).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.DB.WithContext(c).
+		Preload("Endpoint").
+		Where("user_id = ?", user.ID).
+		Find(&user.SpecialPolicies).Error; err != nil {
+		logging.ErrorLogger.Printf("Erro ao carregar special policies para o usuário: %v", err)
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// UpdateTOTP grava o segredo TOTP e o estado do 2FA do usuário. Um segredo nil remove a configuração.
+func (r *GormAuthRepository[Entity]) UpdateTOTP(c *gin.Context, id uuid.UUID, secret *string, enabled bool) error {
+	result := r.DB.WithContext(c).Model(&models.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"totp_secret":  secret,
+		"totp_enabled": enabled,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ReplaceRecoveryCodes substitui todos os códigos de recuperação do usuário pelos hashes informados.
+func (r *GormAuthRepository[Entity]) ReplaceRecoveryCodes(c *gin.Context, userID uuid.UUID, codeHashes []string) error {
+	return r.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		if len(codeHashes) == 0 {
+			return nil
+		}
+
+		codes := make([]models.RecoveryCode, 0, len(codeHashes))
+		for _, hash := range codeHashes {
+			codes = append(codes, models.RecoveryCode{UserID: userID, CodeHash: hash})
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+// UseRecoveryCode marca o código como usado. Retorna false se o código não existir ou já tiver sido usado.
+func (r *GormAuthRepository[Entity]) UseRecoveryCode(c *gin.Context, userID uuid.UUID, codeHash string) (bool, error) {
+	result := r.DB.WithContext(c).Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", gorm.Expr("now()"))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// FindUserIDsByTenant retorna os IDs de todos os usuários do tenant, inclusive os removidos, para revogar as suas sessões.
+// Não depende do tenant do contexto, pois é usado pelo master ao suspender um tenant.
+func (r *GormAuthRepository[Entity]) FindUserIDsByTenant(c *gin.Context, tenantID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.DB.WithContext(c).Unscoped().Model(&models.User{}).Where("tenant_id = ?", tenantID).Pluck("id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// UpdateUserStatus grava o status do usuário. Não depende do tenant do contexto; quem chama valida o acesso ao usuário.
+func (r *GormAuthRepository[Entity]) UpdateUserStatus(c *gin.Context, id uuid.UUID, status enums.UserStatus) error {
+	result := r.DB.WithContext(c).Model(&models.User{}).Where("id = ?", id).Update("status", status)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// MarkEmailVerified registra a verificação do e-mail e ativa o usuário PENDENTE. Um usuário DESATIVADO continua desativado.
+// Só vale se email ainda for o e-mail do usuário; caso contrário, retorna gorm.ErrRecordNotFound.
+func (r *GormAuthRepository[Entity]) MarkEmailVerified(c *gin.Context, id uuid.UUID, email string) error {
+	result := r.DB.WithContext(c).Model(&models.User{}).Where("id = ? AND email = ?", id, email).Updates(map[string]interface{}{
+		"email_verified_at": gorm.Expr("COALESCE(email_verified_at, now())"),
+		"status":            gorm.Expr("CASE WHEN status = ? THEN ?::user_status ELSE status END", enums.UserPendente, enums.UserAtivo),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// SearchUsers busca usuários do tenant do contexto por nome, username ou e-mail, do mais ao menos relevante.
+func (r *GormAuthRepository[Entity]) SearchUsers(c *gin.Context, term string, limit int) ([]models.User, error) {
+	tenantID, exists := c.Get(string(contextkeys.TenantIDKey))
+	if !exists {
+		return nil, fmt.Errorf("tenant não encontado")
+	}
+
+	return searchEntities[models.User](r.DB.WithContext(c).Where("tenant_id = ?", tenantID), term, limit)
+}
+
+// FindDeletedUsers lista os usuários removidos logicamente (lixeira) do tenant do contexto.
+func (r *GormAuthRepository[Entity]) FindDeletedUsers(c *gin.Context, query models.ListQuery) (*models.Page[models.User], error) {
+	tenantID, exists := c.Get(string(contextkeys.TenantIDKey))
+	if !exists {
+		return nil, fmt.Errorf("tenant não encontado")
+	}
+
+	return listTrash[models.User](r.DB.WithContext(c).Where("tenant_id = ?", tenantID), query)
+}
+
+// RestoreUser restaura o usuário removido logicamente do tenant do contexto. Se outro usuário ativo do tenant
+// já usar o e-mail ou o username, retorna gorm.ErrDuplicatedKey.
+func (r *GormAuthRepository[Entity]) RestoreUser(c *gin.Context, id uuid.UUID) (*models.User, error) {
+	tenantID, exists := c.Get(string(contextkeys.TenantIDKey))
+	if !exists {
+		return nil, fmt.Errorf("tenant não encontado")
+	}
+
+	return restoreEntity[models.User](r.DB.WithContext(c).Where("tenant_id = ?", tenantID), id)
+}
+
+// PurgeUser remove definitivamente o usuário do tenant do contexto, removido logicamente ou não, com os seus
+// roles e políticas especiais; os códigos de recuperação são removidos em cascata.
+func (r *GormAuthRepository[Entity]) PurgeUser(c *gin.Context, id uuid.UUID) error {
+	tenantID, exists := c.Get(string(contextkeys.TenantIDKey))
+	if !exists {
+		return fmt.Errorf("tenant não encontado")
+	}
+
+	err := r.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		if err := tx.Unscoped().Where("tenant_id = ? AND id = ?", tenantID, id).Take(&user).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", id).Delete(&models.UserRole{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", id).Delete(&models.PolicyUser{}).Error; err != nil {
+			return err
+		}
+		query, checked := whereVersion(c, tx.Unscoped())
+		return versionError(query.Delete(&user), checked)
+	})
+	return translateDBError(r.DB, err)
+}
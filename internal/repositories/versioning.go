@@ -0,0 +1,34 @@
+// internal/repositories/versioning.go
+
+package repositories
+
+import (
+	"github.com/gin-gonic/gin"
+	contextkeys "github.com/jeancarlosdanese/go-base-api/internal/domain/context_keys"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+// Controle de concorrência otimista: a coluna version é incrementada por trigger a cada UPDATE, e o handler
+// coloca no contexto (contextkeys.IfMatchKey) as versões aceitas pelo If-Match da requisição.
+
+// whereVersion restringe a alteração às versões do If-Match, quando a requisição o informar.
+func whereVersion(c *gin.Context, db *gorm.DB) (*gorm.DB, bool) {
+	versions, ok := c.Get(string(contextkeys.IfMatchKey))
+	if !ok {
+		return db, false
+	}
+	return db.Where("version IN ?", versions), true
+}
+
+// versionError converte em models.ErrVersionMismatch a alteração condicionada ao If-Match que não afetou nenhum
+// registro: a versão mudou ou o registro foi removido.
+func versionError(result *gorm.DB, checked bool) error {
+	if result.Error != nil {
+		return result.Error
+	}
+	if checked && result.RowsAffected == 0 {
+		return models.ErrVersionMismatch
+	}
+	return nil
+}
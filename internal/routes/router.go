@@ -3,9 +3,16 @@
 package routes
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
@@ -21,9 +28,11 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/jeancarlosdanese/go-base-api/internal/app"
+	autherrors "github.com/jeancarlosdanese/go-base-api/internal/domain/auth_errors"
 	contextkeys "github.com/jeancarlosdanese/go-base-api/internal/domain/context_keys"
 	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
 	handlers_v1 "github.com/jeancarlosdanese/go-base-api/internal/handlers_v1"
+	"github.com/jeancarlosdanese/go-base-api/internal/logging"
 	"github.com/jeancarlosdanese/go-base-api/internal/services"
 )
 
@@ -59,6 +68,9 @@ func SetupRouter(r *gin.Engine, sc *app.ServicesContainer) {
 	// Metrics endpoint
 	r.GET("/metrics", MetricsHandler)
 
+	// Chaves públicas para verificação dos JWTs por outros serviços
+	handlers_v1.NewJWKSHandler(sc.TokenService).RegisterRoutes(r)
+
 	// Setup da rota do Swagger com redirecionamento automático
 	swaggerHandler := ginSwagger.WrapHandler(swaggerFiles.Handler)
 	r.GET("/swagger/*any", func(c *gin.Context) {
@@ -81,36 +93,79 @@ func SetupRouter(r *gin.Engine, sc *app.ServicesContainer) {
 		authApiKeyHandler.RegisterRoutes(authApiKeyGroup)
 	}
 
-	// Configuração de rotas não autenticadas
+	// Configuração de rotas não autenticadas (logout e logout-all exigem o access token)
 	authGroup := v1.Group("/auth")
 	authGroup.Use(OriginMiddleware())
 	{
-		authHandler := handlers_v1.NewAuthHandler(sc.UserService, sc.TokenService, sc.TokenRedisService)
+		authHandler := handlers_v1.NewAuthHandler(sc.UserService, sc.TokenService, sc.TokenRedisService, sc.TwoFactorService, sc.LoginAttemptService, sc.TenantStatusService)
 		// auth.POST("/login", authHandler.Login) // Registra diretamente a rota POST /login no grupo /auth
-		authHandler.RegisterRoutes(authGroup)
+		authHandler.RegisterRoutes(authGroup, AuthMiddleware(sc.TokenService, sc.TokenRedisService, sc.TenantStatusService))
+
+		passwordHandler := handlers_v1.NewPasswordHandler(sc.PasswordResetService)
+		passwordHandler.RegisterRoutes(authGroup.Group("/password"))
+
+		emailVerificationHandler := handlers_v1.NewEmailVerificationHandler(sc.UserStatusService)
+		emailVerificationHandler.RegisterRoutes(authGroup.Group("/email"))
 	}
 
 	// Middleware de autenticação que é aplicado a todas as rotas que necessitam autenticação
 	secured := v1.Group("/")
-	secured.Use(AuthMiddleware(sc.TokenService, sc.TokenRedisService))
+	secured.Use(AuthMiddleware(sc.TokenService, sc.TokenRedisService, sc.TenantStatusService))
 	{
+		// Conta do próprio usuário autenticado (sem políticas do Casbin)
+		meHandler := handlers_v1.NewMeHandler(sc.UserService, sc.TokenRedisService, sc.CasbinService)
+		meHandler.RegisterRoutes(secured.Group("/me"))
+
+		twoFactorHandler := handlers_v1.NewTwoFactorHandler(sc.TwoFactorService)
+		twoFactorHandler.RegisterRoutes(secured.Group("/me/2fa"))
+
 		// Grupo para gestão de tenants
 		tenantsGroup := secured.Group("/tenants")
 		// tenantsGroup.Use(RoleMiddleware("administration")) // Apenas usuários com role "administration"
 		tenantsGroup.Use(PolicyMiddleware(sc.CasbinService))
 		{
 			tenantsHandler := handlers_v1.NewTenantsHandler(sc.TenantService)
-			tenantsHandler.RegisterRoutes(tenantsGroup)
+			// Idempotency-Key apenas na criação do tenant: as respostas das API Keys trazem a chave em claro
+			tenantsHandler.RegisterRoutes(tenantsGroup, IdempotencyMiddleware(sc.RedisService, "api_key"))
+
+			apiKeysHandler := handlers_v1.NewApiKeysHandler(sc.TenantService, sc.ApiKeyService)
+			apiKeysHandler.RegisterRoutes(tenantsGroup)
 			// tenantsGroup.GET("", tenantsHandler.GetAll)
 			// tenantsGroup.POST("", tenantsHandler.Create)
 		}
 
 		{
-			usersHandler := handlers_v1.NewUsersHandler(sc.UserService)
+			usersHandler := handlers_v1.NewUsersHandler(sc.UserService, sc.LoginAttemptService, sc.UserStatusService)
 			usersGroup := secured.Group("/users")
 			usersGroup.Use(PolicyMiddleware(sc.CasbinService))
 			// Aqui você pode adicionar middlewares específicos para /users se necessário
-			usersHandler.RegisterRoutes(usersGroup)
+			usersHandler.RegisterRoutes(usersGroup, IdempotencyMiddleware(sc.RedisService))
+
+			userAccessHandler := handlers_v1.NewUserAccessHandler(sc.UserService, sc.SecurityService)
+			userAccessHandler.RegisterRoutes(usersGroup)
+		}
+
+		// Administração de roles, endpoints e políticas do Casbin
+		rolesGroup := secured.Group("/roles")
+		rolesGroup.Use(PolicyMiddleware(sc.CasbinService))
+		{
+			rolesHandler := handlers_v1.NewRolesHandler(sc.SecurityService)
+			rolesHandler.RegisterRoutes(rolesGroup)
+		}
+
+		endpointsGroup := secured.Group("/endpoints")
+		endpointsGroup.Use(PolicyMiddleware(sc.CasbinService))
+		{
+			endpointsHandler := handlers_v1.NewEndpointsHandler(sc.SecurityService)
+			endpointsHandler.RegisterRoutes(endpointsGroup)
+		}
+
+		// Diagnóstico das decisões do Casbin (suporte)
+		authzGroup := secured.Group("/authz")
+		authzGroup.Use(PolicyMiddleware(sc.CasbinService))
+		{
+			authzHandler := handlers_v1.NewAuthzHandler(sc.UserService, sc.CasbinService)
+			authzHandler.RegisterRoutes(authzGroup)
 		}
 	}
 }
@@ -170,6 +225,10 @@ func XApiKeyMiddleware(apiKeyRedisService services.ApiKeyRedisServiceInterface)
 
 		// Tenta recuperar as informações do Tenant do Redis
 		tenantRedis, err := apiKeyRedisService.GetTenantRedisFromApiKey(apiKey, origin)
+		if errors.Is(err, autherrors.ErrTenantSuspended) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Tenant suspenso"})
+			return
+		}
 		if err != nil || tenantRedis == nil {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Falha ao recuperar informações do Tenant"})
 			c.Abort()
@@ -185,10 +244,8 @@ func XApiKeyMiddleware(apiKeyRedisService services.ApiKeyRedisServiceInterface)
 }
 
 // AuthMiddleware é um Midleware para verificar o Bearer Token
-func AuthMiddleware(tokenService services.TokenServiceInterface, tokenRedisService services.TokenRedisServiceInterface) gin.HandlerFunc {
+func AuthMiddleware(tokenService services.TokenServiceInterface, tokenRedisService services.TokenRedisServiceInterface, tenantStatusService services.TenantStatusServiceInterface) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Capturar o tempo de início
-		start := time.Now()
 		tokenString := extractToken(c) // Função auxiliar para extrair o token
 		if tokenString == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token não fornecido"})
@@ -196,13 +253,33 @@ func AuthMiddleware(tokenService services.TokenServiceInterface, tokenRedisServi
 			return
 		}
 
-		_, err := tokenService.ValidateToken(tokenString)
+		token, err := tokenService.ValidateToken(tokenString)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token inválido: " + err.Error()})
 			c.Abort()
 			return
 		}
 
+		claims, ok := token.Claims.(*services.AccessTokenClaims)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token inválido"})
+			c.Abort()
+			return
+		}
+
+		// Tenant suspenso: os tokens emitidos antes da suspensão deixam de valer
+		suspended, err := tenantStatusService.IsSuspended(claims.TenantID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao verificar o status do tenant"})
+			c.Abort()
+			return
+		}
+		if suspended {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Tenant suspenso"})
+			c.Abort()
+			return
+		}
+
 		// Tenta recuperar as informações do usuário do Redis
 		userRedis, err := tokenRedisService.GetUserRedisFromToken(tokenString)
 		if err != nil || userRedis == nil {
@@ -211,17 +288,17 @@ func AuthMiddleware(tokenService services.TokenServiceInterface, tokenRedisServi
 			return
 		}
 
+		// O tenant do token deve ser o mesmo da sessão
+		if claims.TenantID != userRedis.TenantID {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token inválido: tenant não corresponde à sessão"})
+			c.Abort()
+			return
+		}
+
 		// Configura o contexto com o usuário para uso posterior
 		c.Set(string(contextkeys.UserDataKey), userRedis)
 		c.Set(string(contextkeys.TenantIDKey), userRedis.TenantID)
 		c.Next() // Prosseguir com a próxima função no pipeline
-		// Capturar o tempo de término
-		end := time.Now()
-
-		// Calcular a diferença
-		duration := end.Sub(start)
-
-		fmt.Printf("O processo demorou %v\n", duration)
 	}
 }
 
@@ -271,12 +348,24 @@ func PolicyMiddleware(casbinService services.CasbinServiceInterface) gin.Handler
 			return
 		}
 
+		tenantID, exists := c.Get(string(contextkeys.TenantIDKey))
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Tenant não identificado"})
+			c.Abort()
+			return
+		}
+
 		userRedis := tokenData.(*models.UserRedis) // Certifique-se de que este cast está correto conforme sua implementação
 		obj := c.Request.URL.Path
 		act := c.Request.Method
+		// A exclusão definitiva (DELETE ?hard=true) tem política própria, no endpoint .../:id/purge
+		if act == http.MethodDelete && c.Query("hard") == "true" {
+			obj = strings.TrimSuffix(obj, "/") + "/purge"
+		}
 
-		// Tenta verificar permissões usando ID do usuário e roles
-		if !checkPermissions(userRedis, casbinService, obj, act) {
+		// O tenant é o domínio: valem as políticas do usuário e dos seus roles no tenant, além dos roles globais.
+		// As condições das políticas comparam os atributos do usuário com os parâmetros da rota (ex.: o próprio :id).
+		if !casbinService.CheckPermission(userRedis, fmt.Sprintf("%v", tenantID), obj, act) {
 			c.JSON(http.StatusForbidden, gin.H{"error": "Acesso negado - permissão insuficiente"})
 			c.Abort()
 			return
@@ -286,26 +375,6 @@ func PolicyMiddleware(casbinService services.CasbinServiceInterface) gin.Handler
 	}
 }
 
-// checkPermissions tries to verify permissions using the user's ID and their roles
-func checkPermissions(userRedis *models.UserRedis, casbinService services.CasbinServiceInterface, obj, act string) bool {
-	userID := fmt.Sprintf("%v", userRedis.ID) // Ensure user ID is converted to string
-
-	// Verify special permissions using the user ID
-	if casbinService.CheckPermission(userID, obj, act) {
-		return true
-	}
-
-	// Check permissions based on the roles
-	for _, role := range userRedis.Roles {
-		if casbinService.CheckPermission(role, obj, act) {
-			return true
-		}
-	}
-
-	// No permission found
-	return false
-}
-
 // SecurityHeadersMiddleware adiciona headers de segurança HTTP a todas as respostas
 func SecurityHeadersMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -638,3 +707,188 @@ func RequestSizeLimitMiddleware(maxSize int64) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+const (
+	// Por quanto tempo a resposta de uma Idempotency-Key é reaproveitada
+	idempotencyTTL = 24 * time.Hour
+	// Reserva da chave enquanto a primeira requisição é processada
+	idempotencyLockTTL      = time.Minute
+	maxIdempotencyKeyLength = 255
+)
+
+// Headers da resposta guardados junto com o corpo, para a repetição ser idêntica à original
+var idempotencyReplayHeaders = []string{"Content-Type", "ETag", "Location"}
+
+// idempotencyRecord é o que fica no Redis para cada Idempotency-Key: a impressão digital da requisição e,
+// depois de processada, a resposta.
+type idempotencyRecord struct {
+	Fingerprint string            `json:"fingerprint"`
+	Status      int               `json:"status,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	Body        []byte            `json:"body,omitempty"`
+}
+
+// idempotencyWriter copia o corpo da resposta para que ela possa ser guardada.
+type idempotencyWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotencyWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *idempotencyWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// IdempotencyMiddleware atende o header Idempotency-Key dos POSTs: a primeira requisição é processada e a sua
+// resposta guardada no Redis; as repetições com a mesma chave e o mesmo conteúdo (método, rota, query e corpo)
+// recebem a resposta guardada, sem processar de novo. A mesma chave com outro conteúdo, ou enquanto a primeira
+// requisição ainda está em andamento, recebe 409. Respostas 5xx não são guardadas, para que possam ser repetidas.
+// secretFields são os campos da resposta que não são guardados (ex.: a API Key em claro da criação do tenant): eles
+// são exibidos uma única vez e não voltam na repetição; o restante do corpo é guardado sem alteração. As chaves valem
+// por usuário e tenant; use depois do AuthMiddleware e do PolicyMiddleware, apenas nas rotas de criação.
+func IdempotencyMiddleware(redisService services.RedisServiceInterface, secretFields ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader("Idempotency-Key")
+		if c.Request.Method != http.MethodPost || key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Idempotency-Key deve ter no máximo %d caracteres", maxIdempotencyKeyLength)})
+			return
+		}
+
+		body, err := c.GetRawData()
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Falha ao ler o corpo da requisição"})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		hash := sha256.New()
+		fmt.Fprintf(hash, "%s %s?%s\n", c.Request.Method, c.Request.URL.Path, c.Request.URL.RawQuery)
+		hash.Write(body)
+		fingerprint := hex.EncodeToString(hash.Sum(nil))
+
+		tenantID, _ := c.Get(string(contextkeys.TenantIDKey))
+		var userID string
+		if userRedis, ok := c.Value(string(contextkeys.UserDataKey)).(*models.UserRedis); ok {
+			userID = userRedis.ID
+		}
+		redisKey := fmt.Sprintf("idempotency:%v:%s:%s", tenantID, userID, key)
+
+		pending, _ := json.Marshal(idempotencyRecord{Fingerprint: fingerprint})
+		acquired, err := redisService.SetNX(redisKey, string(pending), idempotencyLockTTL)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Falha ao verificar a Idempotency-Key"})
+			return
+		}
+		if !acquired {
+			replayIdempotentResponse(c, redisService, redisKey, fingerprint)
+			return
+		}
+
+		writer := &idempotencyWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		status := writer.Status()
+		if status >= http.StatusInternalServerError {
+			// A falha não é definitiva: libera a chave para uma nova tentativa
+			if err := redisService.Delete(redisKey); err != nil {
+				logging.ErrorLogger.Printf("Falha ao liberar a Idempotency-Key %s: %v", redisKey, err)
+			}
+			return
+		}
+
+		record := idempotencyRecord{Fingerprint: fingerprint, Status: status, Headers: map[string]string{}, Body: withoutFields(writer.body.Bytes(), secretFields)}
+		for _, header := range idempotencyReplayHeaders {
+			if value := writer.Header().Get(header); value != "" {
+				record.Headers[header] = value
+			}
+		}
+		stored, _ := json.Marshal(record)
+		if err := redisService.Set(redisKey, string(stored), idempotencyTTL); err != nil {
+			logging.ErrorLogger.Printf("Falha ao guardar a resposta da Idempotency-Key %s: %v", redisKey, err)
+		}
+	}
+}
+
+// withoutFields remove do objeto JSON os campos de topo informados, mantendo a ordem e os bytes dos demais.
+// Corpos que não são objetos JSON, ou que não têm os campos, ficam como estão.
+func withoutFields(body []byte, fields []string) []byte {
+	if len(fields) == 0 {
+		return body
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return body
+	}
+
+	var redacted bytes.Buffer
+	redacted.WriteByte('{')
+	removed := false
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return body
+		}
+		name, _ := token.(string)
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return body
+		}
+		if slices.Contains(fields, name) {
+			removed = true
+			continue
+		}
+		if redacted.Len() > 1 {
+			redacted.WriteByte(',')
+		}
+		encodedName, _ := json.Marshal(name)
+		redacted.Write(encodedName)
+		redacted.WriteByte(':')
+		redacted.Write(value)
+	}
+	if !removed {
+		return body
+	}
+	redacted.WriteByte('}')
+	return redacted.Bytes()
+}
+
+// replayIdempotentResponse responde a repetição de uma Idempotency-Key já usada.
+func replayIdempotentResponse(c *gin.Context, redisService services.RedisServiceInterface, redisKey, fingerprint string) {
+	value, err := redisService.Get(redisKey)
+	if err != nil {
+		// A chave expirou ou foi liberada entre o SetNX e o Get
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "A requisição com esta Idempotency-Key ainda está em andamento; tente novamente"})
+		return
+	}
+
+	var record idempotencyRecord
+	if err := json.Unmarshal([]byte(value), &record); err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Falha ao verificar a Idempotency-Key"})
+		return
+	}
+	if record.Fingerprint != fingerprint {
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "Idempotency-Key já usada em uma requisição com outro conteúdo"})
+		return
+	}
+	if record.Status == 0 {
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "A requisição com esta Idempotency-Key ainda está em andamento; tente novamente"})
+		return
+	}
+
+	for header, headerValue := range record.Headers {
+		c.Header(header, headerValue)
+	}
+	c.Header("Idempotent-Replayed", "true")
+	c.Data(record.Status, record.Headers["Content-Type"], record.Body)
+	c.Abort()
+}
@@ -0,0 +1,237 @@
+// internal/services/api_key_service.go
+
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	autherrors "github.com/jeancarlosdanese/go-base-api/internal/domain/auth_errors"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/enums"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/logging"
+	"github.com/jeancarlosdanese/go-base-api/internal/repositories"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrApiKeyNotFound indica que a chave não existe para o tenant ou já foi revogada.
+	ErrApiKeyNotFound = errors.New("API Key não encontrada")
+	// ErrApiKeyExpiresInPast indica uma data de expiração que já passou.
+	ErrApiKeyExpiresInPast = errors.New("a data de expiração da API Key deve estar no futuro")
+)
+
+// apiKeyPrefixLength é o tamanho do prefixo guardado em claro para identificar a chave.
+const apiKeyPrefixLength = 8
+
+type ApiKeyServiceInterface interface {
+	Create(c *gin.Context, tenantID uuid.UUID, input models.ApiKeyCreate) (*models.ApiKeyCreated, error)
+	List(c *gin.Context, tenantID uuid.UUID) ([]models.ApiKey, error)
+	Get(c *gin.Context, tenantID, id uuid.UUID) (*models.ApiKey, error)
+	Update(c *gin.Context, tenantID, id uuid.UUID, input models.ApiKeyUpdate) (*models.ApiKey, error)
+	Revoke(c *gin.Context, tenantID, id uuid.UUID) error
+	Rotate(c *gin.Context, tenantID, id uuid.UUID, gracePeriod time.Duration) (*models.ApiKeyCreated, error)
+	Authenticate(apiKey, origin string) (*models.ApiKey, error)
+	TouchLastUsed(id uuid.UUID) error
+	InvalidateTenantCache(tenantID uuid.UUID) error
+}
+
+// ApiKeyService gera e valida as chaves de API dos tenants. A chave em claro só existe na resposta de
+// criação ou rotação; no banco ficam o hash SHA-256 e o prefixo. Toda alteração remove as chaves do tenant do cache.
+type ApiKeyService struct {
+	Repo         repositories.ApiKeyRepository
+	RedisService RedisServiceInterface
+}
+
+func NewApiKeyService(repo repositories.ApiKeyRepository, redisService RedisServiceInterface) *ApiKeyService {
+	return &ApiKeyService{
+		Repo:         repo,
+		RedisService: redisService,
+	}
+}
+
+// Create gera uma nova chave para o tenant e a retorna em claro.
+func (s *ApiKeyService) Create(c *gin.Context, tenantID uuid.UUID, input models.ApiKeyCreate) (*models.ApiKeyCreated, error) {
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		return nil, ErrApiKeyExpiresInPast
+	}
+
+	key, plain, err := newApiKey(tenantID, input.Name, input.Scopes, input.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.Repo.Create(c, key)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.InfoLogger.Printf("API Key %s (%s) criada para o tenant %s", created.ID, created.Prefix, tenantID)
+	return &models.ApiKeyCreated{ApiKey: *created, Key: plain}, nil
+}
+
+func (s *ApiKeyService) List(c *gin.Context, tenantID uuid.UUID) ([]models.ApiKey, error) {
+	return s.Repo.FindApiKeysByTenant(c, tenantID)
+}
+
+func (s *ApiKeyService) Get(c *gin.Context, tenantID, id uuid.UUID) (*models.ApiKey, error) {
+	key, err := s.Repo.FindApiKeyByTenant(c, tenantID, id)
+	if err != nil {
+		return nil, apiKeyRepositoryError(err)
+	}
+	return key, nil
+}
+
+// Update altera nome, escopos e expiração da chave. A chave em si não muda.
+func (s *ApiKeyService) Update(c *gin.Context, tenantID, id uuid.UUID, input models.ApiKeyUpdate) (*models.ApiKey, error) {
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		return nil, ErrApiKeyExpiresInPast
+	}
+
+	updateData := map[string]interface{}{}
+	if input.Name != nil {
+		updateData["name"] = *input.Name
+	}
+	if input.Scopes != nil {
+		updateData["scopes"] = datatypes.NewJSONSlice(normalizeScopes(*input.Scopes))
+	}
+	if input.ExpiresAt != nil {
+		updateData["expires_at"] = *input.ExpiresAt
+	}
+	if len(updateData) == 0 {
+		return s.Get(c, tenantID, id)
+	}
+
+	key, err := s.Repo.UpdateApiKey(c, tenantID, id, updateData)
+	if err != nil {
+		return nil, apiKeyRepositoryError(err)
+	}
+	if err := s.invalidateCache(tenantID); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// Revoke revoga a chave imediatamente.
+func (s *ApiKeyService) Revoke(c *gin.Context, tenantID, id uuid.UUID) error {
+	key, err := s.Repo.FindApiKeyByTenant(c, tenantID, id)
+	if err != nil {
+		return apiKeyRepositoryError(err)
+	}
+
+	if err := s.Repo.RevokeApiKey(c, tenantID, id); err != nil {
+		return apiKeyRepositoryError(err)
+	}
+	if err := s.invalidateCache(tenantID); err != nil {
+		return err
+	}
+
+	logging.InfoLogger.Printf("API Key %s (%s) do tenant %s revogada", key.ID, key.Prefix, tenantID)
+	return nil
+}
+
+// Rotate gera uma nova chave com o mesmo nome, escopos e expiração da atual. A chave antiga continua válida
+// por gracePeriod, para que os clientes troquem a chave sem interrupção; com zero, é revogada na hora.
+func (s *ApiKeyService) Rotate(c *gin.Context, tenantID, id uuid.UUID, gracePeriod time.Duration) (*models.ApiKeyCreated, error) {
+	oldKey, err := s.Repo.FindApiKeyByTenant(c, tenantID, id)
+	if err != nil {
+		return nil, apiKeyRepositoryError(err)
+	}
+	if !oldKey.IsActive(time.Now()) {
+		return nil, ErrApiKeyNotFound
+	}
+
+	newKey, plain, err := newApiKey(tenantID, oldKey.Name, oldKey.Scopes, oldKey.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	oldValidUntil := time.Now().Add(gracePeriod)
+	if oldKey.ExpiresAt != nil && oldKey.ExpiresAt.Before(oldValidUntil) {
+		oldValidUntil = *oldKey.ExpiresAt
+	}
+	if err := s.Repo.RotateApiKey(c, oldKey, newKey, oldValidUntil); err != nil {
+		return nil, err
+	}
+	if err := s.invalidateCache(tenantID); err != nil {
+		return nil, err
+	}
+
+	logging.InfoLogger.Printf("API Key %s (%s) do tenant %s rotacionada para %s (%s)", oldKey.ID, oldKey.Prefix, tenantID, newKey.ID, newKey.Prefix)
+	return &models.ApiKeyCreated{ApiKey: *newKey, Key: plain}, nil
+}
+
+// Authenticate busca a chave ativa pelo hash da chave informada, validando a origem e o status do tenant.
+// A chave retornada traz o Tenant carregado.
+func (s *ApiKeyService) Authenticate(apiKey, origin string) (*models.ApiKey, error) {
+	key, err := s.Repo.FindActiveApiKeyByHash(hashOpaqueToken(apiKey), origin)
+	if err != nil {
+		logging.InfoLogger.Printf("Erro ao buscar Tenant por apiKey: %v", err)
+		return nil, err
+	}
+	if key.Tenant != nil && key.Tenant.Status == enums.Inativo {
+		logging.WarnLogger.Printf("API Key %s (%s) recusada: tenant %s com status %s", key.ID, key.Prefix, key.TenantID, key.Tenant.Status)
+		return nil, autherrors.ErrTenantSuspended
+	}
+
+	if err := s.TouchLastUsed(key.ID); err != nil {
+		logging.WarnLogger.Printf("Falha ao registrar uso da API Key %s: %v", key.ID, err)
+	}
+
+	return key, nil
+}
+
+func (s *ApiKeyService) TouchLastUsed(id uuid.UUID) error {
+	return s.Repo.TouchApiKeyLastUsed(id)
+}
+
+// InvalidateTenantCache remove do cache as chaves do tenant, para que a próxima requisição valide no banco.
+// Usado quando o tenant ou uma de suas chaves é alterado.
+func (s *ApiKeyService) InvalidateTenantCache(tenantID uuid.UUID) error {
+	return invalidateTenantApiKeyCache(s.RedisService, tenantID.String())
+}
+
+// invalidateCache remove do cache as API Keys do tenant após uma alteração. A alteração já está gravada; o erro indica
+// que o cache ainda pode aceitar a chave como estava, até expirar.
+func (s *ApiKeyService) invalidateCache(tenantID uuid.UUID) error {
+	if err := s.InvalidateTenantCache(tenantID); err != nil {
+		logging.ErrorLogger.Printf("API Key alterada, mas falha ao remover as API Keys do tenant %s do cache: %v", tenantID, err)
+		return err
+	}
+	return nil
+}
+
+// newApiKey gera uma chave aleatória de 256 bits e o registro correspondente, sem a chave em claro.
+func newApiKey(tenantID uuid.UUID, name string, scopes []string, expiresAt *time.Time) (*models.ApiKey, string, error) {
+	plain, err := generateOpaqueToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &models.ApiKey{
+		TenantID:  tenantID,
+		Name:      name,
+		Prefix:    plain[:apiKeyPrefixLength],
+		KeyHash:   hashOpaqueToken(plain),
+		Scopes:    datatypes.NewJSONSlice(normalizeScopes(scopes)),
+		ExpiresAt: expiresAt,
+	}, plain, nil
+}
+
+func normalizeScopes(scopes []string) []string {
+	if scopes == nil {
+		return []string{}
+	}
+	return scopes
+}
+
+func apiKeyRepositoryError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrApiKeyNotFound
+	}
+	return err
+}
@@ -7,36 +7,61 @@ import (
 	"log"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
 	"github.com/redis/go-redis/v9"
 )
 
+// apiKeyLastUsedInterval limita a gravação de last_used_at a uma por intervalo quando a chave vem do cache.
+const apiKeyLastUsedInterval = time.Minute
+
+// apiKeyCacheGenerationKey guarda o contador de invalidações do cache de API Keys.
+const apiKeyCacheGenerationKey = "apiKey_generation"
+
 type ApiKeyRedisServiceInterface interface {
-	SaveApiKeyDataRedis(tenant *models.Tenant, apiKey string, accessDuration time.Duration) error
+	SaveApiKeyDataRedis(apiKey *models.ApiKey, origin string, accessDuration time.Duration) error
 	GetTenantRedisFromApiKey(apiKey, origin string) (*models.TenantRedis, error)
 }
 
 type ApiKeyRedisService struct {
-	TenantService  TenantServiceInterface
+	ApiKeyService  ApiKeyServiceInterface
 	RedisService   RedisServiceInterface
 	AccessDuration time.Duration
 }
 
-func NewApiKeyRedisService(tenantsService TenantServiceInterface, redisService RedisServiceInterface, accessDuration time.Duration) *ApiKeyRedisService {
+func NewApiKeyRedisService(apiKeyService ApiKeyServiceInterface, redisService RedisServiceInterface, accessDuration time.Duration) *ApiKeyRedisService {
 	return &ApiKeyRedisService{
-		TenantService:  tenantsService,
+		ApiKeyService:  apiKeyService,
 		RedisService:   redisService,
 		AccessDuration: accessDuration,
 	}
 }
 
-func (s *ApiKeyRedisService) SaveApiKeyDataRedis(tenant *models.Tenant, apiKey string, accessDuration time.Duration) error {
-	apiKeyDataRedis := prepareApiKeyDataRedis(tenant)
+// SaveApiKeyDataRedis guarda os dados do tenant da chave no cache, indexados pelo hash da chave e pela origem
+// validada, e registra a entrada no índice do tenant para a invalidação. O cache nunca dura além da expiração da chave.
+func (s *ApiKeyRedisService) SaveApiKeyDataRedis(apiKey *models.ApiKey, origin string, accessDuration time.Duration) error {
+	// O índice é renovado a cada gravação e precisa durar tanto quanto a entrada mais longa do tenant
+	indexDuration := accessDuration
+	if apiKey.ExpiresAt != nil {
+		if untilExpiry := time.Until(*apiKey.ExpiresAt); untilExpiry < accessDuration {
+			accessDuration = untilExpiry
+		}
+	}
+	if accessDuration <= 0 {
+		return nil
+	}
+
+	apiKeyDataRedis := prepareApiKeyDataRedis(apiKey)
 	apiKeyData, err := json.Marshal(apiKeyDataRedis)
 	if err != nil {
 		return err
 	}
-	if err := s.RedisService.Set("apiKey:"+apiKey, apiKeyData, accessDuration); err != nil {
+	cacheKey := apiKeyCacheKey(apiKey.KeyHash, origin)
+	if err := s.RedisService.HSet(apiKeyTenantIndexKey(apiKey.TenantID.String()), cacheKey, 1, indexDuration); err != nil {
+		log.Printf("ERROR: Error indexing API key data in Redis: %v", err)
+		return err
+	}
+	if err := s.RedisService.Set(cacheKey, apiKeyData, accessDuration); err != nil {
 		log.Printf("ERROR: Error saving API key data to Redis: %v", err)
 		return err
 	}
@@ -44,30 +69,33 @@ func (s *ApiKeyRedisService) SaveApiKeyDataRedis(tenant *models.Tenant, apiKey s
 }
 
 func (s *ApiKeyRedisService) GetTenantRedisFromApiKey(apiKey, origin string) (*models.TenantRedis, error) {
-	result, err := s.RedisService.Get("apiKey:" + apiKey)
+	result, err := s.RedisService.Get(apiKeyCacheKey(hashOpaqueToken(apiKey), origin))
 	if err != nil && err != redis.Nil {
 		log.Printf("ERROR: Error retrieving from Redis: %v", err)
 		return nil, err
 	}
 
 	if result == "" {
-		tenant, err := s.TenantService.ApiKeyAuthenticate(apiKey, origin)
+		// A geração é lida antes da consulta ao banco, para detectar uma invalidação concorrente (ver cacheGeneration)
+		generation, err := s.cacheGeneration()
 		if err != nil {
-			log.Printf("ERROR: Error authenticating API Key: %v", err)
 			return nil, err
 		}
 
-		if err := s.SaveApiKeyDataRedis(tenant, apiKey, s.AccessDuration); err != nil {
-			log.Printf("ERROR: Error saving API Key Data to Redis: %v", err)
+		key, err := s.ApiKeyService.Authenticate(apiKey, origin)
+		if err != nil {
+			log.Printf("ERROR: Error authenticating API Key: %v", err)
 			return nil, err
 		}
 
-		// Retrieve again to confirm saving was successful
-		result, err = s.RedisService.Get("apiKey:" + apiKey)
-		if err != nil {
-			log.Printf("ERROR: Error verifying stored key in Redis: %v", err)
+		if err := s.SaveApiKeyDataRedis(key, origin, s.AccessDuration); err != nil {
+			log.Printf("ERROR: Error saving API Key Data to Redis: %v", err)
 			return nil, err
 		}
+		s.discardIfInvalidated(generation, apiKeyCacheKey(key.KeyHash, origin))
+		// Os dados vêm do banco; last_used_at já foi atualizado em Authenticate
+		tenantRedis := prepareApiKeyDataRedis(key)
+		return &tenantRedis, nil
 	}
 
 	var apiKeyDataRedis models.TenantRedis
@@ -76,13 +104,58 @@ func (s *ApiKeyRedisService) GetTenantRedisFromApiKey(apiKey, origin string) (*m
 		return nil, err
 	}
 
+	s.touchLastUsed(apiKeyDataRedis.ApiKeyID)
+
 	return &apiKeyDataRedis, nil
 }
 
-// prepareApiKeyDataRedis prepares user data to be stored in Redis.
-func prepareApiKeyDataRedis(tenant *models.Tenant) models.TenantRedis {
+// cacheGeneration retorna a geração do cache, incrementada a cada invalidação. É um contador global, e não por
+// tenant, porque antes da consulta ao banco o tenant da chave ainda não é conhecido.
+func (s *ApiKeyRedisService) cacheGeneration() (string, error) {
+	generation, err := s.RedisService.Get(apiKeyCacheGenerationKey)
+	if err != nil && err != redis.Nil {
+		log.Printf("ERROR: Error retrieving API key cache generation from Redis: %v", err)
+		return "", err
+	}
+	return generation, nil
+}
+
+// discardIfInvalidated remove a entrada recém-gravada se houve uma invalidação desde a leitura de generation:
+// os dados podem ter sido lidos do banco antes da alteração e gravados depois da limpeza do cache. Uma invalidação
+// posterior a esta verificação já encontra a entrada no índice do tenant e a remove.
+func (s *ApiKeyRedisService) discardIfInvalidated(generation, cacheKey string) {
+	current, err := s.cacheGeneration()
+	if err == nil && current == generation {
+		return
+	}
+	if err := s.RedisService.Delete(cacheKey); err != nil {
+		log.Printf("WARN: Error discarding stale API key data from Redis: %v", err)
+	}
+}
+
+// touchLastUsed atualiza last_used_at da chave no máximo uma vez por apiKeyLastUsedInterval.
+func (s *ApiKeyRedisService) touchLastUsed(apiKeyID string) {
+	id, err := uuid.Parse(apiKeyID)
+	if err != nil {
+		return
+	}
+	first, err := s.RedisService.SetNX("apiKey_used:"+apiKeyID, 1, apiKeyLastUsedInterval)
+	if err != nil || !first {
+		return
+	}
+	if err := s.ApiKeyService.TouchLastUsed(id); err != nil {
+		log.Printf("WARN: Error updating API key last use: %v", err)
+	}
+}
+
+// prepareApiKeyDataRedis prepares tenant data to be stored in Redis.
+func prepareApiKeyDataRedis(apiKey *models.ApiKey) models.TenantRedis {
 	var cpfCnpj, email string
 
+	tenant := apiKey.Tenant
+	if tenant == nil {
+		tenant = &models.Tenant{}
+	}
 	if tenant.CpfCnpj != nil {
 		cpfCnpj = *tenant.CpfCnpj
 	}
@@ -91,9 +164,40 @@ func prepareApiKeyDataRedis(tenant *models.Tenant) models.TenantRedis {
 	}
 
 	return models.TenantRedis{
-		ID:      tenant.ID.String(),
-		Name:    tenant.Name,
-		CpfCnpj: cpfCnpj,
-		Email:   email,
+		ID:       apiKey.TenantID.String(),
+		Name:     tenant.Name,
+		CpfCnpj:  cpfCnpj,
+		Email:    email,
+		ApiKeyID: apiKey.ID.String(),
+		Scopes:   normalizeScopes(apiKey.Scopes),
+	}
+}
+
+// invalidateTenantApiKeyCache remove do cache todas as entradas das chaves do tenant, em todas as origens.
+// A geração é incrementada antes, para que gravações concorrentes com dados anteriores sejam descartadas.
+func invalidateTenantApiKeyCache(redisService RedisServiceInterface, tenantID string) error {
+	if _, err := redisService.Incr(apiKeyCacheGenerationKey, 0); err != nil {
+		return err
+	}
+
+	indexKey := apiKeyTenantIndexKey(tenantID)
+	entries, err := redisService.HGetAll(indexKey)
+	if err != nil && err != redis.Nil {
+		return err
 	}
+
+	keys := make([]string, 0, len(entries)+1)
+	for cacheKey := range entries {
+		keys = append(keys, cacheKey)
+	}
+	keys = append(keys, indexKey)
+	return redisService.Delete(keys...)
+}
+
+func apiKeyCacheKey(keyHash, origin string) string {
+	return "apiKey:" + keyHash + ":" + origin
+}
+
+func apiKeyTenantIndexKey(tenantID string) string {
+	return "apiKey_tenant:" + tenantID
 }
@@ -3,18 +3,41 @@
 package services
 
 import (
+	"errors"
+
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
 	"github.com/jeancarlosdanese/go-base-api/internal/repositories"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrNotFound indica que o registro não existe; na restauração, que ele não está na lixeira.
+	ErrNotFound = errors.New("registro não encontrado")
+	// ErrRestoreConflict indica que um registro ativo já usa os dados únicos (ex.: e-mail, CPF/CNPJ) do registro restaurado.
+	ErrRestoreConflict = errors.New("já existe um registro ativo com os mesmos dados únicos")
 )
 
+// softDeleteError converte os erros da restauração e da remoção definitiva nos erros do serviço.
+func softDeleteError(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return ErrNotFound
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return ErrRestoreConflict
+	default:
+		return err
+	}
+}
+
 // Service define as operações básicas de um serviço com tipo genérico para entidade.
 type BaseServiceInterface[Entity any] interface {
 	Create(c *gin.Context, entity *Entity) (*Entity, error)
 	Update(c *gin.Context, id uuid.UUID, entity *Entity) (*Entity, error)
 	UpdatePartial(c *gin.Context, id uuid.UUID, updateData map[string]interface{}) (*Entity, error)
 	Delete(c *gin.Context, id uuid.UUID) error
-	GetAll(c *gin.Context) ([]Entity, error)
+	GetAll(c *gin.Context, query models.ListQuery) (*models.Page[Entity], error)
 	GetByID(c *gin.Context, id uuid.UUID) (*Entity, error)
 }
 
@@ -43,8 +66,8 @@ func (s *BaseService[Entity, Repo]) Delete(c *gin.Context, id uuid.UUID) error {
 	return s.Repo.Delete(c, id)
 }
 
-func (s *BaseService[Entity, Repo]) GetAll(c *gin.Context) ([]Entity, error) {
-	return s.Repo.GetAll(c)
+func (s *BaseService[Entity, Repo]) GetAll(c *gin.Context, query models.ListQuery) (*models.Page[Entity], error) {
+	return s.Repo.GetAll(c, query)
 }
 
 func (s *BaseService[Entity, Repo]) GetByID(c *gin.Context, id uuid.UUID) (*Entity, error) {
@@ -10,38 +10,64 @@
 package services
 
 import (
+	"encoding/json"
+	"fmt"
 	"log"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/casbin/casbin/v2"
 	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
+	"github.com/casbin/casbin/v2/util"
 	gormadapter "github.com/casbin/gorm-adapter/v3"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/logging"
 	"gorm.io/gorm"
 )
 
 type CasbinServiceInterface interface {
-	CheckPermission(sub, obj, act string) bool
+	CheckPermission(user *models.UserRedis, dom, obj, act string) bool
+	Explain(user *models.UserRedis, dom, obj, act string) (*models.AuthzExplanation, error)
+	Permissions(user *models.UserRedis, dom string) (*models.UserPermissions, error)
+	LoadPolicy() error
+	SetPolicy(sub, dom, obj, act, cond string) error
+	RemovePolicy(sub, dom, obj string) error
+	AddRoleForUser(user, role, dom string) error
+	DeleteRoleForUser(user, role, dom string) error
 }
+
+// casbinModel é o modelo Casbin (RBAC com domínios) embutido diretamente no código. O domínio é o ID do tenant:
+// o usuário (sub) tem as políticas próprias e as dos roles do seu tenant (g com o tenant) e dos roles globais
+// (g e p com o domínio "*", ver models.GlobalDomain). Cada política pode ter uma condição (cond, ver policyCondition)
+// sobre os atributos do usuário (usr) e os parâmetros da rota; NoCondition indica política sem condição.
+const casbinModel = `
+	[request_definition]
+	r = sub, dom, obj, act, usr
+	[policy_definition]
+	p = sub, dom, obj, act, cond
+	[role_definition]
+	g = _, _, _
+	[policy_effect]
+	e = some(where (p.eft == allow))
+	[matchers]
+	m = (r.sub == p.sub && r.dom == p.dom || g(r.sub, p.sub, r.dom) && r.dom == p.dom || g(r.sub, p.sub, "*") && p.dom == "*") && keyMatch2(r.obj, p.obj) && regexMatch(r.act, p.act) && conditionMatch(r.obj, p.obj, p.cond, r.usr)
+`
+
+// CasbinService usa um SyncedEnforcer, pois as políticas são recarregadas em tempo de execução, concorrendo com as verificações.
+// Com várias instâncias, as alterações são propagadas pelo watcher e as políticas são recarregadas do banco a cada
+// reloadInterval, cobrindo mensagens perdidas.
 type CasbinService struct {
-	enforcer *casbin.Enforcer
-}
-
-func NewCasbinService(db *gorm.DB) (*CasbinService, error) {
-	// Configuração do modelo Casbin embutida diretamente no código.
-	m, err := model.NewModelFromString(`
-		[request_definition]
-		r = sub, obj, act
-		[policy_definition]
-		p = sub, obj, act
-		[policy_effect]
-		e = some(where (p.eft == allow))
-		[matchers]
-		m = r.sub == p.sub && keyMatch2(r.obj, p.obj) && regexMatch(r.act, p.act)
-	`)
-	if err != nil {
-		log.Printf("Erro ao carregar o modelo Casbin: %v", err)
-		return nil, err
-	}
+	enforcer  *casbin.SyncedEnforcer
+	watcher   CasbinWatcherInterface
+	stop      chan struct{}
+	closeOnce sync.Once
+}
 
+// NewCasbinService carrega as políticas da view casbin_rules_view. watcher pode ser nil (instância única)
+// e reloadInterval igual a zero desativa a recarga periódica.
+func NewCasbinService(db *gorm.DB, watcher CasbinWatcherInterface, reloadInterval time.Duration) (*CasbinService, error) {
 	// Adaptador GORM para Casbin usando a tabela especificada.
 	gormadapter.TurnOffAutoMigrate(db)
 	a, err := gormadapter.NewAdapterByDBUseTableName(db, "casbin", "rules_view")
@@ -50,11 +76,25 @@ func NewCasbinService(db *gorm.DB) (*CasbinService, error) {
 		return nil, err
 	}
 
-	enforcer, err := casbin.NewEnforcer(m, a)
+	return NewCasbinServiceFromAdapter(a, watcher, reloadInterval)
+}
+
+// NewCasbinServiceFromAdapter cria o serviço sobre qualquer adaptador Casbin.
+func NewCasbinServiceFromAdapter(a persist.Adapter, watcher CasbinWatcherInterface, reloadInterval time.Duration) (*CasbinService, error) {
+	m, err := model.NewModelFromString(casbinModel)
+	if err != nil {
+		log.Printf("Erro ao carregar o modelo Casbin: %v", err)
+		return nil, err
+	}
+
+	enforcer, err := casbin.NewSyncedEnforcer(m, a)
 	if err != nil {
 		log.Printf("Erro ao criar o enforcer Casbin: %v", err)
 		return nil, err
 	}
+	// As políticas vêm de uma view: as alterações são gravadas pelo SecurityService e aplicadas aqui só em memória.
+	enforcer.EnableAutoSave(false)
+	enforcer.AddFunction("conditionMatch", conditionMatch)
 
 	err = enforcer.LoadPolicy()
 	if err != nil {
@@ -62,17 +102,283 @@ func NewCasbinService(db *gorm.DB) (*CasbinService, error) {
 		return nil, err
 	}
 
-	return &CasbinService{enforcer: enforcer}, nil
+	cs := &CasbinService{enforcer: enforcer, watcher: watcher, stop: make(chan struct{})}
+	if watcher != nil {
+		if err := watcher.SetUpdateCallback(cs.HandleUpdate); err != nil {
+			return nil, err
+		}
+	}
+	if reloadInterval > 0 {
+		go cs.reloadPeriodically(reloadInterval)
+	}
+	return cs, nil
 }
 
-// CheckPermission logs and verifies permissions using Casbin enforcer
-func (cs *CasbinService) CheckPermission(sub, obj, act string) bool {
-	log.Printf("Checking permission for sub: %s, obj: %s, act: %s", sub, obj, act)
-	ok, err := cs.enforcer.Enforce(sub, obj, act)
+// CheckPermission verifica se o usuário pode executar act em obj no domínio dom. Roda a cada requisição e por isso
+// só registra falhas do enforcer; para entender uma negação, use Explain.
+func (cs *CasbinService) CheckPermission(user *models.UserRedis, dom, obj, act string) bool {
+	ok, err := cs.enforcer.Enforce(user.ID, dom, obj, act, subjectAttributesOf(user))
 	if err != nil {
-		log.Printf("Error while checking permission: %v", err)
+		logging.ErrorLogger.Printf("Erro ao verificar a permissão de %s em %s %s (domínio %s): %v", user.ID, act, obj, dom, err)
 		return false
 	}
-	log.Printf("Permission result for %s, %s, %s: %t", sub, obj, act, ok)
 	return ok
 }
+
+// Explain faz a mesma verificação de CheckPermission e informa a política que concedeu o acesso ou, se negado,
+// as políticas do usuário e dos seus roles no domínio que cobrem obj, mas não act ou cuja condição não foi atendida.
+func (cs *CasbinService) Explain(user *models.UserRedis, dom, obj, act string) (*models.AuthzExplanation, error) {
+	sub := user.ID
+	allowed, rule, err := cs.enforcer.EnforceEx(sub, dom, obj, act, subjectAttributesOf(user))
+	if err != nil {
+		return nil, err
+	}
+
+	tenantRoles := cs.enforcer.GetRolesForUserInDomain(sub, dom)
+	globalRoles := cs.enforcer.GetRolesForUserInDomain(sub, models.GlobalDomain)
+	explanation := &models.AuthzExplanation{
+		Allowed: allowed,
+		Domain:  dom,
+		Roles:   append(append([]string{}, tenantRoles...), globalRoles...),
+	}
+
+	if allowed && len(rule) == 5 {
+		matched := authzRule(rule)
+		explanation.MatchedRule = &matched
+		explanation.Reason = fmt.Sprintf("Permitido pela política %s em %s (%s)", describeSubject(sub, matched), matched.Endpoint, matched.Actions)
+		return explanation, nil
+	}
+
+	policies, err := cs.enforcer.GetPolicy()
+	if err != nil {
+		return nil, err
+	}
+	for _, policy := range policies {
+		if len(policy) < 5 || !util.KeyMatch2(obj, policy[2]) {
+			continue
+		}
+		candidate := authzRule(policy)
+		if appliesTo(candidate, sub, dom, tenantRoles, globalRoles) {
+			explanation.Candidates = append(explanation.Candidates, candidate)
+		}
+	}
+
+	for _, candidate := range explanation.Candidates {
+		if candidate.Condition != "" && util.RegexMatch(act, candidate.Actions) {
+			explanation.Reason = fmt.Sprintf("A política %s permite %s em %s, mas a condição %q não foi atendida", describeSubject(sub, candidate), act, candidate.Endpoint, candidate.Condition)
+			return explanation, nil
+		}
+	}
+
+	switch {
+	case len(explanation.Candidates) > 0:
+		explanation.Reason = fmt.Sprintf("Nenhuma política do usuário ou dos seus roles permite %s em %s; as que cobrem o endpoint estão em candidates", act, obj)
+	case len(explanation.Roles) == 0:
+		explanation.Reason = "O usuário não tem roles neste tenant nem políticas que cubram o endpoint"
+	default:
+		explanation.Reason = fmt.Sprintf("Nenhuma política do usuário ou dos seus roles cobre %s", obj)
+	}
+	return explanation, nil
+}
+
+// Permissions monta a matriz de permissões do usuário no domínio dom a partir das políticas em vigor no enforcer:
+// as especiais do usuário, as dos seus roles no tenant e as dos seus roles globais. Políticas com condição entram
+// na matriz, pois permitem a ação em parte dos recursos.
+func (cs *CasbinService) Permissions(user *models.UserRedis, dom string) (*models.UserPermissions, error) {
+	sub := user.ID
+	tenantRoles := cs.enforcer.GetRolesForUserInDomain(sub, dom)
+	globalRoles := cs.enforcer.GetRolesForUserInDomain(sub, models.GlobalDomain)
+
+	policies, err := cs.enforcer.GetPolicy()
+	if err != nil {
+		return nil, err
+	}
+	var granted []string
+	for _, policy := range policies {
+		if len(policy) < 5 {
+			continue
+		}
+		if rule := authzRule(policy); appliesTo(rule, sub, dom, tenantRoles, globalRoles) {
+			granted = append(granted, rule.Endpoint+":"+rule.Actions)
+		}
+	}
+
+	return &models.UserPermissions{
+		Roles:       append(append([]string{}, tenantRoles...), globalRoles...),
+		Permissions: models.NewPermissionMatrix(granted),
+	}, nil
+}
+
+// LoadPolicy recarrega as políticas da view casbin_rules_view e pede a mesma recarga às outras instâncias.
+// Usado quando a alteração atinge várias políticas, como renomear ou remover roles e endpoints.
+func (cs *CasbinService) LoadPolicy() error {
+	if err := cs.reload(); err != nil {
+		return err
+	}
+	if cs.watcher != nil {
+		cs.notify(cs.watcher.Update())
+	}
+	return nil
+}
+
+// SetPolicy define as ações de sub em obj no domínio dom, com a condição cond (vazia se não houver), substituindo
+// a política anterior, e propaga a alteração.
+func (cs *CasbinService) SetPolicy(sub, dom, obj, act, cond string) error {
+	if err := cs.setPolicy(sub, dom, obj, act, cond); err != nil {
+		return err
+	}
+	if cs.watcher != nil {
+		cs.notify(cs.watcher.UpdateForSetPolicy(sub, dom, obj, act, cond))
+	}
+	return nil
+}
+
+// RemovePolicy remove a política de sub em obj no domínio dom e propaga a alteração.
+func (cs *CasbinService) RemovePolicy(sub, dom, obj string) error {
+	if err := cs.removePolicy(sub, dom, obj); err != nil {
+		return err
+	}
+	if cs.watcher != nil {
+		cs.notify(cs.watcher.UpdateForRemovePolicy(sub, dom, obj))
+	}
+	return nil
+}
+
+// AddRoleForUser atribui o role ao usuário no domínio dom e propaga a alteração.
+func (cs *CasbinService) AddRoleForUser(user, role, dom string) error {
+	if _, err := cs.enforcer.SelfAddPolicy("g", "g", []string{user, role, dom}); err != nil {
+		return err
+	}
+	if cs.watcher != nil {
+		cs.notify(cs.watcher.UpdateForAddRoleForUser(user, role, dom))
+	}
+	return nil
+}
+
+// DeleteRoleForUser retira o role do usuário no domínio dom e propaga a alteração.
+func (cs *CasbinService) DeleteRoleForUser(user, role, dom string) error {
+	if _, err := cs.enforcer.SelfRemovePolicy("g", "g", []string{user, role, dom}); err != nil {
+		return err
+	}
+	if cs.watcher != nil {
+		cs.notify(cs.watcher.UpdateForDeleteRoleForUser(user, role, dom))
+	}
+	return nil
+}
+
+// HandleUpdate aplica uma alteração publicada por outra instância. Mensagens que não puderem ser aplicadas
+// de forma incremental resultam na recarga completa.
+func (cs *CasbinService) HandleUpdate(payload string) {
+	var update CasbinPolicyUpdate
+	if err := json.Unmarshal([]byte(payload), &update); err != nil {
+		logging.WarnLogger.Printf("Mensagem de políticas do Casbin inválida, recarregando: %v", err)
+		_ = cs.reload()
+		return
+	}
+
+	var err error
+	switch {
+	case update.Op == CasbinUpdateSet && update.Sub != "" && update.Dom != "" && update.Obj != "" && update.Act != "":
+		err = cs.setPolicy(update.Sub, update.Dom, update.Obj, update.Act, update.Cond)
+	case update.Op == CasbinUpdateRemove && update.Sub != "" && update.Dom != "" && update.Obj != "":
+		err = cs.removePolicy(update.Sub, update.Dom, update.Obj)
+	case update.Op == CasbinUpdateAddRole && update.Sub != "" && update.Role != "" && update.Dom != "":
+		_, err = cs.enforcer.SelfAddPolicy("g", "g", []string{update.Sub, update.Role, update.Dom})
+	case update.Op == CasbinUpdateDeleteRole && update.Sub != "" && update.Role != "" && update.Dom != "":
+		_, err = cs.enforcer.SelfRemovePolicy("g", "g", []string{update.Sub, update.Role, update.Dom})
+	default:
+		_ = cs.reload()
+		return
+	}
+	if err != nil {
+		logging.WarnLogger.Printf("Falha ao aplicar a alteração de políticas do Casbin (%s), recarregando: %v", update.Op, err)
+		_ = cs.reload()
+	}
+}
+
+// Close encerra a recarga periódica e o watcher.
+func (cs *CasbinService) Close() {
+	cs.closeOnce.Do(func() {
+		close(cs.stop)
+		if cs.watcher != nil {
+			cs.watcher.Close()
+		}
+	})
+}
+
+func (cs *CasbinService) reload() error {
+	if err := cs.enforcer.LoadPolicy(); err != nil {
+		log.Printf("Erro ao recarregar as políticas: %v", err)
+		return err
+	}
+	return nil
+}
+
+// appliesTo indica se a política vale para sub no domínio dom, seguindo o matcher do casbinModel.
+func appliesTo(rule models.AuthzRule, sub, dom string, tenantRoles, globalRoles []string) bool {
+	switch {
+	case rule.Subject == sub:
+		return rule.Domain == dom
+	case rule.Domain == dom:
+		return slices.Contains(tenantRoles, rule.Subject)
+	case rule.Domain == models.GlobalDomain:
+		return slices.Contains(globalRoles, rule.Subject)
+	}
+	return false
+}
+
+func authzRule(policy []string) models.AuthzRule {
+	rule := models.AuthzRule{Subject: policy[0], Domain: policy[1], Endpoint: policy[2], Actions: policy[3]}
+	if policy[4] != NoCondition {
+		rule.Condition = policy[4]
+	}
+	return rule
+}
+
+func describeSubject(sub string, rule models.AuthzRule) string {
+	switch {
+	case rule.Subject == sub:
+		return "especial do usuário"
+	case rule.Domain == models.GlobalDomain:
+		return fmt.Sprintf("do role global %s", rule.Subject)
+	default:
+		return fmt.Sprintf("do role %s", rule.Subject)
+	}
+}
+
+func (cs *CasbinService) setPolicy(sub, dom, obj, act, cond string) error {
+	if cond == "" {
+		cond = NoCondition
+	}
+	if _, err := cs.enforcer.SelfRemoveFilteredPolicy("p", "p", 0, sub, dom, obj); err != nil {
+		return err
+	}
+	_, err := cs.enforcer.SelfAddPolicy("p", "p", []string{sub, dom, obj, act, cond})
+	return err
+}
+
+func (cs *CasbinService) removePolicy(sub, dom, obj string) error {
+	_, err := cs.enforcer.SelfRemoveFilteredPolicy("p", "p", 0, sub, dom, obj)
+	return err
+}
+
+// notify apenas registra a falha de publicação: a alteração já vale nesta instância e as demais
+// a recebem na próxima recarga periódica.
+func (cs *CasbinService) notify(err error) {
+	if err != nil {
+		logging.WarnLogger.Printf("Falha ao propagar a alteração de políticas do Casbin: %v", err)
+	}
+}
+
+func (cs *CasbinService) reloadPeriodically(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			_ = cs.reload()
+		case <-cs.stop:
+			return
+		}
+	}
+}
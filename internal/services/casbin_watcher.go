@@ -0,0 +1,154 @@
+// internal/services/casbin_watcher.go
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/logging"
+	"github.com/redis/go-redis/v9"
+)
+
+// Operações publicadas pelo CasbinWatcher.
+const (
+	CasbinUpdateReload     = "reload"
+	CasbinUpdateSet        = "set"
+	CasbinUpdateRemove     = "remove"
+	CasbinUpdateAddRole    = "add_role"
+	CasbinUpdateDeleteRole = "delete_role"
+)
+
+// CasbinPolicyUpdate é a mensagem trocada entre as instâncias. "set" substitui as ações (e a condição) de sub em obj no domínio dom,
+// "remove" apaga a política de sub em obj no domínio dom, "add_role" e "delete_role" atribuem e retiram o role
+// do usuário sub no domínio dom e "reload" pede a recarga completa a partir do banco.
+type CasbinPolicyUpdate struct {
+	InstanceID string `json:"instance_id"`
+	Op         string `json:"op"`
+	Sub        string `json:"sub,omitempty"`
+	Dom        string `json:"dom,omitempty"`
+	Obj        string `json:"obj,omitempty"`
+	Act        string `json:"act,omitempty"`
+	Cond       string `json:"cond,omitempty"`
+	Role       string `json:"role,omitempty"`
+}
+
+// CasbinWatcherInterface propaga as alterações de políticas para as demais instâncias.
+// Estende persist.Watcher (SetUpdateCallback, Update e Close) com as atualizações incrementais.
+type CasbinWatcherInterface interface {
+	SetUpdateCallback(callback func(string)) error
+	Update() error
+	UpdateForSetPolicy(sub, dom, obj, act, cond string) error
+	UpdateForRemovePolicy(sub, dom, obj string) error
+	UpdateForAddRoleForUser(user, role, dom string) error
+	UpdateForDeleteRoleForUser(user, role, dom string) error
+	Close()
+}
+
+// CasbinWatcher publica as alterações de políticas em um canal Redis (pub/sub) e entrega ao callback
+// as mensagens publicadas pelas outras instâncias; as mensagens da própria instância são ignoradas.
+type CasbinWatcher struct {
+	client     *redis.Client
+	channel    string
+	instanceID string
+	pubsub     *redis.PubSub
+	callback   func(string)
+	mu         sync.RWMutex
+	closeOnce  sync.Once
+}
+
+// NewCasbinWatcher assina o canal e começa a receber as mensagens. Falha se o Redis não confirmar a assinatura.
+func NewCasbinWatcher(client *redis.Client, channel string) (*CasbinWatcher, error) {
+	w := &CasbinWatcher{
+		client:     client,
+		channel:    channel,
+		instanceID: uuid.NewString(),
+	}
+
+	w.pubsub = client.Subscribe(context.Background(), channel)
+	if _, err := w.pubsub.Receive(context.Background()); err != nil {
+		logging.ErrorLogger.Printf("Erro ao assinar o canal %s de políticas do Casbin: %v", channel, err)
+		_ = w.pubsub.Close()
+		return nil, err
+	}
+
+	go w.listen()
+	logging.InfoLogger.Printf("Watcher do Casbin assinando o canal %s (instância %s)", channel, w.instanceID)
+	return w, nil
+}
+
+// SetUpdateCallback define a função chamada com o conteúdo (JSON) de cada mensagem recebida.
+func (w *CasbinWatcher) SetUpdateCallback(callback func(string)) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.callback = callback
+	return nil
+}
+
+// Update pede às outras instâncias a recarga completa das políticas.
+func (w *CasbinWatcher) Update() error {
+	return w.publish(CasbinPolicyUpdate{Op: CasbinUpdateReload})
+}
+
+// UpdateForSetPolicy avisa as outras instâncias que as ações de sub em obj no domínio dom passaram a ser act,
+// com a condição cond.
+func (w *CasbinWatcher) UpdateForSetPolicy(sub, dom, obj, act, cond string) error {
+	return w.publish(CasbinPolicyUpdate{Op: CasbinUpdateSet, Sub: sub, Dom: dom, Obj: obj, Act: act, Cond: cond})
+}
+
+// UpdateForRemovePolicy avisa as outras instâncias que a política de sub em obj no domínio dom foi removida.
+func (w *CasbinWatcher) UpdateForRemovePolicy(sub, dom, obj string) error {
+	return w.publish(CasbinPolicyUpdate{Op: CasbinUpdateRemove, Sub: sub, Dom: dom, Obj: obj})
+}
+
+// UpdateForAddRoleForUser avisa as outras instâncias que o role foi atribuído ao usuário no domínio dom.
+func (w *CasbinWatcher) UpdateForAddRoleForUser(user, role, dom string) error {
+	return w.publish(CasbinPolicyUpdate{Op: CasbinUpdateAddRole, Sub: user, Role: role, Dom: dom})
+}
+
+// UpdateForDeleteRoleForUser avisa as outras instâncias que o role foi retirado do usuário no domínio dom.
+func (w *CasbinWatcher) UpdateForDeleteRoleForUser(user, role, dom string) error {
+	return w.publish(CasbinPolicyUpdate{Op: CasbinUpdateDeleteRole, Sub: user, Role: role, Dom: dom})
+}
+
+// Close encerra a assinatura; o callback não é mais chamado.
+func (w *CasbinWatcher) Close() {
+	w.closeOnce.Do(func() {
+		if err := w.pubsub.Close(); err != nil {
+			logging.ErrorLogger.Printf("Erro ao encerrar o watcher do Casbin: %v", err)
+		}
+	})
+}
+
+func (w *CasbinWatcher) publish(update CasbinPolicyUpdate) error {
+	update.InstanceID = w.instanceID
+	payload, err := json.Marshal(update)
+	if err != nil {
+		return err
+	}
+	if err := w.client.Publish(context.Background(), w.channel, payload).Err(); err != nil {
+		logging.ErrorLogger.Printf("Erro ao publicar a alteração de políticas do Casbin: %v", err)
+		return err
+	}
+	return nil
+}
+
+// listen entrega as mensagens ao callback até a assinatura ser encerrada. O go-redis refaz a assinatura
+// sozinho se a conexão cair; mensagens perdidas nesse intervalo são cobertas pela recarga periódica.
+func (w *CasbinWatcher) listen() {
+	for msg := range w.pubsub.Channel() {
+		var update CasbinPolicyUpdate
+		if err := json.Unmarshal([]byte(msg.Payload), &update); err == nil && update.InstanceID == w.instanceID {
+			continue
+		}
+
+		w.mu.RLock()
+		callback := w.callback
+		w.mu.RUnlock()
+		if callback != nil {
+			callback(msg.Payload)
+		}
+	}
+}
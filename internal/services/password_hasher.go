@@ -0,0 +1,180 @@
+// internal/services/password_hasher.go
+
+package services
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Algoritmos de hash de senha suportados.
+const (
+	PasswordAlgorithmArgon2id = "argon2id"
+	PasswordAlgorithmBcrypt   = "bcrypt"
+)
+
+// BcryptMaxPasswordBytes é o tamanho máximo de senha aceito pelo bcrypt; bytes além disso seriam ignorados.
+const BcryptMaxPasswordBytes = 72
+
+// ErrUnknownPasswordHash indica que o hash armazenado não está em um formato reconhecido.
+var ErrUnknownPasswordHash = errors.New("formato de hash de senha desconhecido")
+
+// PasswordHasher gera e verifica hashes de senha. Verify aceita qualquer algoritmo suportado, para que
+// hashes antigos continuem válidos; NeedsRehash indica quando o hash deve ser regerado com a configuração atual.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Verify(password, encodedHash string) (bool, error)
+	NeedsRehash(encodedHash string) bool
+}
+
+// Argon2idParams são os parâmetros de custo do Argon2id. Memory é dado em KiB.
+type Argon2idParams struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// PasswordHasherConfig define o algoritmo usado nos novos hashes e seus parâmetros.
+type PasswordHasherConfig struct {
+	Algorithm  string
+	BcryptCost int
+	Argon2id   Argon2idParams
+}
+
+// DefaultPasswordHasher gera hashes no formato PHC ($argon2id$v=19$m=...,t=...,p=...$salt$hash) ou no formato
+// modular do bcrypt ($2a$...), conforme o algoritmo configurado, e verifica hashes de ambos.
+type DefaultPasswordHasher struct {
+	Config PasswordHasherConfig
+}
+
+func NewPasswordHasher(config PasswordHasherConfig) *DefaultPasswordHasher {
+	if config.Argon2id.SaltLength == 0 {
+		config.Argon2id.SaltLength = 16
+	}
+	if config.Argon2id.KeyLength == 0 {
+		config.Argon2id.KeyLength = 32
+	}
+	return &DefaultPasswordHasher{Config: config}
+}
+
+// NewBcryptPasswordHasher cria um hasher que gera hashes bcrypt com o custo informado.
+func NewBcryptPasswordHasher(cost int) *DefaultPasswordHasher {
+	return NewPasswordHasher(PasswordHasherConfig{Algorithm: PasswordAlgorithmBcrypt, BcryptCost: cost})
+}
+
+func (h *DefaultPasswordHasher) Hash(password string) (string, error) {
+	switch h.Config.Algorithm {
+	case PasswordAlgorithmArgon2id:
+		return h.hashArgon2id(password)
+	case PasswordAlgorithmBcrypt:
+		hashed, err := bcrypt.GenerateFromPassword([]byte(password), h.Config.BcryptCost)
+		if err != nil {
+			return "", err
+		}
+		return string(hashed), nil
+	default:
+		return "", fmt.Errorf("algoritmo de hash de senha não suportado: %s", h.Config.Algorithm)
+	}
+}
+
+func (h *DefaultPasswordHasher) Verify(password, encodedHash string) (bool, error) {
+	switch {
+	case strings.HasPrefix(encodedHash, "$argon2id$"):
+		params, salt, key, err := decodeArgon2idHash(encodedHash)
+		if err != nil {
+			return false, err
+		}
+		candidate := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+		return subtle.ConstantTimeCompare(candidate, key) == 1, nil
+	case isBcryptHash(encodedHash):
+		err := bcrypt.CompareHashAndPassword([]byte(encodedHash), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+		return err == nil, err
+	default:
+		return false, ErrUnknownPasswordHash
+	}
+}
+
+func (h *DefaultPasswordHasher) NeedsRehash(encodedHash string) bool {
+	switch h.Config.Algorithm {
+	case PasswordAlgorithmArgon2id:
+		params, salt, key, err := decodeArgon2idHash(encodedHash)
+		if err != nil {
+			return true
+		}
+		current := h.Config.Argon2id
+		return params.Memory != current.Memory ||
+			params.Iterations != current.Iterations ||
+			params.Parallelism != current.Parallelism ||
+			uint32(len(salt)) != current.SaltLength ||
+			uint32(len(key)) != current.KeyLength
+	case PasswordAlgorithmBcrypt:
+		if !isBcryptHash(encodedHash) {
+			return true
+		}
+		cost, err := bcrypt.Cost([]byte(encodedHash))
+		return err != nil || cost != h.Config.BcryptCost
+	default:
+		return false
+	}
+}
+
+func (h *DefaultPasswordHasher) hashArgon2id(password string) (string, error) {
+	params := h.Config.Argon2id
+	salt := make([]byte, params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, params.Memory, params.Iterations, params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func decodeArgon2idHash(encodedHash string) (Argon2idParams, []byte, []byte, error) {
+	var params Argon2idParams
+
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, hash
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 6 || parts[1] != PasswordAlgorithmArgon2id {
+		return params, nil, nil, ErrUnknownPasswordHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("versão do argon2id não suportada: %s", parts[2])
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, fmt.Errorf("parâmetros do argon2id inválidos: %w", err)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("salt do argon2id inválido: %w", err)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("hash do argon2id inválido: %w", err)
+	}
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+
+	return params, salt, key, nil
+}
+
+func isBcryptHash(encodedHash string) bool {
+	return strings.HasPrefix(encodedHash, "$2a$") || strings.HasPrefix(encodedHash, "$2b$") || strings.HasPrefix(encodedHash, "$2y$")
+}
@@ -0,0 +1,175 @@
+// internal/services/password_policy.go
+
+package services
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/logging"
+)
+
+// Códigos das violações da política de senha, estáveis para tratamento pelo front-end.
+const (
+	PasswordViolationMinLength        = "min_length"
+	PasswordViolationMaxLength        = "max_length"
+	PasswordViolationUpper            = "uppercase"
+	PasswordViolationLower            = "lowercase"
+	PasswordViolationDigit            = "digit"
+	PasswordViolationSymbol           = "symbol"
+	PasswordViolationContainsEmail    = "contains_email"
+	PasswordViolationContainsUsername = "contains_username"
+	PasswordViolationBreached         = "breached"
+)
+
+// PasswordPolicyError lista todas as regras da política que a senha não atende.
+type PasswordPolicyError struct {
+	Violations []models.PasswordViolation
+}
+
+func (e *PasswordPolicyError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		messages = append(messages, v.Message)
+	}
+	return "senha não atende à política: " + strings.Join(messages, "; ")
+}
+
+// BreachedPasswordChecker verifica se uma senha aparece em uma lista de senhas vazadas.
+type BreachedPasswordChecker interface {
+	IsBreached(password string) (bool, error)
+}
+
+// PasswordPolicyConfig define as regras da política de senha.
+type PasswordPolicyConfig struct {
+	MinLength     int
+	MaxBytes      int // tamanho máximo em bytes (0 = sem limite); usado com o bcrypt, que ignora o excedente
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+}
+
+// PasswordPolicy valida senhas novas (criação, troca e redefinição).
+type PasswordPolicy struct {
+	Config   PasswordPolicyConfig
+	Breached BreachedPasswordChecker
+}
+
+// NewPasswordPolicy cria a política. breached pode ser nil para desativar a verificação de senhas vazadas.
+func NewPasswordPolicy(config PasswordPolicyConfig, breached BreachedPasswordChecker) *PasswordPolicy {
+	return &PasswordPolicy{Config: config, Breached: breached}
+}
+
+// Validate retorna um *PasswordPolicyError com todas as violações encontradas, ou nil se a senha for aceita.
+// email e username são usados para recusar senhas que os contenham.
+func (p *PasswordPolicy) Validate(password, email, username string) error {
+	var violations []models.PasswordViolation
+	add := func(code, message string) {
+		violations = append(violations, models.PasswordViolation{Code: code, Message: message})
+	}
+
+	if utf8.RuneCountInString(password) < p.Config.MinLength {
+		add(PasswordViolationMinLength, fmt.Sprintf("a senha deve ter pelo menos %d caracteres", p.Config.MinLength))
+	}
+	if p.Config.MaxBytes > 0 && len(password) > p.Config.MaxBytes {
+		add(PasswordViolationMaxLength, fmt.Sprintf("a senha deve ter no máximo %d bytes", p.Config.MaxBytes))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+	if p.Config.RequireUpper && !hasUpper {
+		add(PasswordViolationUpper, "a senha deve ter ao menos uma letra maiúscula")
+	}
+	if p.Config.RequireLower && !hasLower {
+		add(PasswordViolationLower, "a senha deve ter ao menos uma letra minúscula")
+	}
+	if p.Config.RequireDigit && !hasDigit {
+		add(PasswordViolationDigit, "a senha deve ter ao menos um número")
+	}
+	if p.Config.RequireSymbol && !hasSymbol {
+		add(PasswordViolationSymbol, "a senha deve ter ao menos um símbolo")
+	}
+
+	lowered := strings.ToLower(password)
+	if local, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(email)), "@"); len(local) >= 3 && strings.Contains(lowered, local) {
+		add(PasswordViolationContainsEmail, "a senha não pode conter o e-mail")
+	}
+	if name := strings.ToLower(strings.TrimSpace(username)); len(name) >= 3 && strings.Contains(lowered, name) {
+		add(PasswordViolationContainsUsername, "a senha não pode conter o nome de usuário")
+	}
+
+	// Só consulta a lista de vazadas se a senha passou nas demais regras, evitando I/O desnecessário.
+	if len(violations) == 0 && p.Breached != nil {
+		breached, err := p.Breached.IsBreached(password)
+		if err != nil {
+			logging.WarnLogger.Printf("Falha ao consultar a lista de senhas vazadas: %v", err)
+		} else if breached {
+			add(PasswordViolationBreached, "a senha aparece em vazamentos de dados conhecidos; escolha outra")
+		}
+	}
+
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// PwnedRangeDirChecker consulta um diretório com arquivos de prefixo no formato da API de range do
+// Have I Been Pwned: o arquivo <PREFIXO> (ou <PREFIXO>.txt) contém linhas "SUFIXO:CONTAGEM", onde
+// PREFIXO são os 5 primeiros e SUFIXO os 35 últimos caracteres hexadecimais do SHA-1 da senha.
+// Apenas o arquivo do prefixo é lido, sem carregar a lista inteira em memória.
+type PwnedRangeDirChecker struct {
+	Dir string
+}
+
+func NewPwnedRangeDirChecker(dir string) *PwnedRangeDirChecker {
+	return &PwnedRangeDirChecker{Dir: dir}
+}
+
+func (p *PwnedRangeDirChecker) IsBreached(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	file, err := os.Open(filepath.Join(p.Dir, prefix+".txt"))
+	if errors.Is(err, os.ErrNotExist) {
+		file, err = os.Open(filepath.Join(p.Dir, prefix))
+	}
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		entry, _, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if strings.EqualFold(entry, suffix) {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
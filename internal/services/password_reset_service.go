@@ -0,0 +1,174 @@
+// internal/services/password_reset_service.go
+
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/logging"
+	"github.com/jeancarlosdanese/go-base-api/internal/mailer"
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrPasswordResetTokenInvalid indica que o token de redefinição não existe, expirou ou já foi usado.
+var ErrPasswordResetTokenInvalid = errors.New("token de redefinição de senha inválido ou expirado")
+
+type PasswordResetServiceInterface interface {
+	RequestPasswordReset(c *gin.Context, email, origin string) error
+	ResetPassword(c *gin.Context, token, newPassword string) error
+}
+
+// PasswordResetService emite e consome tokens de redefinição de senha de uso único.
+// Apenas o hash SHA-256 do token é guardado no Redis; o token em si só existe no e-mail enviado ao usuário.
+type PasswordResetService struct {
+	UserService       UserServiceInterface
+	RedisService      RedisServiceInterface
+	TokenRedisService TokenRedisServiceInterface
+	Mailer            mailer.Mailer
+	TokenDuration     time.Duration
+	ResetURL          string
+}
+
+func NewPasswordResetService(
+	userService UserServiceInterface,
+	redisService RedisServiceInterface,
+	tokenRedisService TokenRedisServiceInterface,
+	mailer mailer.Mailer,
+	tokenDuration time.Duration,
+	resetURL string) *PasswordResetService {
+	return &PasswordResetService{
+		UserService:       userService,
+		RedisService:      redisService,
+		TokenRedisService: tokenRedisService,
+		Mailer:            mailer,
+		TokenDuration:     tokenDuration,
+		ResetURL:          resetURL,
+	}
+}
+
+// RequestPasswordReset gera um token para o usuário e o envia por e-mail. Um novo pedido invalida o token anterior.
+// Se o e-mail não existir para a origem, nada é enviado e nenhum erro é retornado, para não revelar quais e-mails estão cadastrados.
+func (s *PasswordResetService) RequestPasswordReset(c *gin.Context, email, origin string) error {
+	user, err := s.UserService.FindByEmail(c, email, origin)
+	if err != nil || user == nil {
+		logging.InfoLogger.Printf("Pedido de redefinição de senha para e-mail não cadastrado na origem: %s", origin)
+		return nil
+	}
+
+	token, err := generateOpaqueToken()
+	if err != nil {
+		return err
+	}
+	userID := user.ID.String()
+
+	previous, err := s.RedisService.GetDel(passwordResetUserKey(userID))
+	if err != nil && err != redis.Nil {
+		return err
+	}
+	if previous != "" {
+		if err := s.RedisService.Delete(passwordResetKey(previous)); err != nil {
+			return err
+		}
+	}
+
+	tokenHash := hashOpaqueToken(token)
+	if err := s.RedisService.Set(passwordResetKey(tokenHash), userID, s.TokenDuration); err != nil {
+		return err
+	}
+	if err := s.RedisService.Set(passwordResetUserKey(userID), tokenHash, s.TokenDuration); err != nil {
+		return err
+	}
+
+	return s.Mailer.Send(c, mailer.Message{
+		To:      user.Email,
+		Subject: "Redefinição de senha",
+		Body:    s.resetMessage(user.Name, token),
+		Secret:  token,
+	})
+}
+
+// ResetPassword consome o token, grava a nova senha e revoga todas as sessões do usuário.
+// Se a senha for recusada pela política, o token volta a valer pelo tempo que lhe restava.
+func (s *PasswordResetService) ResetPassword(c *gin.Context, token, newPassword string) error {
+	tokenHash := hashOpaqueToken(token)
+	remaining, err := s.RedisService.TTL(passwordResetKey(tokenHash))
+	if err != nil {
+		return err
+	}
+
+	userIDStr, err := s.RedisService.GetDel(passwordResetKey(tokenHash))
+	if err == redis.Nil || (err == nil && userIDStr == "") {
+		return ErrPasswordResetTokenInvalid
+	}
+	if err != nil {
+		return err
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return ErrPasswordResetTokenInvalid
+	}
+
+	if err := s.UserService.UpdatePassword(c, userID, newPassword); err != nil {
+		var policyErr *PasswordPolicyError
+		if errors.As(err, &policyErr) && remaining > 0 {
+			if restoreErr := s.RedisService.Set(passwordResetKey(tokenHash), userIDStr, remaining); restoreErr != nil {
+				logging.WarnLogger.Printf("Falha ao restaurar token de redefinição de senha do usuário %s: %v", userIDStr, restoreErr)
+			}
+		}
+		return err
+	}
+
+	if err := s.RedisService.Delete(passwordResetUserKey(userIDStr)); err != nil {
+		logging.WarnLogger.Printf("Falha ao remover índice de redefinição de senha do usuário %s: %v", userIDStr, err)
+	}
+
+	if err := s.TokenRedisService.RevokeAllSessions(userIDStr); err != nil {
+		logging.ErrorLogger.Printf("Senha redefinida, mas falha ao revogar sessões do usuário %s: %v", userIDStr, err)
+		return err
+	}
+
+	logging.InfoLogger.Printf("Senha redefinida para o usuário %s", userIDStr)
+	return nil
+}
+
+func (s *PasswordResetService) resetMessage(name, token string) string {
+	validity := s.TokenDuration.Round(time.Minute)
+	if s.ResetURL != "" {
+		link := s.ResetURL + "?token=" + url.QueryEscape(token)
+		return fmt.Sprintf("Olá %s,\n\nPara redefinir sua senha, acesse o link abaixo (válido por %s):\n\n%s\n\nSe você não solicitou a redefinição, ignore este e-mail.", name, validity, link)
+	}
+	return fmt.Sprintf("Olá %s,\n\nUse o código abaixo para redefinir sua senha (válido por %s):\n\n%s\n\nSe você não solicitou a redefinição, ignore este e-mail.", name, validity, token)
+}
+
+// generateOpaqueToken gera um token aleatório de 256 bits para links e desafios de uso único.
+func generateOpaqueToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashOpaqueToken retorna o hash SHA-256 do token, que é o que fica guardado no Redis.
+func hashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func passwordResetKey(tokenHash string) string {
+	return "password_reset:" + tokenHash
+}
+
+func passwordResetUserKey(userID string) string {
+	return "password_reset_user:" + userID
+}
@@ -0,0 +1,168 @@
+// internal/services/policy_condition.go
+
+package services
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+)
+
+// NoCondition é a condição das políticas que valem sem restrição de atributos; no banco, a coluna condition fica nula.
+const NoCondition = "*"
+
+// ErrInvalidCondition indica uma condição de política fora da sintaxe aceita.
+var ErrInvalidCondition = errors.New("condição inválida: use comparações como param.id == user.id, unidas por &&")
+
+// Atributos do usuário da sessão disponíveis nas condições.
+var subjectAttributes = map[string]bool{"id": true, "tenant_id": true, "username": true, "email": true}
+
+// conditionClause é uma comparação entre dois operandos: user.<atributo> ou param.<parâmetro da rota>.
+type conditionClause struct {
+	left, right string
+	equal       bool
+}
+
+// policyCondition é uma condição de política (ABAC): todas as comparações precisam ser verdadeiras. Os parâmetros
+// vêm do caminho da requisição casado com o endpoint da política, ex.: em /api/v1/users/:id, param.id é o ID do
+// usuário acessado; assim, "param.id == user.id" restringe a política ao próprio usuário e
+// "param.id == user.tenant_id" (em /api/v1/tenants/:id) ao próprio tenant.
+type policyCondition []conditionClause
+
+// parsedConditions guarda as condições já interpretadas, pois elas são avaliadas a cada requisição.
+var parsedConditions sync.Map
+
+func parseCondition(expr string) (policyCondition, error) {
+	var condition policyCondition
+	for _, part := range strings.Split(expr, "&&") {
+		clause := conditionClause{equal: true}
+		operator := "=="
+		if strings.Contains(part, "!=") {
+			clause.equal = false
+			operator = "!="
+		}
+		left, right, found := strings.Cut(part, operator)
+		if !found {
+			return nil, ErrInvalidCondition
+		}
+		clause.left, clause.right = strings.TrimSpace(left), strings.TrimSpace(right)
+		if !validOperand(clause.left) || !validOperand(clause.right) {
+			return nil, ErrInvalidCondition
+		}
+		condition = append(condition, clause)
+	}
+	return condition, nil
+}
+
+func validOperand(operand string) bool {
+	switch {
+	case strings.HasPrefix(operand, "user."):
+		return subjectAttributes[strings.TrimPrefix(operand, "user.")]
+	case strings.HasPrefix(operand, "param."):
+		name := strings.TrimPrefix(operand, "param.")
+		return name != "" && !strings.ContainsAny(name, " /:=!&")
+	}
+	return false
+}
+
+// normalizeCondition valida a condição para o endpoint (os parâmetros precisam existir na rota) e a devolve
+// no formato canônico. Condição vazia significa política sem condição.
+func normalizeCondition(expr, endpoint string) (string, error) {
+	if strings.TrimSpace(expr) == "" {
+		return "", nil
+	}
+	condition, err := parseCondition(expr)
+	if err != nil {
+		return "", err
+	}
+
+	params := make(map[string]bool)
+	for _, segment := range strings.Split(endpoint, "/") {
+		if strings.HasPrefix(segment, ":") {
+			params[strings.TrimPrefix(segment, ":")] = true
+		}
+	}
+
+	clauses := make([]string, 0, len(condition))
+	for _, clause := range condition {
+		for _, operand := range []string{clause.left, clause.right} {
+			if name, ok := strings.CutPrefix(operand, "param."); ok && !params[name] {
+				return "", ErrInvalidCondition
+			}
+		}
+		operator := " == "
+		if !clause.equal {
+			operator = " != "
+		}
+		clauses = append(clauses, clause.left+operator+clause.right)
+	}
+	return strings.Join(clauses, " && "), nil
+}
+
+// eval avalia a condição com os atributos do usuário e os parâmetros da rota. Atributos ausentes ou vazios
+// nunca são iguais a nada, para que uma sessão incompleta não satisfaça "param.id == user.id".
+func (pc policyCondition) eval(subject, params map[string]string) bool {
+	value := func(operand string) string {
+		if name, ok := strings.CutPrefix(operand, "user."); ok {
+			return subject[name]
+		}
+		return params[strings.TrimPrefix(operand, "param.")]
+	}
+	for _, clause := range pc {
+		left, right := value(clause.left), value(clause.right)
+		if left == "" || right == "" || (left == right) != clause.equal {
+			return false
+		}
+	}
+	return true
+}
+
+// routeParams extrai os parâmetros (:nome) do endpoint a partir do caminho da requisição, que já casou com ele
+// pelo keyMatch2.
+func routeParams(path, endpoint string) map[string]string {
+	params := make(map[string]string)
+	pathSegments := strings.Split(path, "/")
+	for i, segment := range strings.Split(endpoint, "/") {
+		if name, ok := strings.CutPrefix(segment, ":"); ok && i < len(pathSegments) {
+			params[name] = pathSegments[i]
+		}
+	}
+	return params
+}
+
+// subjectAttributesOf devolve os atributos do usuário da sessão usados nas condições.
+func subjectAttributesOf(user *models.UserRedis) map[string]string {
+	return map[string]string{
+		"id":        user.ID,
+		"tenant_id": user.TenantID,
+		"username":  user.Username,
+		"email":     user.Email,
+	}
+}
+
+// conditionMatch é a função conditionMatch(r.obj, p.obj, p.cond, r.usr) do matcher do Casbin.
+func conditionMatch(args ...interface{}) (interface{}, error) {
+	if len(args) != 4 {
+		return false, nil
+	}
+	path, _ := args[0].(string)
+	endpoint, _ := args[1].(string)
+	expr, _ := args[2].(string)
+	subject, _ := args[3].(map[string]string)
+	if expr == NoCondition {
+		return true, nil
+	}
+
+	cached, ok := parsedConditions.Load(expr)
+	if !ok {
+		condition, err := parseCondition(expr)
+		if err != nil {
+			// Condição gravada fora da sintaxe: a política não concede nada
+			return false, nil
+		}
+		cached, _ = parsedConditions.LoadOrStore(expr, condition)
+	}
+	return cached.(policyCondition).eval(subject, routeParams(path, endpoint)), nil
+}
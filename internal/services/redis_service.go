@@ -13,7 +13,16 @@ import (
 
 type RedisServiceInterface interface {
 	Set(key string, value interface{}, expiration time.Duration) error
+	SetNX(key string, value interface{}, expiration time.Duration) (bool, error)
 	Get(key string) (string, error)
+	GetDel(key string) (string, error)
+	Delete(keys ...string) error
+	Incr(key string, expiration time.Duration) (int64, error)
+	TTL(key string) (time.Duration, error)
+	HSet(key, field string, value interface{}, expiration time.Duration) error
+	HGet(key, field string) (string, error)
+	HGetAll(key string) (map[string]string, error)
+	HDel(key string, fields ...string) error
 }
 
 type RedisService struct {
@@ -35,6 +44,15 @@ func (r *RedisService) Set(key string, value interface{}, expiration time.Durati
 	return err
 }
 
+// SetNX grava a chave apenas se ela ainda não existir. Retorna true se a chave foi gravada.
+func (r *RedisService) SetNX(key string, value interface{}, expiration time.Duration) (bool, error) {
+	ok, err := r.Client.SetNX(context.Background(), key, value, expiration).Result()
+	if err != nil {
+		log.Printf("ERROR: Error setting key (NX) in Redis: %v", err)
+	}
+	return ok, err
+}
+
 func (r *RedisService) Get(key string) (string, error) {
 	// log.Printf("INFO: Getting key from Redis: %s", key)
 	result, err := r.Client.Get(context.Background(), key).Result()
@@ -43,3 +61,96 @@ func (r *RedisService) Get(key string) (string, error) {
 	}
 	return result, err
 }
+
+// GetDel lê e remove a chave em uma única operação atômica.
+func (r *RedisService) GetDel(key string) (string, error) {
+	result, err := r.Client.GetDel(context.Background(), key).Result()
+	if err != nil && err != redis.Nil {
+		log.Printf("ERROR: Error getting and deleting key from Redis: %v", err)
+	}
+	return result, err
+}
+
+func (r *RedisService) Delete(keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	err := r.Client.Del(context.Background(), keys...).Err()
+	if err != nil {
+		log.Printf("ERROR: Error deleting keys from Redis: %v", err)
+	}
+	return err
+}
+
+// Incr incrementa o contador e, na primeira vez, define sua expiração. Retorna o valor após o incremento.
+func (r *RedisService) Incr(key string, expiration time.Duration) (int64, error) {
+	ctx := context.Background()
+	value, err := r.Client.Incr(ctx, key).Result()
+	if err != nil {
+		log.Printf("ERROR: Error incrementing key in Redis: %v", err)
+		return 0, err
+	}
+	if value == 1 && expiration > 0 {
+		if err := r.Client.Expire(ctx, key, expiration).Err(); err != nil {
+			log.Printf("ERROR: Error setting expiration in Redis: %v", err)
+			return value, err
+		}
+	}
+	return value, nil
+}
+
+// TTL retorna o tempo restante da chave. Chaves inexistentes ou sem expiração retornam zero.
+func (r *RedisService) TTL(key string) (time.Duration, error) {
+	ttl, err := r.Client.TTL(context.Background(), key).Result()
+	if err != nil {
+		log.Printf("ERROR: Error getting TTL from Redis: %v", err)
+		return 0, err
+	}
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+// HSet grava um campo em um hash e renova a expiração do hash inteiro.
+func (r *RedisService) HSet(key, field string, value interface{}, expiration time.Duration) error {
+	ctx := context.Background()
+	_, err := r.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, field, value)
+		if expiration > 0 {
+			pipe.Expire(ctx, key, expiration)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("ERROR: Error setting hash field in Redis: %v", err)
+	}
+	return err
+}
+
+func (r *RedisService) HGet(key, field string) (string, error) {
+	result, err := r.Client.HGet(context.Background(), key, field).Result()
+	if err != nil && err != redis.Nil {
+		log.Printf("ERROR: Error getting hash field from Redis: %v", err)
+	}
+	return result, err
+}
+
+func (r *RedisService) HGetAll(key string) (map[string]string, error) {
+	result, err := r.Client.HGetAll(context.Background(), key).Result()
+	if err != nil {
+		log.Printf("ERROR: Error getting hash from Redis: %v", err)
+	}
+	return result, err
+}
+
+func (r *RedisService) HDel(key string, fields ...string) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	err := r.Client.HDel(context.Background(), key, fields...).Err()
+	if err != nil {
+		log.Printf("ERROR: Error deleting hash fields from Redis: %v", err)
+	}
+	return err
+}
@@ -0,0 +1,650 @@
+// internal/services/security_service.go
+
+package services
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	contextkeys "github.com/jeancarlosdanese/go-base-api/internal/domain/context_keys"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/enums"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/logging"
+	"github.com/jeancarlosdanese/go-base-api/internal/repositories"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrRoleNotFound indica que o role não existe.
+	ErrRoleNotFound = errors.New("role não encontrado")
+	// ErrEndpointNotFound indica que o endpoint não existe.
+	ErrEndpointNotFound = errors.New("endpoint não encontrado")
+	// ErrUserNotFound indica que o usuário não existe no tenant da requisição.
+	ErrUserNotFound = errors.New("usuário não encontrado")
+	// ErrPolicyNotFound indica que não há política (ou atribuição de role) para o par informado.
+	ErrPolicyNotFound = errors.New("política não encontrada")
+	// ErrSecurityConflict indica um nome já usado ou uma política/atribuição já existente.
+	ErrSecurityConflict = errors.New("registro já existe")
+	// ErrSecurityInUse indica um role atribuído a usuários ou um endpoint usado em políticas.
+	ErrSecurityInUse = errors.New("registro em uso")
+	// ErrInvalidActions indica ações fora de enums.ValidActionTypes.
+	ErrInvalidActions = errors.New("ações inválidas: use GET, POST, PUT, PATCH ou DELETE separados por |")
+	// ErrProtectedRole indica uma tentativa de renomear ou remover o role master.
+	ErrProtectedRole = errors.New("o role master não pode ser renomeado nem removido")
+	// ErrGlobalRole indica uma alteração em roles globais (ou sua atribuição) por quem não tem o role master.
+	ErrGlobalRole = errors.New("apenas o role master administra roles globais")
+	// ErrActionsNotGranted indica a concessão de ações que o próprio usuário não tem no endpoint.
+	ErrActionsNotGranted = errors.New("só é possível conceder ações que você já possui no endpoint")
+	// ErrSecurityContext indica uma requisição sem usuário ou tenant autenticado.
+	ErrSecurityContext = errors.New("usuário ou tenant não encontrado no contexto")
+)
+
+// masterRoleName é o role com acesso à administração de permissões; renomeá-lo ou removê-lo trancaria a API.
+const masterRoleName = "master"
+
+// actionsOrder define a ordem canônica das ações gravadas nas políticas.
+var actionsOrder = []enums.ActionType{enums.GET, enums.POST, enums.PUT, enums.PATCH, enums.DELETE}
+
+type SecurityServiceInterface interface {
+	ListRoles(c *gin.Context) ([]models.Role, error)
+	GetRole(c *gin.Context, id uint) (*models.Role, error)
+	CreateRole(c *gin.Context, input models.RoleInput) (*models.Role, error)
+	RenameRole(c *gin.Context, id uint, input models.RoleInput) (*models.Role, error)
+	DeleteRole(c *gin.Context, id uint) error
+
+	ListEndpoints(c *gin.Context) ([]models.Endpoint, error)
+	GetEndpoint(c *gin.Context, id uint) (*models.Endpoint, error)
+	CreateEndpoint(c *gin.Context, input models.EndpointInput) (*models.Endpoint, error)
+	RenameEndpoint(c *gin.Context, id uint, input models.EndpointInput) (*models.Endpoint, error)
+	DeleteEndpoint(c *gin.Context, id uint) error
+
+	ListRolePolicies(c *gin.Context, roleID uint) ([]models.PolicyRole, error)
+	CreateRolePolicy(c *gin.Context, roleID uint, input models.PolicyInput) (*models.PolicyRole, error)
+	UpdateRolePolicy(c *gin.Context, roleID, endpointID uint, input models.PolicyActionsInput) (*models.PolicyRole, error)
+	DeleteRolePolicy(c *gin.Context, roleID, endpointID uint) error
+
+	ListUserPolicies(c *gin.Context, userID uuid.UUID) ([]models.PolicyUser, error)
+	CreateUserPolicy(c *gin.Context, userID uuid.UUID, input models.PolicyInput) (*models.PolicyUser, error)
+	UpdateUserPolicy(c *gin.Context, userID uuid.UUID, endpointID uint, input models.PolicyActionsInput) (*models.PolicyUser, error)
+	DeleteUserPolicy(c *gin.Context, userID uuid.UUID, endpointID uint) error
+
+	ListUserRoles(c *gin.Context, userID uuid.UUID) ([]models.Role, error)
+	AddUserRole(c *gin.Context, userID uuid.UUID, roleID uint) error
+	RemoveUserRole(c *gin.Context, userID uuid.UUID, roleID uint) error
+}
+
+// SecurityService administra roles, endpoints e políticas. Alterações de uma política ou atribuição de role são aplicadas
+// no Casbin de forma incremental; as de roles e endpoints, que atingem várias políticas, recarregam todas.
+// Cada tenant vê os roles globais e os próprios, e administra apenas os próprios; roles globais são do role master.
+// Roles e políticas de usuários são administrados apenas para os usuários do tenant da requisição.
+// Quem não tem o role master só concede ações que já possui. Os roles do usuário ficam gravados na sessão no login;
+// por isso, alterar os roles de um usuário encerra suas sessões.
+type SecurityService struct {
+	Repo              repositories.SecurityRepository
+	CasbinService     CasbinServiceInterface
+	TokenRedisService TokenRedisServiceInterface
+}
+
+func NewSecurityService(repo repositories.SecurityRepository, casbinService CasbinServiceInterface, tokenRedisService TokenRedisServiceInterface) *SecurityService {
+	return &SecurityService{
+		Repo:              repo,
+		CasbinService:     casbinService,
+		TokenRedisService: tokenRedisService,
+	}
+}
+
+func (s *SecurityService) ListRoles(c *gin.Context) ([]models.Role, error) {
+	tenantID, err := requestTenantID(c)
+	if err != nil {
+		return nil, err
+	}
+	return s.Repo.FindRoles(c, tenantID)
+}
+
+// GetRole busca um role global ou do tenant da requisição; roles de outros tenants não são encontrados.
+func (s *SecurityService) GetRole(c *gin.Context, id uint) (*models.Role, error) {
+	tenantID, err := requestTenantID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := s.Repo.FindRoleByID(c, id)
+	if err != nil {
+		return nil, securityRepositoryError(err, ErrRoleNotFound)
+	}
+	if role.TenantID != nil && *role.TenantID != tenantID {
+		return nil, ErrRoleNotFound
+	}
+	return role, nil
+}
+
+// CreateRole cria um role do tenant da requisição ou, com input.Global, um role global (apenas o role master).
+func (s *SecurityService) CreateRole(c *gin.Context, input models.RoleInput) (*models.Role, error) {
+	tenantID, err := requestTenantID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	role := &models.Role{Name: strings.TrimSpace(input.Name), TenantID: &tenantID}
+	if input.Global {
+		if err := s.checkGlobalAdmin(c); err != nil {
+			return nil, err
+		}
+		role.TenantID = nil
+	}
+
+	if err := s.Repo.CreateRole(c, role); err != nil {
+		return nil, securityRepositoryError(err, ErrRoleNotFound)
+	}
+	logging.InfoLogger.Printf("Role %d (%s) criado no domínio %s", role.ID, role.Name, role.Domain())
+	return role, s.reloadPolicy()
+}
+
+// RenameRole renomeia o role. As políticas do Casbin usam o nome, então são recarregadas.
+func (s *SecurityService) RenameRole(c *gin.Context, id uint, input models.RoleInput) (*models.Role, error) {
+	role, err := s.getManageableRole(c, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkProtectedRole(role); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.Repo.UpdateRoleName(c, id, strings.TrimSpace(input.Name))
+	if err != nil {
+		return nil, securityRepositoryError(err, ErrRoleNotFound)
+	}
+	role.Name = updated.Name
+	logging.InfoLogger.Printf("Role %d renomeado para %s", role.ID, role.Name)
+	return role, s.reloadPolicy()
+}
+
+// DeleteRole remove o role e suas políticas. Roles atribuídos a usuários não são removidos.
+func (s *SecurityService) DeleteRole(c *gin.Context, id uint) error {
+	role, err := s.getManageableRole(c, id)
+	if err != nil {
+		return err
+	}
+	if err := checkProtectedRole(role); err != nil {
+		return err
+	}
+
+	if err := s.Repo.DeleteRole(c, id); err != nil {
+		return securityRepositoryError(err, ErrRoleNotFound)
+	}
+	logging.InfoLogger.Printf("Role %d removido", id)
+	return s.reloadPolicy()
+}
+
+func (s *SecurityService) ListEndpoints(c *gin.Context) ([]models.Endpoint, error) {
+	return s.Repo.FindEndpoints(c)
+}
+
+func (s *SecurityService) GetEndpoint(c *gin.Context, id uint) (*models.Endpoint, error) {
+	endpoint, err := s.Repo.FindEndpointByID(c, id)
+	if err != nil {
+		return nil, securityRepositoryError(err, ErrEndpointNotFound)
+	}
+	return endpoint, nil
+}
+
+func (s *SecurityService) CreateEndpoint(c *gin.Context, input models.EndpointInput) (*models.Endpoint, error) {
+	endpoint := &models.Endpoint{Name: strings.TrimSpace(input.Name)}
+	if err := s.Repo.CreateEndpoint(c, endpoint); err != nil {
+		return nil, securityRepositoryError(err, ErrEndpointNotFound)
+	}
+	logging.InfoLogger.Printf("Endpoint %d (%s) criado", endpoint.ID, endpoint.Name)
+	return endpoint, s.reloadPolicy()
+}
+
+func (s *SecurityService) RenameEndpoint(c *gin.Context, id uint, input models.EndpointInput) (*models.Endpoint, error) {
+	endpoint, err := s.Repo.UpdateEndpointName(c, id, strings.TrimSpace(input.Name))
+	if err != nil {
+		return nil, securityRepositoryError(err, ErrEndpointNotFound)
+	}
+	logging.InfoLogger.Printf("Endpoint %d renomeado para %s", endpoint.ID, endpoint.Name)
+	return endpoint, s.reloadPolicy()
+}
+
+// DeleteEndpoint remove o endpoint. Endpoints usados em políticas não são removidos.
+func (s *SecurityService) DeleteEndpoint(c *gin.Context, id uint) error {
+	if err := s.Repo.DeleteEndpoint(c, id); err != nil {
+		return securityRepositoryError(err, ErrEndpointNotFound)
+	}
+	logging.InfoLogger.Printf("Endpoint %d removido", id)
+	return s.reloadPolicy()
+}
+
+func (s *SecurityService) ListRolePolicies(c *gin.Context, roleID uint) ([]models.PolicyRole, error) {
+	if _, err := s.GetRole(c, roleID); err != nil {
+		return nil, err
+	}
+	return s.Repo.FindRolePolicies(c, roleID)
+}
+
+func (s *SecurityService) CreateRolePolicy(c *gin.Context, roleID uint, input models.PolicyInput) (*models.PolicyRole, error) {
+	actions, err := normalizeActions(input.Actions)
+	if err != nil {
+		return nil, err
+	}
+	role, err := s.getManageableRole(c, roleID)
+	if err != nil {
+		return nil, err
+	}
+	endpoint, err := s.GetEndpoint(c, input.EndpointID)
+	if err != nil {
+		return nil, err
+	}
+	condition, err := normalizeCondition(input.Condition, endpoint.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkGrantable(c, endpoint, actions); err != nil {
+		return nil, err
+	}
+
+	policy := &models.PolicyRole{RoleID: roleID, EndpointID: endpoint.ID, Actions: actions, Condition: condition}
+	if err := s.Repo.CreateRolePolicy(c, policy); err != nil {
+		return nil, securityRepositoryError(err, ErrPolicyNotFound)
+	}
+	policy.Endpoint = endpoint
+	logging.InfoLogger.Printf("Política do role %s (%s) para %s criada: %s %s", role.Name, role.Domain(), endpoint.Name, actions, condition)
+	return policy, s.setPolicy(role.Name, role.Domain(), endpoint.Name, actions, condition)
+}
+
+func (s *SecurityService) UpdateRolePolicy(c *gin.Context, roleID, endpointID uint, input models.PolicyActionsInput) (*models.PolicyRole, error) {
+	actions, err := normalizeActions(input.Actions)
+	if err != nil {
+		return nil, err
+	}
+
+	role, endpoint, err := s.getRolePolicyTarget(c, roleID, endpointID)
+	if err != nil {
+		return nil, err
+	}
+	condition, err := normalizeCondition(input.Condition, endpoint.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkGrantable(c, endpoint, actions); err != nil {
+		return nil, err
+	}
+
+	if err := s.Repo.UpdateRolePolicy(c, roleID, endpointID, actions, condition); err != nil {
+		return nil, securityRepositoryError(err, ErrPolicyNotFound)
+	}
+	logging.InfoLogger.Printf("Política do role %s (%s) para %s alterada: %s %s", role.Name, role.Domain(), endpoint.Name, actions, condition)
+	policy := &models.PolicyRole{RoleID: roleID, EndpointID: endpointID, Actions: actions, Condition: condition}
+	return policy, s.setPolicy(role.Name, role.Domain(), endpoint.Name, actions, condition)
+}
+
+func (s *SecurityService) DeleteRolePolicy(c *gin.Context, roleID, endpointID uint) error {
+	role, endpoint, err := s.getRolePolicyTarget(c, roleID, endpointID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.Repo.DeleteRolePolicy(c, roleID, endpointID); err != nil {
+		return securityRepositoryError(err, ErrPolicyNotFound)
+	}
+	logging.InfoLogger.Printf("Política do role %s (%s) para %s removida", role.Name, role.Domain(), endpoint.Name)
+	return s.removePolicy(role.Name, role.Domain(), endpoint.Name)
+}
+
+func (s *SecurityService) ListUserPolicies(c *gin.Context, userID uuid.UUID) ([]models.PolicyUser, error) {
+	if _, err := s.getTenantUser(c, userID); err != nil {
+		return nil, err
+	}
+	return s.Repo.FindUserPolicies(c, userID)
+}
+
+// CreateUserPolicy concede ao usuário, que pertence ao tenant da requisição, ações sobre o endpoint nesse tenant.
+func (s *SecurityService) CreateUserPolicy(c *gin.Context, userID uuid.UUID, input models.PolicyInput) (*models.PolicyUser, error) {
+	actions, err := normalizeActions(input.Actions)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := s.getTenantUser(c, userID)
+	if err != nil {
+		return nil, err
+	}
+	endpoint, err := s.GetEndpoint(c, input.EndpointID)
+	if err != nil {
+		return nil, err
+	}
+	condition, err := normalizeCondition(input.Condition, endpoint.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkGrantable(c, endpoint, actions); err != nil {
+		return nil, err
+	}
+
+	policy := &models.PolicyUser{UserID: userID, EndpointID: endpoint.ID, Actions: actions, Condition: condition}
+	if err := s.Repo.CreateUserPolicy(c, policy); err != nil {
+		return nil, securityRepositoryError(err, ErrPolicyNotFound)
+	}
+	policy.Endpoint = endpoint
+	logging.InfoLogger.Printf("Política do usuário %s para %s criada: %s %s", userID, endpoint.Name, actions, condition)
+	return policy, s.setPolicy(userID.String(), tenantID.String(), endpoint.Name, actions, condition)
+}
+
+func (s *SecurityService) UpdateUserPolicy(c *gin.Context, userID uuid.UUID, endpointID uint, input models.PolicyActionsInput) (*models.PolicyUser, error) {
+	actions, err := normalizeActions(input.Actions)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := s.getTenantUser(c, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := s.getPolicyEndpoint(c, endpointID)
+	if err != nil {
+		return nil, err
+	}
+	condition, err := normalizeCondition(input.Condition, endpoint.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkGrantable(c, endpoint, actions); err != nil {
+		return nil, err
+	}
+
+	if err := s.Repo.UpdateUserPolicy(c, userID, endpointID, actions, condition); err != nil {
+		return nil, securityRepositoryError(err, ErrPolicyNotFound)
+	}
+	logging.InfoLogger.Printf("Política do usuário %s para %s alterada: %s %s", userID, endpoint.Name, actions, condition)
+	policy := &models.PolicyUser{UserID: userID, EndpointID: endpointID, Actions: actions, Condition: condition}
+	return policy, s.setPolicy(userID.String(), tenantID.String(), endpoint.Name, actions, condition)
+}
+
+func (s *SecurityService) DeleteUserPolicy(c *gin.Context, userID uuid.UUID, endpointID uint) error {
+	tenantID, err := s.getTenantUser(c, userID)
+	if err != nil {
+		return err
+	}
+	endpoint, err := s.getPolicyEndpoint(c, endpointID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.Repo.DeleteUserPolicy(c, userID, endpointID); err != nil {
+		return securityRepositoryError(err, ErrPolicyNotFound)
+	}
+	logging.InfoLogger.Printf("Política do usuário %s para %s removida", userID, endpoint.Name)
+	return s.removePolicy(userID.String(), tenantID.String(), endpoint.Name)
+}
+
+func (s *SecurityService) ListUserRoles(c *gin.Context, userID uuid.UUID) ([]models.Role, error) {
+	if _, err := s.getTenantUser(c, userID); err != nil {
+		return nil, err
+	}
+	return s.Repo.FindUserRoles(c, userID)
+}
+
+// AddUserRole atribui ao usuário um role do tenant (ou, pelo role master, um role global) e encerra as sessões dele,
+// que carregam os roles do login.
+func (s *SecurityService) AddUserRole(c *gin.Context, userID uuid.UUID, roleID uint) error {
+	role, err := s.getManageableRole(c, roleID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.getTenantUser(c, userID); err != nil {
+		return err
+	}
+
+	if err := s.Repo.AddUserRole(c, userID, role.ID); err != nil {
+		return securityRepositoryError(err, ErrRoleNotFound)
+	}
+	logging.InfoLogger.Printf("Role %s (%s) atribuído ao usuário %s", role.Name, role.Domain(), userID)
+	if err := s.CasbinService.AddRoleForUser(userID.String(), role.Name, role.Domain()); err != nil {
+		logging.ErrorLogger.Printf("Role atribuído, mas falha ao aplicá-lo no Casbin: %v", err)
+		return err
+	}
+	return s.refreshUser(userID)
+}
+
+// RemoveUserRole retira o role do usuário e encerra as sessões dele, que ainda carregam o role.
+func (s *SecurityService) RemoveUserRole(c *gin.Context, userID uuid.UUID, roleID uint) error {
+	role, err := s.getManageableRole(c, roleID)
+	if errors.Is(err, ErrRoleNotFound) {
+		return ErrPolicyNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := s.getTenantUser(c, userID); err != nil {
+		return err
+	}
+
+	if err := s.Repo.RemoveUserRole(c, userID, roleID); err != nil {
+		return securityRepositoryError(err, ErrPolicyNotFound)
+	}
+	logging.InfoLogger.Printf("Role %s (%s) retirado do usuário %s", role.Name, role.Domain(), userID)
+	if err := s.CasbinService.DeleteRoleForUser(userID.String(), role.Name, role.Domain()); err != nil {
+		logging.ErrorLogger.Printf("Role retirado, mas falha ao aplicar a remoção no Casbin: %v", err)
+		return err
+	}
+	return s.refreshUser(userID)
+}
+
+// getTenantUser confirma que o usuário pertence ao tenant da requisição e devolve o tenant; usuários de outros tenants
+// não são encontrados.
+func (s *SecurityService) getTenantUser(c *gin.Context, userID uuid.UUID) (uuid.UUID, error) {
+	tenantID, err := requestTenantID(c)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if _, err := s.Repo.FindTenantUser(c, tenantID, userID); err != nil {
+		return uuid.Nil, securityRepositoryError(err, ErrUserNotFound)
+	}
+	return tenantID, nil
+}
+
+// getManageableRole busca um role que a requisição pode alterar ou atribuir: os do próprio tenant e, para o role master,
+// os globais.
+func (s *SecurityService) getManageableRole(c *gin.Context, id uint) (*models.Role, error) {
+	role, err := s.GetRole(c, id)
+	if err != nil {
+		return nil, err
+	}
+	if role.TenantID == nil {
+		if err := s.checkGlobalAdmin(c); err != nil {
+			return nil, err
+		}
+	}
+	return role, nil
+}
+
+func checkProtectedRole(role *models.Role) error {
+	if role.TenantID == nil && role.Name == masterRoleName {
+		return ErrProtectedRole
+	}
+	return nil
+}
+
+// getRolePolicyTarget busca o role e o endpoint de uma política existente; sem eles não há política.
+func (s *SecurityService) getRolePolicyTarget(c *gin.Context, roleID, endpointID uint) (*models.Role, *models.Endpoint, error) {
+	role, err := s.getManageableRole(c, roleID)
+	if errors.Is(err, ErrRoleNotFound) {
+		return nil, nil, ErrPolicyNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	endpoint, err := s.getPolicyEndpoint(c, endpointID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return role, endpoint, nil
+}
+
+func (s *SecurityService) getPolicyEndpoint(c *gin.Context, endpointID uint) (*models.Endpoint, error) {
+	endpoint, err := s.Repo.FindEndpointByID(c, endpointID)
+	if err != nil {
+		return nil, securityRepositoryError(err, ErrPolicyNotFound)
+	}
+	return endpoint, nil
+}
+
+// isGlobalAdmin indica se o usuário da requisição tem o role master global. Os roles são lidos do banco, e não da
+// sessão, pois um tenant pode ter um role próprio chamado master.
+func (s *SecurityService) isGlobalAdmin(c *gin.Context) (bool, error) {
+	user, err := requestUser(c)
+	if err != nil {
+		return false, err
+	}
+	userID, err := uuid.Parse(user.ID)
+	if err != nil {
+		return false, ErrSecurityContext
+	}
+
+	roles, err := s.Repo.FindUserRoles(c, userID)
+	if err != nil {
+		return false, err
+	}
+	for _, role := range roles {
+		if role.TenantID == nil && role.Name == masterRoleName {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *SecurityService) checkGlobalAdmin(c *gin.Context) error {
+	ok, err := s.isGlobalAdmin(c)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrGlobalRole
+	}
+	return nil
+}
+
+// checkGrantable impede que alguém sem o role master conceda ações que não possui no endpoint, o que permitiria
+// ampliar as próprias permissões por meio de um role ou política nova. Vale apenas o que o usuário tem sem condição,
+// pois o endpoint é verificado pelo padrão da rota (ex.: /api/v1/users/:id), que não satisfaz condições.
+func (s *SecurityService) checkGrantable(c *gin.Context, endpoint *models.Endpoint, actions string) error {
+	ok, err := s.isGlobalAdmin(c)
+	if err != nil || ok {
+		return err
+	}
+
+	user, err := requestUser(c)
+	if err != nil {
+		return err
+	}
+	for _, action := range strings.Split(actions, "|") {
+		if !s.CasbinService.CheckPermission(user, user.TenantID, endpoint.Name, action) {
+			return ErrActionsNotGranted
+		}
+	}
+	return nil
+}
+
+func (s *SecurityService) refreshUser(userID uuid.UUID) error {
+	if err := s.TokenRedisService.RevokeAllSessions(userID.String()); err != nil {
+		logging.ErrorLogger.Printf("Falha ao revogar as sessões do usuário %s após alterar seus roles: %v", userID, err)
+		return err
+	}
+	return nil
+}
+
+// reloadPolicy recarrega as políticas do Casbin. A alteração já está gravada; o erro indica que ela ainda não vale.
+func (s *SecurityService) reloadPolicy() error {
+	if err := s.CasbinService.LoadPolicy(); err != nil {
+		logging.ErrorLogger.Printf("Alteração de permissões gravada, mas falha ao recarregar as políticas: %v", err)
+		return err
+	}
+	return nil
+}
+
+// setPolicy aplica no Casbin as ações e a condição gravadas para sub em obj no domínio dom. A alteração já está
+// gravada; o erro indica que ela ainda não vale.
+func (s *SecurityService) setPolicy(sub, dom, obj, actions, condition string) error {
+	if err := s.CasbinService.SetPolicy(sub, dom, obj, actions, condition); err != nil {
+		logging.ErrorLogger.Printf("Política gravada, mas falha ao aplicá-la no Casbin: %v", err)
+		return err
+	}
+	return nil
+}
+
+// removePolicy retira do Casbin a política de sub em obj no domínio dom já removida do banco.
+func (s *SecurityService) removePolicy(sub, dom, obj string) error {
+	if err := s.CasbinService.RemovePolicy(sub, dom, obj); err != nil {
+		logging.ErrorLogger.Printf("Política removida, mas falha ao retirá-la do Casbin: %v", err)
+		return err
+	}
+	return nil
+}
+
+// requestUser devolve o usuário autenticado da requisição.
+func requestUser(c *gin.Context) (*models.UserRedis, error) {
+	value, exists := c.Get(string(contextkeys.UserDataKey))
+	if !exists {
+		return nil, ErrSecurityContext
+	}
+	user, ok := value.(*models.UserRedis)
+	if !ok {
+		return nil, ErrSecurityContext
+	}
+	return user, nil
+}
+
+// requestTenantID devolve o tenant da requisição, que é o domínio das permissões.
+func requestTenantID(c *gin.Context) (uuid.UUID, error) {
+	value, exists := c.Get(string(contextkeys.TenantIDKey))
+	if !exists {
+		return uuid.Nil, ErrSecurityContext
+	}
+	switch v := value.(type) {
+	case uuid.UUID:
+		return v, nil
+	case string:
+		tenantID, err := uuid.Parse(v)
+		if err != nil {
+			return uuid.Nil, ErrSecurityContext
+		}
+		return tenantID, nil
+	default:
+		return uuid.Nil, ErrSecurityContext
+	}
+}
+
+// normalizeActions valida as ações contra enums.ValidActionTypes e as devolve sem repetições, na ordem canônica.
+func normalizeActions(actions string) (string, error) {
+	selected := make(map[enums.ActionType]bool)
+	for _, part := range strings.Split(actions, "|") {
+		action := enums.ActionType(strings.ToUpper(strings.TrimSpace(part)))
+		if !action.IsValid() {
+			return "", ErrInvalidActions
+		}
+		selected[action] = true
+	}
+
+	normalized := make([]string, 0, len(selected))
+	for _, action := range actionsOrder {
+		if selected[action] {
+			normalized = append(normalized, string(action))
+		}
+	}
+	return strings.Join(normalized, "|"), nil
+}
+
+// securityRepositoryError converte os erros do SecurityRepository nos erros do serviço.
+func securityRepositoryError(err error, notFound error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return notFound
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return ErrSecurityConflict
+	case errors.Is(err, gorm.ErrForeignKeyViolated):
+		return ErrSecurityInUse
+	default:
+		return err
+	}
+}
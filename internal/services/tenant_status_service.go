@@ -0,0 +1,83 @@
+// internal/services/tenant_status_service.go
+
+package services
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/logging"
+	"github.com/jeancarlosdanese/go-base-api/internal/repositories"
+	"github.com/redis/go-redis/v9"
+)
+
+// TenantStatusServiceInterface aplica a suspensão de tenants às sessões e às requisições autenticadas.
+type TenantStatusServiceInterface interface {
+	IsSuspended(tenantID string) (bool, error)
+	Suspend(c *gin.Context, tenantID uuid.UUID) error
+	MarkSuspended(tenantID uuid.UUID) error
+	Reactivate(tenantID uuid.UUID) error
+}
+
+// TenantStatusService mantém no Redis uma marca para cada tenant suspenso, consultada a cada requisição
+// autenticada, e revoga as sessões dos usuários do tenant na suspensão. O status no banco continua sendo a
+// referência para login e API Keys; a marca evita uma consulta ao banco por requisição.
+type TenantStatusService struct {
+	UserRepo          repositories.UserRepository
+	RedisService      RedisServiceInterface
+	TokenRedisService TokenRedisServiceInterface
+}
+
+func NewTenantStatusService(userRepo repositories.UserRepository, redisService RedisServiceInterface, tokenRedisService TokenRedisServiceInterface) *TenantStatusService {
+	return &TenantStatusService{
+		UserRepo:          userRepo,
+		RedisService:      redisService,
+		TokenRedisService: tokenRedisService,
+	}
+}
+
+// IsSuspended informa se o tenant está marcado como suspenso.
+func (s *TenantStatusService) IsSuspended(tenantID string) (bool, error) {
+	_, err := s.RedisService.Get(tenantSuspendedKey(tenantID))
+	if err == redis.Nil {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Suspend marca o tenant como suspenso e encerra todas as sessões dos seus usuários.
+// A marca é gravada antes da revogação, para que nenhuma sessão volte a ser aceita no meio do processo.
+func (s *TenantStatusService) Suspend(c *gin.Context, tenantID uuid.UUID) error {
+	if err := s.MarkSuspended(tenantID); err != nil {
+		return err
+	}
+
+	userIDs, err := s.UserRepo.FindUserIDsByTenant(c, tenantID)
+	if err != nil {
+		return err
+	}
+	for _, userID := range userIDs {
+		if err := s.TokenRedisService.RevokeAllSessions(userID.String()); err != nil {
+			return err
+		}
+	}
+
+	logging.InfoLogger.Printf("Tenant %s suspenso: sessões de %d usuário(s) revogadas", tenantID, len(userIDs))
+	return nil
+}
+
+// MarkSuspended grava apenas a marca de suspensão, sem expiração, sem revogar sessões.
+func (s *TenantStatusService) MarkSuspended(tenantID uuid.UUID) error {
+	return s.RedisService.Set(tenantSuspendedKey(tenantID.String()), 1, 0)
+}
+
+// Reactivate remove a marca de suspensão. As sessões revogadas não voltam; os usuários precisam logar novamente.
+func (s *TenantStatusService) Reactivate(tenantID uuid.UUID) error {
+	return s.RedisService.Delete(tenantSuspendedKey(tenantID.String()))
+}
+
+func tenantSuspendedKey(tenantID string) string {
+	return "tenant_suspended:" + tenantID
+}
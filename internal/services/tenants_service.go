@@ -3,57 +3,172 @@
 package services
 
 import (
-	"fmt"
+	"errors"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/enums"
 	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
 	"github.com/jeancarlosdanese/go-base-api/internal/logging"
 	"github.com/jeancarlosdanese/go-base-api/internal/repositories"
-	"github.com/jeancarlosdanese/go-base-api/internal/utils"
+	"gorm.io/gorm"
 )
 
+// ErrTenantHasDependents indica um tenant que ainda tem usuários ou roles, mesmo removidos logicamente.
+var ErrTenantHasDependents = errors.New("o tenant ainda tem usuários ou roles; remova-os definitivamente antes")
+
 // TenantServiceInterface define as operações adicionais do TenantService além das operações CRUD básicas.
 type TenantServiceInterface interface {
 	BaseServiceInterface[models.Tenant]
 	CreateTenantWithApiKey(c *gin.Context, entity *models.Tenant) (*models.Tenant, error)
-	ApiKeyAuthenticate(apiKey, origin string) (*models.Tenant, error)
+	Search(c *gin.Context, term string, limit int) ([]models.Tenant, error)
+	GetTrash(c *gin.Context, query models.ListQuery) (*models.Page[models.Tenant], error)
+	Restore(c *gin.Context, id uuid.UUID) (*models.Tenant, error)
+	Purge(c *gin.Context, id uuid.UUID) error
 }
 type TenantService struct {
 	*BaseService[models.Tenant, repositories.TenantRepository]
+	ApiKeyService       ApiKeyServiceInterface
+	TenantStatusService TenantStatusServiceInterface
 }
 
-func NewTenantService(repo repositories.TenantRepository) *TenantService {
+func NewTenantService(repo repositories.TenantRepository, apiKeyService ApiKeyServiceInterface, tenantStatusService TenantStatusServiceInterface) *TenantService {
 	baseService := NewBaseService[models.Tenant, repositories.TenantRepository](repo)
-	return &TenantService{BaseService: baseService}
+	return &TenantService{BaseService: baseService, ApiKeyService: apiKeyService, TenantStatusService: tenantStatusService}
 }
 
-// CreateTenantWithApiKey cria um tenant gerando e adicionando uma ApiKey para o tenant
+// CreateTenantWithApiKey cria um tenant e a sua primeira ApiKey. A chave em claro volta apenas em tenant.ApiKey,
+// nesta resposta; novas chaves são geridas em /tenants/:id/api-keys.
 func (s *TenantService) CreateTenantWithApiKey(c *gin.Context, tenant *models.Tenant) (*models.Tenant, error) {
-	// Gera uma ApiKey para a o Tenant
-	apikey, err := utils.GenerateApiKey(64)
+	tenantCreated, err := s.Repo.Create(c, tenant)
 	if err != nil {
 		return nil, err
 	}
 
-	fmt.Printf("API_KEY: %v", apikey)
+	apiKey, err := s.ApiKeyService.Create(c, tenantCreated.ID, models.ApiKeyCreate{Name: "default"})
+	if err != nil {
+		logging.ErrorLogger.Printf("Tenant %s criado, mas falha ao gerar a API Key: %v", tenantCreated.ID, err)
+		return nil, err
+	}
+	tenantCreated.ApiKey = &apiKey.Key
 
-	tenant.ApiKey = &apikey
+	return tenantCreated, nil
+}
 
-	tenantCreated, err := s.Repo.Create(c, tenant)
+// Search busca tenants por nome, CPF/CNPJ, cidade ou e-mail, tolerando acentos e erros de digitação.
+func (s *TenantService) Search(c *gin.Context, term string, limit int) ([]models.Tenant, error) {
+	return s.Repo.SearchTenants(c, term, limit)
+}
+
+// Update atualiza o tenant e remove as suas API Keys do cache, pois nome, status e origens permitidas fazem parte da validação.
+// Com status INATIVO, o tenant é suspenso e as sessões dos seus usuários são revogadas.
+func (s *TenantService) Update(c *gin.Context, id uuid.UUID, tenant *models.Tenant) (*models.Tenant, error) {
+	updated, err := s.Repo.Update(c, id, tenant)
 	if err != nil {
 		return nil, err
 	}
+	s.invalidateApiKeyCache(id)
+	if err := s.applyStatus(c, id, updated.Status); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
 
-	return tenantCreated, nil
+// UpdatePartial atualiza parcialmente o tenant e remove as suas API Keys do cache. Se o status for alterado,
+// a suspensão ou reativação é aplicada como em Update.
+func (s *TenantService) UpdatePartial(c *gin.Context, id uuid.UUID, updateData map[string]interface{}) (*models.Tenant, error) {
+	updated, err := s.Repo.UpdatePartial(c, id, updateData)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateApiKeyCache(id)
+	if _, ok := updateData["status"]; ok {
+		if err := s.applyStatus(c, id, updated.Status); err != nil {
+			return nil, err
+		}
+	}
+	return updated, nil
 }
 
-// ApiKeyAuthenticate verifica a apiKey do Tenant.
-func (s *TenantService) ApiKeyAuthenticate(apiKey, origin string) (*models.Tenant, error) {
-	user, err := s.Repo.FindByApiKey(apiKey, origin)
+// Delete remove o tenant, as suas API Keys do cache e as sessões dos seus usuários.
+func (s *TenantService) Delete(c *gin.Context, id uuid.UUID) error {
+	if err := s.Repo.Delete(c, id); err != nil {
+		return err
+	}
+	s.invalidateApiKeyCache(id)
+	if err := s.TenantStatusService.Suspend(c, id); err != nil {
+		logging.ErrorLogger.Printf("Falha ao revogar as sessões do tenant removido %s: %v", id, err)
+		return err
+	}
+	return nil
+}
+
+// GetTrash lista os tenants removidos logicamente.
+func (s *TenantService) GetTrash(c *gin.Context, query models.ListQuery) (*models.Page[models.Tenant], error) {
+	return s.Repo.FindDeletedTenants(c, query)
+}
+
+// Restore restaura o tenant removido. A suspensão aplicada na remoção é desfeita se o tenant estiver ATIVO;
+// os usuários precisam logar novamente.
+func (s *TenantService) Restore(c *gin.Context, id uuid.UUID) (*models.Tenant, error) {
+	restored, err := s.Repo.RestoreTenant(c, id)
 	if err != nil {
-		logging.InfoLogger.Printf("Erro ao buscar Tenant por apiKey: %v", err)
+		return nil, softDeleteError(err)
+	}
+	if err := s.applyStatus(c, id, restored.Status); err != nil {
 		return nil, err
 	}
+	return restored, nil
+}
+
+// Purge remove definitivamente o tenant e as suas API Keys. Os usuários e roles do tenant precisam ser removidos antes.
+// A marca de suspensão gravada na remoção lógica, que não expira, também é removida.
+func (s *TenantService) Purge(c *gin.Context, id uuid.UUID) error {
+	if err := s.Repo.PurgeTenant(c, id); err != nil {
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return ErrTenantHasDependents
+		}
+		return softDeleteError(err)
+	}
+	s.invalidateApiKeyCache(id)
+	if err := s.TenantStatusService.Reactivate(id); err != nil {
+		logging.ErrorLogger.Printf("Tenant %s removido, mas falha ao remover a marca de suspensão: %v", id, err)
+		return err
+	}
+	return nil
+}
+
+// SyncSuspendedTenants grava a marca de suspensão dos tenants inativos no banco. Executado na inicialização,
+// para que a marca exista mesmo para tenants inativados antes dela ou após a perda dos dados do Redis.
+func (s *TenantService) SyncSuspendedTenants() error {
+	ids, err := s.Repo.FindTenantIDsByStatus(enums.Inativo)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := s.TenantStatusService.MarkSuspended(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyStatus suspende o tenant INATIVO ou remove a suspensão do tenant ATIVO.
+func (s *TenantService) applyStatus(c *gin.Context, id uuid.UUID, status enums.StatusType) error {
+	var err error
+	if status == enums.Inativo {
+		err = s.TenantStatusService.Suspend(c, id)
+	} else {
+		err = s.TenantStatusService.Reactivate(id)
+	}
+	if err != nil {
+		logging.ErrorLogger.Printf("Falha ao aplicar o status %s ao tenant %s: %v", status, id, err)
+	}
+	return err
+}
 
-	return user, nil
+func (s *TenantService) invalidateApiKeyCache(id uuid.UUID) {
+	if err := s.ApiKeyService.InvalidateTenantCache(id); err != nil {
+		logging.ErrorLogger.Printf("Falha ao remover as API Keys do tenant %s do cache: %v", id, err)
+	}
 }
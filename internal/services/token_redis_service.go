@@ -4,16 +4,32 @@ package services
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/logging"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	// ErrRefreshTokenRevoked indica que a família do refresh token não existe mais (logout, revogação ou expiração).
+	ErrRefreshTokenRevoked = errors.New("refresh token revogado")
+	// ErrRefreshTokenReused indica que um refresh token já consumido foi apresentado novamente; a família inteira é revogada.
+	ErrRefreshTokenReused = errors.New("refresh token reutilizado")
 )
 
 type TokenRedisServiceInterface interface {
-	SaveUserRedis(user *models.User, token, refreshToken string, accessDuration time.Duration) error
+	SaveUserRedis(user *models.User, session *models.UserSession, accessDuration, refreshDuration time.Duration) error
 	ValidateRefreshToken(refreshToken string) (*models.UserRedis, error)
+	ConsumeRefreshToken(userID, familyID, refreshToken string) (*models.UserRedis, error)
 	GetUserRedisFromToken(token string) (*models.UserRedis, error)
+	RevokeSession(userID, sessionID string) error
+	RevokeAllSessions(userID string) error
+	RevokeOtherSessions(userID, keepSessionID string) error
+	ListSessions(userID string) ([]models.UserSession, error)
 }
 
 type TokenRedisService struct {
@@ -26,16 +42,43 @@ func NewTokenRedisService(redisService RedisServiceInterface) *TokenRedisService
 	}
 }
 
-func (s *TokenRedisService) SaveUserRedis(user *models.User, token, refreshToken string, accessDuration time.Duration) error {
+// SaveUserRedis grava os tokens da sessão no Redis. Se a sessão já existir (refresh), os tokens anteriores são removidos.
+func (s *TokenRedisService) SaveUserRedis(user *models.User, session *models.UserSession, accessDuration, refreshDuration time.Duration) error {
+	now := time.Now()
+	session.UserID = user.ID.String()
+	session.CreatedAt = now
+	session.RefreshedAt = now
+	session.ExpiresAt = now.Add(refreshDuration)
+
+	previous, err := s.getSession(session.UserID, session.ID)
+	if err != nil {
+		return err
+	}
+	if previous != nil {
+		session.CreatedAt = previous.CreatedAt
+		if err := s.RedisService.Delete("token:"+previous.AccessToken, "refresh_token:"+previous.RefreshToken); err != nil {
+			return err
+		}
+	}
+
 	tokenDataRedis := prepareUserRedis(user)
+	tokenDataRedis.SessionID = session.ID
 	tokenData, err := json.Marshal(tokenDataRedis)
 	if err != nil {
 		return err
 	}
-	if err := s.RedisService.Set("token:"+token, tokenData, accessDuration); err != nil {
+	if err := s.RedisService.Set("token:"+session.AccessToken, tokenData, accessDuration); err != nil {
 		return err
 	}
-	return nil
+	if err := s.RedisService.Set("refresh_token:"+session.RefreshToken, tokenData, refreshDuration); err != nil {
+		return err
+	}
+
+	sessionData, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	return s.RedisService.HSet(userSessionsKey(session.UserID), session.ID, sessionData, refreshDuration)
 }
 
 func (s *TokenRedisService) ValidateRefreshToken(refreshToken string) (*models.UserRedis, error) {
@@ -50,6 +93,46 @@ func (s *TokenRedisService) ValidateRefreshToken(refreshToken string) (*models.U
 	return &tokenDataRedis, nil
 }
 
+// ConsumeRefreshToken consome o refresh token atual da família (sessão), impedindo que seja usado duas vezes.
+// Se o token apresentado não for o atual da família, trata-se de reutilização: a família inteira é revogada.
+func (s *TokenRedisService) ConsumeRefreshToken(userID, familyID, refreshToken string) (*models.UserRedis, error) {
+	session, err := s.getSession(userID, familyID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, ErrRefreshTokenRevoked
+	}
+
+	if session.RefreshToken != refreshToken {
+		return nil, s.revokeReusedFamily(userID, familyID)
+	}
+
+	result, err := s.RedisService.GetDel("refresh_token:" + refreshToken)
+	if err == redis.Nil {
+		// Outra requisição consumiu o mesmo token entre a leitura da sessão e o GETDEL.
+		return nil, s.revokeReusedFamily(userID, familyID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var tokenDataRedis models.UserRedis
+	if err := json.Unmarshal([]byte(result), &tokenDataRedis); err != nil {
+		return nil, err
+	}
+	return &tokenDataRedis, nil
+}
+
+// revokeReusedFamily revoga todos os tokens da família após a detecção de reutilização de um refresh token.
+func (s *TokenRedisService) revokeReusedFamily(userID, familyID string) error {
+	logging.WarnLogger.Printf("Reutilização de refresh token detectada: usuário %s, família %s. Revogando a família.", userID, familyID)
+	if err := s.RevokeSession(userID, familyID); err != nil {
+		logging.ErrorLogger.Printf("Falha ao revogar a família %s do usuário %s: %v", familyID, userID, err)
+		return err
+	}
+	return ErrRefreshTokenReused
+}
+
 func (s *TokenRedisService) GetUserRedisFromToken(token string) (*models.UserRedis, error) {
 	result, err := s.RedisService.Get("token:" + token)
 	if err != nil {
@@ -62,6 +145,114 @@ func (s *TokenRedisService) GetUserRedisFromToken(token string) (*models.UserRed
 	return &tokenDataRedis, nil
 }
 
+// RevokeSession remove o access token, o refresh token e o registro de uma sessão do usuário.
+func (s *TokenRedisService) RevokeSession(userID, sessionID string) error {
+	session, err := s.getSession(userID, sessionID)
+	if err != nil {
+		return err
+	}
+	if session != nil {
+		if err := s.RedisService.Delete("token:"+session.AccessToken, "refresh_token:"+session.RefreshToken); err != nil {
+			return err
+		}
+	}
+	return s.RedisService.HDel(userSessionsKey(userID), sessionID)
+}
+
+// RevokeAllSessions encerra todas as sessões ativas do usuário.
+func (s *TokenRedisService) RevokeAllSessions(userID string) error {
+	sessions, err := s.RedisService.HGetAll(userSessionsKey(userID))
+	if err != nil && err != redis.Nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(sessions)*2+1)
+	for _, data := range sessions {
+		var session models.UserSession
+		if err := json.Unmarshal([]byte(data), &session); err != nil {
+			continue
+		}
+		keys = append(keys, "token:"+session.AccessToken, "refresh_token:"+session.RefreshToken)
+	}
+	keys = append(keys, userSessionsKey(userID))
+
+	return s.RedisService.Delete(keys...)
+}
+
+// RevokeOtherSessions encerra todas as sessões do usuário, exceto a informada (normalmente a sessão atual).
+func (s *TokenRedisService) RevokeOtherSessions(userID, keepSessionID string) error {
+	sessions, err := s.ListSessions(userID)
+	if err != nil {
+		return err
+	}
+
+	for _, session := range sessions {
+		if session.ID == keepSessionID {
+			continue
+		}
+		if err := s.RevokeSession(userID, session.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListSessions retorna as sessões ativas do usuário, ordenadas da mais recente para a mais antiga.
+// Sessões cujo refresh token já expirou são removidas do registro.
+func (s *TokenRedisService) ListSessions(userID string) ([]models.UserSession, error) {
+	data, err := s.RedisService.HGetAll(userSessionsKey(userID))
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	sessions := make([]models.UserSession, 0, len(data))
+	var expired []string
+	for id, value := range data {
+		var session models.UserSession
+		if err := json.Unmarshal([]byte(value), &session); err != nil {
+			logging.WarnLogger.Printf("Sessão %s do usuário %s com formato inválido: %v", id, userID, err)
+			continue
+		}
+		if !session.ExpiresAt.IsZero() && session.ExpiresAt.Before(now) {
+			expired = append(expired, id)
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+
+	if len(expired) > 0 {
+		if err := s.RedisService.HDel(userSessionsKey(userID), expired...); err != nil {
+			logging.WarnLogger.Printf("Falha ao remover sessões expiradas do usuário %s: %v", userID, err)
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].RefreshedAt.After(sessions[j].RefreshedAt)
+	})
+	return sessions, nil
+}
+
+// getSession busca uma sessão do usuário. Retorna nil, nil se a sessão não existir.
+func (s *TokenRedisService) getSession(userID, sessionID string) (*models.UserSession, error) {
+	data, err := s.RedisService.HGet(userSessionsKey(userID), sessionID)
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+	if err == redis.Nil || data == "" {
+		return nil, nil
+	}
+	var session models.UserSession
+	if err := json.Unmarshal([]byte(data), &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func userSessionsKey(userID string) string {
+	return "user_sessions:" + userID
+}
+
 // prepareUserRedis prepares user data to be stored in Redis.
 func prepareUserRedis(user *models.User) models.UserRedis {
 	// Map para evitar duplicatas e coletar todas as permissões
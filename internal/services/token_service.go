@@ -3,103 +3,181 @@
 package services
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
 )
 
+const (
+	tokenUseAccess  = "access"
+	tokenUseRefresh = "refresh"
+)
+
+// ErrWrongTokenUse indica que um refresh token foi usado onde se espera um access token, ou o inverso.
+var ErrWrongTokenUse = errors.New("tipo de token inválido para esta operação")
+
 type TokenServiceInterface interface {
-	CreateTokens(userID uuid.UUID, roles []string, permissions []string) (string, string, error)
-	RefreshTokens(refreshToken string) (uuid.UUID, error)
+	CreateTokens(subject TokenSubject) (string, string, error)
+	RefreshTokens(refreshToken string) (*RefreshClaims, error)
 	ValidateToken(tokenString string) (*jwt.Token, error)
 	GetAccessDuration() time.Duration
+	GetRefreshDuration() time.Duration
+	JWKS() models.JWKS
+}
+
+// TokenSubject reúne os dados do usuário e da sessão que são gravados nos tokens.
+type TokenSubject struct {
+	UserID      uuid.UUID
+	TenantID    uuid.UUID
+	FamilyID    string // ID da sessão; todos os refresh tokens gerados a partir de um login pertencem à mesma família
+	Roles       []string
+	Permissions []string
 }
+
+// RefreshClaims contém as claims extraídas de um refresh token válido.
+type RefreshClaims struct {
+	UserID   uuid.UUID
+	TenantID uuid.UUID
+	FamilyID string
+	TokenID  string
+}
+
+// TokenClaimsConfig define as claims padrão emitidas (iss, aud) e a tolerância de relógio aceita na validação.
+// Issuer e Audience vazios não são emitidos nem exigidos.
+type TokenClaimsConfig struct {
+	Issuer   string
+	Audience string
+	Leeway   time.Duration
+}
+
+// AccessTokenClaims são as claims do access token.
+type AccessTokenClaims struct {
+	jwt.RegisteredClaims
+	TokenUse    string   `json:"token_use"`
+	TenantID    string   `json:"tenant_id"`
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+}
+
+// refreshTokenClaims são as claims do refresh token.
+type refreshTokenClaims struct {
+	jwt.RegisteredClaims
+	TokenUse string `json:"token_use"`
+	TenantID string `json:"tenant_id"`
+	FamilyID string `json:"fam"`
+}
+
 type TokenService struct {
-	SecretKey       []byte
+	Signer          TokenSigner
 	AccessDuration  time.Duration
 	RefreshDuration time.Duration
+	Claims          TokenClaimsConfig
 }
 
-func NewTokenService(secretKey string, accessDuration, refreshDuration time.Duration) *TokenService {
+func NewTokenService(signer TokenSigner, accessDuration, refreshDuration time.Duration, claims TokenClaimsConfig) *TokenService {
 	return &TokenService{
-		SecretKey:       []byte(secretKey),
+		Signer:          signer,
 		AccessDuration:  accessDuration,
 		RefreshDuration: refreshDuration,
+		Claims:          claims,
 	}
 }
 
 // CreateTokens cria tanto o token de acesso quanto o refresh token
-func (t *TokenService) CreateTokens(userID uuid.UUID, roles []string, permissions []string) (string, string, error) {
-	accessToken, err := t.createAccessToken(userID, roles, permissions)
+func (t *TokenService) CreateTokens(subject TokenSubject) (string, string, error) {
+	accessToken, err := t.createAccessToken(subject)
 	if err != nil {
 		return "", "", err
 	}
-	refreshToken, err := t.createRefreshToken(userID)
+	refreshToken, err := t.createRefreshToken(subject)
 	if err != nil {
 		return "", "", err
 	}
 	return accessToken, refreshToken, nil
 }
 
-func (t *TokenService) RefreshTokens(refreshToken string) (uuid.UUID, error) {
-	// Validar e parsear o refreshToken
-	token, err := jwt.Parse(refreshToken, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("algoritmo de assinatura inesperado: %v", token.Header["alg"])
-		}
-		return t.SecretKey, nil
-	})
+// RefreshTokens valida a assinatura, as claims padrão e o tipo do refresh token e retorna suas claims.
+// A verificação de revogação e reutilização é responsabilidade do TokenRedisService.
+func (t *TokenService) RefreshTokens(refreshToken string) (*RefreshClaims, error) {
+	claims := &refreshTokenClaims{}
+	if _, err := t.parse(refreshToken, claims); err != nil {
+		return nil, err
+	}
+
+	if claims.TokenUse != tokenUseRefresh {
+		return nil, ErrWrongTokenUse
+	}
 
-	if err != nil || !token.Valid {
-		return uuid.Nil, err
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return nil, err
 	}
 
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok || !token.Valid {
-		return uuid.Nil, err
+	if claims.FamilyID == "" {
+		return nil, fmt.Errorf("refresh token sem família")
 	}
 
-	userID, err := uuid.Parse(claims["sub"].(string))
+	tenantID, err := uuid.Parse(claims.TenantID)
 	if err != nil {
-		return uuid.Nil, err
+		return nil, fmt.Errorf("refresh token sem tenant_id válido: %w", err)
 	}
 
-	return userID, nil
+	return &RefreshClaims{
+		UserID:   userID,
+		TenantID: tenantID,
+		FamilyID: claims.FamilyID,
+		TokenID:  claims.ID,
+	}, nil
 }
 
-func (t *TokenService) createAccessToken(userID uuid.UUID, roles, permissions []string) (string, error) {
-	claims := jwt.MapClaims{
-		"sub":         userID.String(),
-		"roles":       roles,
-		"permissions": permissions,
-		"exp":         time.Now().Add(t.AccessDuration).Unix(),
+func (t *TokenService) createAccessToken(subject TokenSubject) (string, error) {
+	claims := AccessTokenClaims{
+		RegisteredClaims: t.registeredClaims(subject.UserID, t.AccessDuration),
+		TokenUse:         tokenUseAccess,
+		TenantID:         subject.TenantID.String(),
+		Roles:            subject.Roles,
+		Permissions:      subject.Permissions,
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(t.SecretKey)
+	return t.Signer.Sign(claims)
 }
 
-func (t *TokenService) createRefreshToken(userID uuid.UUID) (string, error) {
-	claims := jwt.MapClaims{
-		"sub": userID.String(),
-		"exp": time.Now().Add(t.RefreshDuration).Unix(),
+func (t *TokenService) createRefreshToken(subject TokenSubject) (string, error) {
+	claims := refreshTokenClaims{
+		RegisteredClaims: t.registeredClaims(subject.UserID, t.RefreshDuration),
+		TokenUse:         tokenUseRefresh,
+		TenantID:         subject.TenantID.String(),
+		FamilyID:         subject.FamilyID,
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(t.SecretKey)
+	return t.Signer.Sign(claims)
 }
 
-func (t *TokenService) ValidateToken(tokenString string) (*jwt.Token, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("algoritmo de assinatura inesperado: %v", token.Header["alg"])
-		}
-
-		return t.SecretKey, nil
-	})
+// registeredClaims monta as claims padrão (RFC 7519) com um jti único por token.
+func (t *TokenService) registeredClaims(userID uuid.UUID, duration time.Duration) jwt.RegisteredClaims {
+	now := time.Now()
+	claims := jwt.RegisteredClaims{
+		ID:        uuid.NewString(),
+		Subject:   userID.String(),
+		Issuer:    t.Claims.Issuer,
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(duration)),
+	}
+	if t.Claims.Audience != "" {
+		claims.Audience = jwt.ClaimStrings{t.Claims.Audience}
+	}
+	return claims
+}
 
+// ValidateToken valida um access token; refresh tokens são rejeitados.
+func (t *TokenService) ValidateToken(tokenString string) (*jwt.Token, error) {
+	claims := &AccessTokenClaims{}
+	token, err := t.parse(tokenString, claims)
 	if err != nil {
 		return nil, err
 	}
@@ -108,9 +186,40 @@ func (t *TokenService) ValidateToken(tokenString string) (*jwt.Token, error) {
 		return nil, fmt.Errorf("token inválido")
 	}
 
+	if claims.TokenUse != tokenUseAccess {
+		return nil, ErrWrongTokenUse
+	}
+
 	return token, nil
 }
 
+// parse verifica a assinatura do token com as chaves do signer e valida exp, nbf, iat, iss e aud conforme a configuração.
+func (t *TokenService) parse(tokenString string, claims jwt.Claims) (*jwt.Token, error) {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(t.Signer.Methods()),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(t.Claims.Leeway),
+	}
+	if t.Claims.Issuer != "" {
+		options = append(options, jwt.WithIssuer(t.Claims.Issuer))
+	}
+	if t.Claims.Audience != "" {
+		options = append(options, jwt.WithAudience(t.Claims.Audience))
+	}
+
+	return jwt.ParseWithClaims(tokenString, claims, t.Signer.Keyfunc, options...)
+}
+
 func (t *TokenService) GetAccessDuration() time.Duration {
 	return t.AccessDuration
 }
+
+func (t *TokenService) GetRefreshDuration() time.Duration {
+	return t.RefreshDuration
+}
+
+// JWKS retorna as chaves públicas usadas para verificar os tokens emitidos.
+func (t *TokenService) JWKS() models.JWKS {
+	return t.Signer.JWKS()
+}
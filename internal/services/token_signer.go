@@ -0,0 +1,230 @@
+// internal/services/token_signer.go
+
+package services
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+)
+
+// TokenSigner abstrai o algoritmo e as chaves usados para assinar e verificar os JWTs.
+type TokenSigner interface {
+	// Sign assina as claims com a chave ativa, definindo o header "kid".
+	Sign(claims jwt.Claims) (string, error)
+	// Keyfunc resolve a chave de verificação a partir do header "kid" do token.
+	Keyfunc(token *jwt.Token) (interface{}, error)
+	// Methods retorna os algoritmos aceitos na verificação.
+	Methods() []string
+	// JWKS retorna as chaves públicas de verificação (chaves HMAC nunca são publicadas).
+	JWKS() models.JWKS
+}
+
+// SigningKey representa uma chave identificada por um kid. PrivateKey é nil em chaves usadas apenas para verificação.
+type SigningKey struct {
+	ID         string
+	Method     jwt.SigningMethod
+	PrivateKey interface{}
+	PublicKey  interface{}
+}
+
+// KeySetSigner assina com uma chave ativa e verifica com qualquer chave do conjunto, permitindo a rotação de chaves.
+type KeySetSigner struct {
+	active *SigningKey
+	keys   map[string]*SigningKey
+}
+
+// NewHMACSigner cria um signer HS256 com um segredo compartilhado (modo legado, sem JWKS).
+func NewHMACSigner(secretKey string) *KeySetSigner {
+	key := &SigningKey{
+		ID:         "hmac",
+		Method:     jwt.SigningMethodHS256,
+		PrivateKey: []byte(secretKey),
+		PublicKey:  []byte(secretKey),
+	}
+	return &KeySetSigner{active: key, keys: map[string]*SigningKey{key.ID: key}}
+}
+
+// NewKeySetSigner cria um signer com a chave ativa e chaves adicionais, aceitas apenas para verificação.
+func NewKeySetSigner(active *SigningKey, verificationKeys ...*SigningKey) (*KeySetSigner, error) {
+	if active == nil || active.PrivateKey == nil {
+		return nil, errors.New("a chave ativa precisa de uma chave privada")
+	}
+
+	keys := map[string]*SigningKey{active.ID: active}
+	for _, key := range verificationKeys {
+		if _, exists := keys[key.ID]; exists {
+			return nil, fmt.Errorf("kid duplicado: %s", key.ID)
+		}
+		keys[key.ID] = key
+	}
+
+	return &KeySetSigner{active: active, keys: keys}, nil
+}
+
+// NewTokenSignerFromFiles monta o signer a partir de arquivos PEM. Sem chave privada, usa HMAC com o segredo informado.
+// verificationKeys segue o formato "kid=caminho.pem,kid2=caminho2.pem".
+func NewTokenSignerFromFiles(secretKey, privateKeyFile, keyID, verificationKeys string) (TokenSigner, error) {
+	if privateKeyFile == "" {
+		return NewHMACSigner(secretKey), nil
+	}
+
+	if keyID == "" {
+		return nil, errors.New("JWT_KEY_ID é obrigatório ao usar uma chave privada")
+	}
+
+	active, err := loadSigningKeyFile(keyID, privateKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	if active.PrivateKey == nil {
+		return nil, fmt.Errorf("o arquivo %s não contém uma chave privada", privateKeyFile)
+	}
+
+	var others []*SigningKey
+	for _, entry := range strings.Split(verificationKeys, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kid, path, found := strings.Cut(entry, "=")
+		if !found || kid == "" || path == "" {
+			return nil, fmt.Errorf("chave de verificação inválida %q, use o formato kid=caminho.pem", entry)
+		}
+		key, err := loadSigningKeyFile(strings.TrimSpace(kid), strings.TrimSpace(path))
+		if err != nil {
+			return nil, err
+		}
+		// Chaves antigas servem apenas para verificar tokens já emitidos.
+		key.PrivateKey = nil
+		others = append(others, key)
+	}
+
+	return NewKeySetSigner(active, others...)
+}
+
+func loadSigningKeyFile(keyID, path string) (*SigningKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao ler a chave %s: %w", path, err)
+	}
+	return ParseSigningKeyPEM(keyID, data)
+}
+
+// ParseSigningKeyPEM interpreta uma chave RSA ou Ed25519 em PEM (privada PKCS#1/PKCS#8 ou pública PKIX).
+func ParseSigningKeyPEM(keyID string, data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("PEM inválido")
+	}
+
+	var parsed interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("tipo de PEM não suportado: %s", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key := &SigningKey{ID: keyID}
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.Method, key.PrivateKey, key.PublicKey = jwt.SigningMethodRS256, k, &k.PublicKey
+	case *rsa.PublicKey:
+		key.Method, key.PublicKey = jwt.SigningMethodRS256, k
+	case ed25519.PrivateKey:
+		key.Method, key.PrivateKey, key.PublicKey = jwt.SigningMethodEdDSA, k, k.Public()
+	case ed25519.PublicKey:
+		key.Method, key.PublicKey = jwt.SigningMethodEdDSA, k
+	default:
+		return nil, fmt.Errorf("tipo de chave não suportado: %T", parsed)
+	}
+
+	return key, nil
+}
+
+func (s *KeySetSigner) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(s.active.Method, claims)
+	token.Header["kid"] = s.active.ID
+	return token.SignedString(s.active.PrivateKey)
+}
+
+func (s *KeySetSigner) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key := s.active
+	if kid != "" {
+		var ok bool
+		if key, ok = s.keys[kid]; !ok {
+			return nil, fmt.Errorf("chave de assinatura desconhecida: %s", kid)
+		}
+	} else if len(s.keys) > 1 {
+		return nil, errors.New("token sem kid")
+	}
+
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("algoritmo de assinatura inesperado: %v", token.Header["alg"])
+	}
+
+	return key.PublicKey, nil
+}
+
+func (s *KeySetSigner) Methods() []string {
+	seen := make(map[string]bool)
+	methods := make([]string, 0, len(s.keys))
+	for _, key := range s.keys {
+		if alg := key.Method.Alg(); !seen[alg] {
+			seen[alg] = true
+			methods = append(methods, alg)
+		}
+	}
+	return methods
+}
+
+func (s *KeySetSigner) JWKS() models.JWKS {
+	jwks := models.JWKS{Keys: []models.JWK{}}
+	for _, key := range s.keys {
+		if jwk, ok := publicJWK(key); ok {
+			jwks.Keys = append(jwks.Keys, jwk)
+		}
+	}
+	return jwks
+}
+
+// publicJWK converte a chave pública para o formato JWK (RFC 7517). Chaves simétricas não são convertidas.
+func publicJWK(key *SigningKey) (models.JWK, bool) {
+	encode := base64.RawURLEncoding.EncodeToString
+	jwk := models.JWK{Kid: key.ID, Use: "sig", Alg: key.Method.Alg()}
+
+	switch pub := key.PublicKey.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = encode(pub.N.Bytes())
+		jwk.E = encode(big.NewInt(int64(pub.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = encode(pub)
+	default:
+		return models.JWK{}, false
+	}
+
+	return jwk, true
+}
@@ -0,0 +1,351 @@
+// internal/services/two_factor_service.go
+
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/logging"
+	"github.com/jeancarlosdanese/go-base-api/internal/repositories"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	recoveryCodesCount          = 10
+	twoFactorChallengeMaxTries  = 5
+	twoFactorChallengeKeyPrefix = "2fa_challenge:"
+	twoFactorAttemptsKeyPrefix  = "2fa_challenge_attempts:"
+)
+
+var (
+	ErrTwoFactorAlreadyEnabled   = errors.New("2FA já está ativo")
+	ErrTwoFactorNotEnabled       = errors.New("2FA não está ativo")
+	ErrTwoFactorNotEnrolled      = errors.New("configuração do 2FA não foi iniciada")
+	ErrInvalidTwoFactorCode      = errors.New("código de verificação inválido")
+	ErrTwoFactorChallengeInvalid = errors.New("desafio de 2FA inválido ou expirado")
+)
+
+type TwoFactorServiceInterface interface {
+	BeginEnrollment(c *gin.Context, userID uuid.UUID) (*models.TOTPEnrollment, error)
+	ConfirmEnrollment(c *gin.Context, userID uuid.UUID, code string) ([]string, error)
+	Disable(c *gin.Context, userID uuid.UUID, code string) error
+	RegenerateRecoveryCodes(c *gin.Context, userID uuid.UUID, code string) ([]string, error)
+	CreateChallenge(user *models.User, origin string) (*models.TwoFactorChallenge, error)
+	CompleteChallenge(c *gin.Context, challengeToken, code, origin string) (*models.User, error)
+}
+
+// TwoFactorService gerencia o segundo fator TOTP (RFC 6238): configuração, códigos de recuperação
+// e o desafio emitido pelo login quando o usuário tem 2FA ativo.
+type TwoFactorService struct {
+	UserRepo          repositories.UserRepository
+	RedisService      RedisServiceInterface
+	Issuer            string
+	ChallengeDuration time.Duration
+}
+
+func NewTwoFactorService(userRepo repositories.UserRepository, redisService RedisServiceInterface, issuer string, challengeDuration time.Duration) *TwoFactorService {
+	return &TwoFactorService{
+		UserRepo:          userRepo,
+		RedisService:      redisService,
+		Issuer:            issuer,
+		ChallengeDuration: challengeDuration,
+	}
+}
+
+// twoFactorChallenge é o estado do desafio guardado no Redis.
+type twoFactorChallenge struct {
+	UserID    string    `json:"user_id"`
+	Origin    string    `json:"origin"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// BeginEnrollment gera um novo segredo TOTP para o usuário. O 2FA só passa a valer após ConfirmEnrollment.
+func (s *TwoFactorService) BeginEnrollment(c *gin.Context, userID uuid.UUID) (*models.TOTPEnrollment, error) {
+	user, err := s.UserRepo.GetOnlyByID(c, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.UserRepo.UpdateTOTP(c, userID, &secret, false); err != nil {
+		return nil, err
+	}
+
+	return &models.TOTPEnrollment{
+		Secret:          secret,
+		ProvisioningURI: TOTPProvisioningURI(s.Issuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmEnrollment ativa o 2FA após validar um código gerado pelo autenticador e retorna os códigos de recuperação.
+func (s *TwoFactorService) ConfirmEnrollment(c *gin.Context, userID uuid.UUID, code string) ([]string, error) {
+	user, err := s.UserRepo.GetOnlyByID(c, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+	if user.TOTPSecret == nil || *user.TOTPSecret == "" {
+		return nil, ErrTwoFactorNotEnrolled
+	}
+
+	if err := s.verifyTOTP(user, code); err != nil {
+		return nil, err
+	}
+
+	if err := s.UserRepo.UpdateTOTP(c, userID, user.TOTPSecret, true); err != nil {
+		return nil, err
+	}
+
+	logging.InfoLogger.Printf("2FA ativado para o usuário %s", userID)
+	return s.replaceRecoveryCodes(c, userID)
+}
+
+// Disable desativa o 2FA. Exige um código TOTP ou de recuperação válido.
+func (s *TwoFactorService) Disable(c *gin.Context, userID uuid.UUID, code string) error {
+	user, err := s.enabledUser(c, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.verifyCode(c, user, code); err != nil {
+		return err
+	}
+
+	if err := s.UserRepo.UpdateTOTP(c, userID, nil, false); err != nil {
+		return err
+	}
+	if err := s.UserRepo.ReplaceRecoveryCodes(c, userID, nil); err != nil {
+		return err
+	}
+
+	logging.InfoLogger.Printf("2FA desativado para o usuário %s", userID)
+	return nil
+}
+
+// RegenerateRecoveryCodes invalida os códigos de recuperação atuais e gera novos. Exige um código TOTP válido.
+func (s *TwoFactorService) RegenerateRecoveryCodes(c *gin.Context, userID uuid.UUID, code string) ([]string, error) {
+	user, err := s.enabledUser(c, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.verifyTOTP(user, code); err != nil {
+		return nil, err
+	}
+
+	return s.replaceRecoveryCodes(c, userID)
+}
+
+// CreateChallenge emite o token de desafio que substitui os tokens de acesso no login de usuários com 2FA.
+func (s *TwoFactorService) CreateChallenge(user *models.User, origin string) (*models.TwoFactorChallenge, error) {
+	token, err := generateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	challenge := twoFactorChallenge{
+		UserID:    user.ID.String(),
+		Origin:    origin,
+		ExpiresAt: time.Now().Add(s.ChallengeDuration),
+	}
+	if err := s.saveChallenge(hashOpaqueToken(token), &challenge); err != nil {
+		return nil, err
+	}
+
+	return &models.TwoFactorChallenge{
+		TwoFactorRequired: true,
+		ChallengeToken:    token,
+		ExpiresIn:         int(s.ChallengeDuration.Seconds()),
+	}, nil
+}
+
+// CompleteChallenge valida o código do segundo fator e retorna o usuário (com roles e políticas) para emissão dos tokens.
+// O desafio é de uso único e é descartado após tentativas demais. Com ErrInvalidTwoFactorCode, o usuário também é
+// retornado, para que a falha seja contada nas tentativas de login da conta.
+func (s *TwoFactorService) CompleteChallenge(c *gin.Context, challengeToken, code, origin string) (*models.User, error) {
+	tokenHash := hashOpaqueToken(challengeToken)
+	key := twoFactorChallengeKeyPrefix + tokenHash
+	attemptsKey := twoFactorAttemptsKeyPrefix + tokenHash
+	data, err := s.RedisService.Get(key)
+	if err == redis.Nil || (err == nil && data == "") {
+		return nil, ErrTwoFactorChallengeInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var challenge twoFactorChallenge
+	if err := json.Unmarshal([]byte(data), &challenge); err != nil {
+		return nil, ErrTwoFactorChallengeInvalid
+	}
+	if challenge.Origin != origin {
+		logging.WarnLogger.Printf("Desafio de 2FA apresentado por outra origem: %s", origin)
+		return nil, ErrTwoFactorChallengeInvalid
+	}
+
+	userID, err := uuid.Parse(challenge.UserID)
+	if err != nil {
+		return nil, ErrTwoFactorChallengeInvalid
+	}
+
+	// A tentativa é contada antes da verificação, com INCR: requisições simultâneas não leem o mesmo contador e
+	// nenhuma passa do limite. O contador expira junto com o desafio.
+	attempts, err := s.RedisService.Incr(attemptsKey, s.ChallengeDuration)
+	if err != nil {
+		return nil, err
+	}
+	if attempts > twoFactorChallengeMaxTries {
+		_ = s.RedisService.Delete(key, attemptsKey)
+		return nil, ErrTwoFactorChallengeInvalid
+	}
+
+	user, err := s.UserRepo.FindByIDWithPolicies(c, userID)
+	if err != nil {
+		return nil, err
+	}
+	// O usuário ou o tenant podem ter sido desativados entre a senha e o segundo fator
+	if err := CheckLoginAllowed(user); err != nil {
+		_ = s.RedisService.Delete(key, attemptsKey)
+		return nil, err
+	}
+
+	if err := s.verifyCode(c, user, code); err != nil {
+		if attempts == twoFactorChallengeMaxTries {
+			logging.WarnLogger.Printf("Desafio de 2FA descartado após %d tentativas: usuário %s", attempts, challenge.UserID)
+			_ = s.RedisService.Delete(key, attemptsKey)
+		}
+		if errors.Is(err, ErrInvalidTwoFactorCode) {
+			return user, err
+		}
+		return nil, err
+	}
+
+	// Uso único: se outra requisição consumiu o desafio ao mesmo tempo, esta é rejeitada.
+	if _, err := s.RedisService.GetDel(key); err != nil {
+		if err == redis.Nil {
+			return nil, ErrTwoFactorChallengeInvalid
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *TwoFactorService) enabledUser(c *gin.Context, userID uuid.UUID) (*models.User, error) {
+	user, err := s.UserRepo.GetOnlyByID(c, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.TOTPEnabled || user.TOTPSecret == nil {
+		return nil, ErrTwoFactorNotEnabled
+	}
+	return user, nil
+}
+
+// verifyCode aceita um código TOTP ou, se não tiver o formato TOTP, um código de recuperação.
+func (s *TwoFactorService) verifyCode(c *gin.Context, user *models.User, code string) error {
+	code = strings.TrimSpace(code)
+	if len(code) == TOTPDigits {
+		return s.verifyTOTP(user, code)
+	}
+
+	ok, err := s.UserRepo.UseRecoveryCode(c, user.ID, hashRecoveryCode(code))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidTwoFactorCode
+	}
+
+	logging.InfoLogger.Printf("Código de recuperação usado pelo usuário %s", user.ID)
+	return nil
+}
+
+// verifyTOTP valida o código e impede que o mesmo código seja aceito duas vezes dentro da janela.
+func (s *TwoFactorService) verifyTOTP(user *models.User, code string) error {
+	if user.TOTPSecret == nil {
+		return ErrTwoFactorNotEnrolled
+	}
+
+	step, ok := ValidateTOTP(*user.TOTPSecret, code, time.Now())
+	if !ok {
+		return ErrInvalidTwoFactorCode
+	}
+
+	window := time.Duration((2*TOTPSkew+1)*TOTPPeriod) * time.Second
+	fresh, err := s.RedisService.SetNX(fmt.Sprintf("totp_used:%s:%d", user.ID, step), 1, window)
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		logging.WarnLogger.Printf("Código TOTP reutilizado pelo usuário %s", user.ID)
+		return ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+func (s *TwoFactorService) replaceRecoveryCodes(c *gin.Context, userID uuid.UUID) ([]string, error) {
+	codes := make([]string, 0, recoveryCodesCount)
+	hashes := make([]string, 0, recoveryCodesCount)
+	for i := 0; i < recoveryCodesCount; i++ {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+
+	if err := s.UserRepo.ReplaceRecoveryCodes(c, userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func (s *TwoFactorService) saveChallenge(tokenHash string, challenge *twoFactorChallenge) error {
+	ttl := time.Until(challenge.ExpiresAt)
+	if ttl <= 0 {
+		return ErrTwoFactorChallengeInvalid
+	}
+	data, err := json.Marshal(challenge)
+	if err != nil {
+		return err
+	}
+	return s.RedisService.Set(twoFactorChallengeKeyPrefix+tokenHash, data, ttl)
+}
+
+// generateRecoveryCode gera um código no formato xxxxx-xxxxx (base32 minúsculo, 50 bits).
+func generateRecoveryCode() (string, error) {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	encoded := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b))[:10]
+	return encoded[:5] + "-" + encoded[5:], nil
+}
+
+// hashRecoveryCode normaliza (minúsculas, sem hífens e espaços) e gera o hash SHA-256 do código.
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,188 @@
+// internal/services/user_status_service.go
+
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/enums"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/logging"
+	"github.com/jeancarlosdanese/go-base-api/internal/mailer"
+	"github.com/jeancarlosdanese/go-base-api/internal/repositories"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrEmailVerificationTokenInvalid indica que o token de verificação não existe, expirou ou já foi usado.
+	ErrEmailVerificationTokenInvalid = errors.New("token de verificação de e-mail inválido ou expirado")
+	// ErrEmailAlreadyVerified indica um pedido de verificação para um e-mail já verificado.
+	ErrEmailAlreadyVerified = errors.New("e-mail já verificado")
+)
+
+type UserStatusServiceInterface interface {
+	SendVerificationEmail(c *gin.Context, user *models.User) error
+	VerifyEmail(c *gin.Context, token string) error
+	CancelEmailVerification(userID uuid.UUID) error
+	Disable(c *gin.Context, user *models.User) error
+	Enable(c *gin.Context, user *models.User) error
+}
+
+// UserStatusService controla o ciclo de vida do usuário: verificação do e-mail (PENDENTE -> ATIVO) e
+// desativação/reativação por um administrador. Os links de verificação são de uso único e, como na
+// redefinição de senha, apenas o hash SHA-256 do token fica no Redis, com o usuário e o e-mail a verificar.
+type UserStatusService struct {
+	UserRepo          repositories.UserRepository
+	RedisService      RedisServiceInterface
+	TokenRedisService TokenRedisServiceInterface
+	Mailer            mailer.Mailer
+	TokenDuration     time.Duration
+	VerifyURL         string
+}
+
+func NewUserStatusService(
+	userRepo repositories.UserRepository,
+	redisService RedisServiceInterface,
+	tokenRedisService TokenRedisServiceInterface,
+	mailer mailer.Mailer,
+	tokenDuration time.Duration,
+	verifyURL string) *UserStatusService {
+	return &UserStatusService{
+		UserRepo:          userRepo,
+		RedisService:      redisService,
+		TokenRedisService: tokenRedisService,
+		Mailer:            mailer,
+		TokenDuration:     tokenDuration,
+		VerifyURL:         verifyURL,
+	}
+}
+
+// SendVerificationEmail gera um token de verificação para o usuário e o envia por e-mail. Um novo envio invalida o link anterior.
+func (s *UserStatusService) SendVerificationEmail(c *gin.Context, user *models.User) error {
+	if user.EmailVerifiedAt != nil {
+		return ErrEmailAlreadyVerified
+	}
+
+	token, err := generateOpaqueToken()
+	if err != nil {
+		return err
+	}
+	userID := user.ID.String()
+
+	if err := s.CancelEmailVerification(user.ID); err != nil {
+		return err
+	}
+
+	// O token verifica o e-mail para o qual foi enviado: se o e-mail mudar, ele deixa de valer
+	tokenHash := hashOpaqueToken(token)
+	if err := s.RedisService.Set(emailVerificationKey(tokenHash), userID+":"+user.Email, s.TokenDuration); err != nil {
+		return err
+	}
+	if err := s.RedisService.Set(emailVerificationUserKey(userID), tokenHash, s.TokenDuration); err != nil {
+		return err
+	}
+
+	return s.Mailer.Send(c, mailer.Message{
+		To:      user.Email,
+		Subject: "Confirme seu e-mail",
+		Body:    s.verificationMessage(user.Name, token),
+		Secret:  token,
+	})
+}
+
+// VerifyEmail consome o token e registra a verificação do e-mail, ativando o usuário PENDENTE. O token só vale
+// se o e-mail para o qual foi enviado ainda for o e-mail do usuário.
+func (s *UserStatusService) VerifyEmail(c *gin.Context, token string) error {
+	value, err := s.RedisService.GetDel(emailVerificationKey(hashOpaqueToken(token)))
+	if err == redis.Nil || (err == nil && value == "") {
+		return ErrEmailVerificationTokenInvalid
+	}
+	if err != nil {
+		return err
+	}
+
+	userIDStr, email, found := strings.Cut(value, ":")
+	userID, err := uuid.Parse(userIDStr)
+	if !found || err != nil {
+		return ErrEmailVerificationTokenInvalid
+	}
+
+	if err := s.UserRepo.MarkEmailVerified(c, userID, email); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logging.InfoLogger.Printf("Token de verificação do usuário %s emitido para um e-mail que não é mais o seu", userIDStr)
+			return ErrEmailVerificationTokenInvalid
+		}
+		return err
+	}
+
+	if err := s.RedisService.Delete(emailVerificationUserKey(userIDStr)); err != nil {
+		logging.WarnLogger.Printf("Falha ao remover índice de verificação de e-mail do usuário %s: %v", userIDStr, err)
+	}
+
+	logging.InfoLogger.Printf("E-mail do usuário %s verificado", userIDStr)
+	return nil
+}
+
+// CancelEmailVerification invalida o link de verificação pendente do usuário, se houver. Usado em um novo envio
+// e quando o e-mail do usuário é alterado.
+func (s *UserStatusService) CancelEmailVerification(userID uuid.UUID) error {
+	previous, err := s.RedisService.GetDel(emailVerificationUserKey(userID.String()))
+	if err != nil && err != redis.Nil {
+		return err
+	}
+	if previous == "" {
+		return nil
+	}
+	return s.RedisService.Delete(emailVerificationKey(previous))
+}
+
+// Disable desativa o usuário e encerra todas as suas sessões. O registro e o histórico são mantidos.
+func (s *UserStatusService) Disable(c *gin.Context, user *models.User) error {
+	if err := s.UserRepo.UpdateUserStatus(c, user.ID, enums.UserDesativado); err != nil {
+		return err
+	}
+	user.Status = enums.UserDesativado
+
+	if err := s.TokenRedisService.RevokeAllSessions(user.ID.String()); err != nil {
+		logging.ErrorLogger.Printf("Usuário %s desativado, mas falha ao revogar as sessões: %v", user.ID, err)
+		return err
+	}
+
+	logging.InfoLogger.Printf("Usuário %s desativado", user.ID)
+	return nil
+}
+
+// Enable reativa o usuário. A decisão é do administrador: um usuário PENDENTE também passa a ATIVO.
+func (s *UserStatusService) Enable(c *gin.Context, user *models.User) error {
+	if err := s.UserRepo.UpdateUserStatus(c, user.ID, enums.UserAtivo); err != nil {
+		return err
+	}
+	user.Status = enums.UserAtivo
+
+	logging.InfoLogger.Printf("Usuário %s reativado", user.ID)
+	return nil
+}
+
+func (s *UserStatusService) verificationMessage(name, token string) string {
+	validity := s.TokenDuration.Round(time.Minute)
+	if s.VerifyURL != "" {
+		link := s.VerifyURL + "?token=" + url.QueryEscape(token)
+		return fmt.Sprintf("Olá %s,\n\nPara confirmar seu e-mail e ativar sua conta, acesse o link abaixo (válido por %s):\n\n%s\n\nSe você não reconhece este cadastro, ignore este e-mail.", name, validity, link)
+	}
+	return fmt.Sprintf("Olá %s,\n\nUse o código abaixo para confirmar seu e-mail e ativar sua conta (válido por %s):\n\n%s\n\nSe você não reconhece este cadastro, ignore este e-mail.", name, validity, token)
+}
+
+func emailVerificationKey(tokenHash string) string {
+	return "email_verification:" + tokenHash
+}
+
+func emailVerificationUserKey(userID string) string {
+	return "email_verification_user:" + userID
+}
@@ -8,10 +8,11 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	autherrors "github.com/jeancarlosdanese/go-base-api/internal/domain/auth_errors"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/enums"
 	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
 	"github.com/jeancarlosdanese/go-base-api/internal/logging"
 	"github.com/jeancarlosdanese/go-base-api/internal/repositories"
-	"golang.org/x/crypto/bcrypt"
 )
 
 // UserServiceInterface define as operações adicionais do UserService além das operações CRUD básicas.
@@ -20,14 +21,40 @@ type UserServiceInterface interface {
 	CreateUserWithPassword(c *gin.Context, entity *models.UserCreate) (*models.User, error)
 	Authenticate(c *gin.Context, email, password, origin string) (*models.User, error)
 	GetOnlyByID(c *gin.Context, id uuid.UUID) (*models.User, error)
+	FindByEmail(c *gin.Context, email, origin string) (*models.User, error)
+	GetLoginOrigins(c *gin.Context, id uuid.UUID) ([]string, error)
+	UpdatePassword(c *gin.Context, id uuid.UUID, newPassword string) error
+	UpdateProfile(c *gin.Context, id uuid.UUID, profile *models.UserProfileUpdate) (*models.User, error)
+	ChangePassword(c *gin.Context, id uuid.UUID, currentPassword, newPassword string) error
+	Search(c *gin.Context, term string, limit int) ([]models.User, error)
+	GetTrash(c *gin.Context, query models.ListQuery) (*models.Page[models.User], error)
+	Restore(c *gin.Context, id uuid.UUID) (*models.User, error)
+	Purge(c *gin.Context, id uuid.UUID) error
 }
+
+// ErrInvalidCurrentPassword indica que a senha atual informada na troca de senha não confere.
+var ErrInvalidCurrentPassword = errors.New("senha atual inválida")
+
 type UserService struct {
 	*BaseService[models.User, repositories.UserRepository]
+	PasswordHasher PasswordHasher
+	PasswordPolicy *PasswordPolicy
+	// CasbinService e TokenRedisService são usados em Purge para retirar as políticas do usuário do Casbin e
+	// encerrar as suas sessões.
+	CasbinService     CasbinServiceInterface
+	TokenRedisService TokenRedisServiceInterface
 }
 
-func NewUserService(repo repositories.UserRepository) *UserService {
+// NewUserService cria o serviço de usuários. Com passwordPolicy nil, as senhas não são validadas.
+func NewUserService(repo repositories.UserRepository, passwordHasher PasswordHasher, passwordPolicy *PasswordPolicy, casbinService CasbinServiceInterface, tokenRedisService TokenRedisServiceInterface) *UserService {
 	baseService := NewBaseService[models.User, repositories.UserRepository](repo) // Tipos especificados aqui
-	return &UserService{BaseService: baseService}
+	return &UserService{
+		BaseService:       baseService,
+		PasswordHasher:    passwordHasher,
+		PasswordPolicy:    passwordPolicy,
+		CasbinService:     casbinService,
+		TokenRedisService: tokenRedisService,
+	}
 }
 
 // // Create sobrescreve o método Create para retornar um erro, alertando para o uso do méetodo CreateUserWithPassword.
@@ -36,9 +63,14 @@ func NewUserService(repo repositories.UserRepository) *UserService {
 // }
 
 // CreateUserWithPassword é o método indicado para adicionar usuários, para fazer o hashing de senha.
+// O usuário é criado PENDENTE, até verificar o e-mail.
 func (s *UserService) CreateUserWithPassword(c *gin.Context, userCreate *models.UserCreate) (*models.User, error) {
+	if err := s.validatePassword(userCreate.Password, userCreate.Email, userCreate.Username); err != nil {
+		return nil, err
+	}
+
 	// Gera um hash para a senha do usuário
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(userCreate.Password), bcrypt.DefaultCost)
+	hashedPassword, err := s.PasswordHasher.Hash(userCreate.Password)
 	if err != nil {
 		return nil, err
 	}
@@ -49,7 +81,8 @@ func (s *UserService) CreateUserWithPassword(c *gin.Context, userCreate *models.
 		Username: userCreate.Username,
 		Name:     userCreate.Name,
 		Email:    userCreate.Email,
-		Password: string(hashedPassword),
+		Password: hashedPassword,
+		Status:   enums.UserPendente,
 	}
 
 	userCreated, err := s.Repo.Create(c, &user)
@@ -60,7 +93,8 @@ func (s *UserService) CreateUserWithPassword(c *gin.Context, userCreate *models.
 	return userCreated, nil
 }
 
-// Authenticate verifica as credenciais de um usuário.
+// Authenticate verifica as credenciais de um usuário. Se o hash armazenado usar um algoritmo ou custo
+// desatualizado, a senha é re-hasheada com a configuração atual, sem exigir ação do usuário.
 func (s *UserService) Authenticate(c *gin.Context, email, password, origin string) (*models.User, error) {
 	user, err := s.Repo.FindByEmail(c, email, origin)
 	if err != nil {
@@ -68,15 +102,99 @@ func (s *UserService) Authenticate(c *gin.Context, email, password, origin strin
 		return nil, err
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
+	ok, err := s.PasswordHasher.Verify(password, user.Password)
 	if err != nil {
+		logging.ErrorLogger.Printf("Erro ao verificar o hash de senha do usuário %s: %v", user.ID, err)
+	}
+	if !ok {
 		logging.InfoLogger.Printf("Tentativa de login com credenciais inválidas")
-		return nil, errors.New("senha inválida")
+		return nil, autherrors.ErrInvalidPassword
+	}
+
+	// Os status do usuário e do tenant só são revelados após a validação da senha
+	if err := CheckLoginAllowed(user); err != nil {
+		return nil, err
+	}
+
+	if s.PasswordHasher.NeedsRehash(user.Password) {
+		s.rehashPassword(c, user, password)
 	}
 
 	return user, nil
 }
 
+// CheckLoginAllowed verifica se o usuário pode receber tokens: o usuário precisa estar ATIVO e o tenant carregado
+// com ele não pode estar INATIVO. Sem o tenant carregado, apenas o status do usuário é verificado.
+func CheckLoginAllowed(user *models.User) error {
+	switch user.Status {
+	case enums.UserPendente:
+		logging.InfoLogger.Printf("Login recusado para o usuário %s: e-mail não verificado", user.ID)
+		return autherrors.ErrUserPending
+	case enums.UserDesativado:
+		logging.WarnLogger.Printf("Login recusado para o usuário %s: usuário desativado", user.ID)
+		return autherrors.ErrUserDisabled
+	}
+	if user.Tenant != nil && user.Tenant.Status == enums.Inativo {
+		logging.WarnLogger.Printf("Login recusado para o usuário %s: tenant %s com status %s", user.ID, user.TenantID, user.Tenant.Status)
+		return autherrors.ErrTenantSuspended
+	}
+	return nil
+}
+
+// rehashPassword regrava o hash com a configuração atual. Falhas são apenas registradas, pois o login já foi validado.
+func (s *UserService) rehashPassword(c *gin.Context, user *models.User, password string) {
+	hashedPassword, err := s.PasswordHasher.Hash(password)
+	if err != nil {
+		logging.ErrorLogger.Printf("Erro ao atualizar o hash de senha do usuário %s: %v", user.ID, err)
+		return
+	}
+	if err := s.Repo.UpdatePassword(c, user.ID, hashedPassword); err != nil {
+		logging.ErrorLogger.Printf("Erro ao gravar o novo hash de senha do usuário %s: %v", user.ID, err)
+		return
+	}
+	user.Password = hashedPassword
+	logging.InfoLogger.Printf("Hash de senha do usuário %s atualizado", user.ID)
+}
+
+// Search busca os usuários do tenant do contexto por nome, username ou e-mail, tolerando acentos e erros de digitação.
+func (s *UserService) Search(c *gin.Context, term string, limit int) ([]models.User, error) {
+	return s.Repo.SearchUsers(c, term, limit)
+}
+
+// GetTrash lista os usuários removidos logicamente do tenant do contexto.
+func (s *UserService) GetTrash(c *gin.Context, query models.ListQuery) (*models.Page[models.User], error) {
+	return s.Repo.FindDeletedUsers(c, query)
+}
+
+// Restore restaura o usuário removido, com os seus roles e políticas. Se outro usuário ativo do tenant já usar
+// o e-mail ou o username, retorna ErrRestoreConflict.
+func (s *UserService) Restore(c *gin.Context, id uuid.UUID) (*models.User, error) {
+	restored, err := s.Repo.RestoreUser(c, id)
+	if err != nil {
+		return nil, softDeleteError(err)
+	}
+	return restored, nil
+}
+
+// Purge remove definitivamente o usuário, com os seus roles, políticas especiais e códigos de recuperação.
+// Após a remoção, as sessões do usuário são revogadas e as políticas do Casbin recarregadas (e propagadas
+// às outras instâncias), como na desativação e nas alterações feitas pelo SecurityService.
+func (s *UserService) Purge(c *gin.Context, id uuid.UUID) error {
+	if err := s.Repo.PurgeUser(c, id); err != nil {
+		return softDeleteError(err)
+	}
+
+	if err := s.TokenRedisService.RevokeAllSessions(id.String()); err != nil {
+		logging.ErrorLogger.Printf("Usuário %s removido, mas falha ao revogar as sessões: %v", id, err)
+		return err
+	}
+	if err := s.CasbinService.LoadPolicy(); err != nil {
+		logging.ErrorLogger.Printf("Usuário %s removido, mas falha ao recarregar as políticas: %v", id, err)
+		return err
+	}
+	return nil
+}
+
 // GetOnlyByID busca usuário apenas pelo seu ID
 func (s *UserService) GetOnlyByID(c *gin.Context, id uuid.UUID) (*models.User, error) {
 	user, err := s.Repo.GetOnlyByID(c, id)
@@ -88,3 +206,88 @@ func (s *UserService) GetOnlyByID(c *gin.Context, id uuid.UUID) (*models.User, e
 
 	return user, nil
 }
+
+// FindByEmail busca o usuário pelo email dentro do tenant que permite a origem informada.
+func (s *UserService) FindByEmail(c *gin.Context, email, origin string) (*models.User, error) {
+	return s.Repo.FindByEmail(c, email, origin)
+}
+
+// GetLoginOrigins retorna as origens do tenant do usuário, nas quais ele pode fazer login.
+func (s *UserService) GetLoginOrigins(c *gin.Context, id uuid.UUID) ([]string, error) {
+	user, err := s.Repo.FindByIDWithPolicies(c, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.Tenant == nil {
+		return nil, nil
+	}
+	return user.Tenant.Origins(), nil
+}
+
+// UpdatePassword valida a nova senha pela política, gera o hash e a grava para o usuário.
+func (s *UserService) UpdatePassword(c *gin.Context, id uuid.UUID, newPassword string) error {
+	user, err := s.Repo.GetOnlyByID(c, id)
+	if err != nil {
+		return err
+	}
+
+	return s.setPassword(c, user, newPassword)
+}
+
+// UpdateProfile altera apenas os campos de perfil (nome, username e thumbnail) informados.
+func (s *UserService) UpdateProfile(c *gin.Context, id uuid.UUID, profile *models.UserProfileUpdate) (*models.User, error) {
+	updateData := map[string]interface{}{}
+	if profile.Name != nil {
+		updateData["name"] = *profile.Name
+	}
+	if profile.Username != nil {
+		updateData["username"] = *profile.Username
+	}
+	if profile.Thumbnail != nil {
+		updateData["thumbnail"] = *profile.Thumbnail
+	}
+
+	if len(updateData) == 0 {
+		return s.Repo.GetByID(c, id)
+	}
+
+	return s.Repo.UpdatePartial(c, id, updateData)
+}
+
+// ChangePassword troca a senha do usuário após conferir a senha atual.
+func (s *UserService) ChangePassword(c *gin.Context, id uuid.UUID, currentPassword, newPassword string) error {
+	user, err := s.Repo.GetOnlyByID(c, id)
+	if err != nil {
+		return err
+	}
+
+	if ok, err := s.PasswordHasher.Verify(currentPassword, user.Password); !ok {
+		if err != nil {
+			logging.ErrorLogger.Printf("Erro ao verificar o hash de senha do usuário %s: %v", id, err)
+		}
+		logging.InfoLogger.Printf("Troca de senha com senha atual inválida para o usuário %s", id)
+		return ErrInvalidCurrentPassword
+	}
+
+	return s.setPassword(c, user, newPassword)
+}
+
+func (s *UserService) setPassword(c *gin.Context, user *models.User, newPassword string) error {
+	if err := s.validatePassword(newPassword, user.Email, user.Username); err != nil {
+		return err
+	}
+
+	hashedPassword, err := s.PasswordHasher.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+
+	return s.Repo.UpdatePassword(c, user.ID, hashedPassword)
+}
+
+func (s *UserService) validatePassword(password, email, username string) error {
+	if s.PasswordPolicy == nil {
+		return nil
+	}
+	return s.PasswordPolicy.Validate(password, email, username)
+}
@@ -0,0 +1,481 @@
+// internal/settings/settings.go
+
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"golang.org/x/crypto/bcrypt"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileEnv é a variável de ambiente com o caminho do arquivo de configuração opcional (.yaml, .yml ou .toml).
+const ConfigFileEnv = "CONFIG_FILE"
+
+// Config reúne as configurações da aplicação. Os valores são carregados, nesta ordem de precedência,
+// das variáveis de ambiente, do arquivo indicado em CONFIG_FILE e dos valores padrão.
+type Config struct {
+	Server   ServerConfig   `yaml:"server" toml:"server"`
+	Database DatabaseConfig `yaml:"database" toml:"database"`
+	Redis    RedisConfig    `yaml:"redis" toml:"redis"`
+	Auth     AuthConfig     `yaml:"auth" toml:"auth"`
+	Mailer   MailerConfig   `yaml:"mailer" toml:"mailer"`
+	Casbin   CasbinConfig   `yaml:"casbin" toml:"casbin"`
+}
+
+// ServerConfig contém as configurações do servidor HTTP.
+type ServerConfig struct {
+	Addr string `yaml:"addr" toml:"addr"`
+}
+
+// DatabaseConfig contém as configurações de conexão com o PostgreSQL.
+type DatabaseConfig struct {
+	Host     string `yaml:"host" toml:"host"`
+	Port     string `yaml:"port" toml:"port"`
+	User     string `yaml:"user" toml:"user"`
+	Password string `yaml:"password" toml:"password"`
+	Name     string `yaml:"name" toml:"name"`
+	TimeZone string `yaml:"time_zone" toml:"time_zone"`
+}
+
+// RedisConfig contém as configurações de conexão com o Redis.
+type RedisConfig struct {
+	Host     string `yaml:"host" toml:"host"`
+	Port     string `yaml:"port" toml:"port"`
+	Password string `yaml:"password" toml:"password"`
+	DB       int    `yaml:"db" toml:"db"`
+	PoolSize int    `yaml:"pool_size" toml:"pool_size"`
+}
+
+// AuthConfig contém os tempos de vida dos tokens e demais parâmetros de autenticação.
+type AuthConfig struct {
+	AccessTokenTTL  Duration  `yaml:"access_token_ttl" toml:"access_token_ttl"`
+	RefreshTokenTTL Duration  `yaml:"refresh_token_ttl" toml:"refresh_token_ttl"`
+	ApiKeyCacheTTL  Duration  `yaml:"api_key_cache_ttl" toml:"api_key_cache_ttl"`
+	JWT             JWTConfig `yaml:"jwt" toml:"jwt"`
+
+	// PasswordHashAlgorithm é o algoritmo dos novos hashes de senha (argon2id ou bcrypt). Hashes gerados
+	// com outro algoritmo ou custo continuam aceitos e são atualizados no próximo login.
+	PasswordHashAlgorithm string       `yaml:"password_hash_algorithm" toml:"password_hash_algorithm"`
+	BcryptCost            int          `yaml:"bcrypt_cost" toml:"bcrypt_cost"`
+	Argon2id              Argon2Config `yaml:"argon2id" toml:"argon2id"`
+
+	// PasswordResetTTL é a validade do token de redefinição de senha; PasswordResetURL é a página do
+	// front-end que recebe o token (?token=...). Sem URL, o e-mail traz apenas o token.
+	PasswordResetTTL Duration `yaml:"password_reset_ttl" toml:"password_reset_ttl"`
+	PasswordResetURL string   `yaml:"password_reset_url" toml:"password_reset_url"`
+
+	// EmailVerificationTTL é a validade do link de verificação de e-mail enviado aos novos usuários;
+	// EmailVerificationURL é a página do front-end que recebe o token (?token=...). Sem URL, o e-mail traz apenas o token.
+	EmailVerificationTTL Duration `yaml:"email_verification_ttl" toml:"email_verification_ttl"`
+	EmailVerificationURL string   `yaml:"email_verification_url" toml:"email_verification_url"`
+
+	// TOTPIssuer é o nome exibido no aplicativo autenticador; TwoFactorChallengeTTL é a validade do
+	// desafio emitido pelo login de usuários com 2FA.
+	TOTPIssuer            string   `yaml:"totp_issuer" toml:"totp_issuer"`
+	TwoFactorChallengeTTL Duration `yaml:"two_factor_challenge_ttl" toml:"two_factor_challenge_ttl"`
+
+	Login          LoginProtectionConfig `yaml:"login" toml:"login"`
+	PasswordPolicy PasswordPolicyConfig  `yaml:"password_policy" toml:"password_policy"`
+}
+
+// PasswordPolicyConfig define as regras aplicadas às senhas na criação, troca e redefinição.
+// BreachedPasswordsDir aponta para um diretório de arquivos de prefixo SHA-1 (k-anonymity, formato
+// "SUFIXO:CONTAGEM", um arquivo por prefixo de 5 caracteres); vazio desativa a verificação.
+type PasswordPolicyConfig struct {
+	MinLength            int    `yaml:"min_length" toml:"min_length"`
+	RequireUpper         bool   `yaml:"require_upper" toml:"require_upper"`
+	RequireLower         bool   `yaml:"require_lower" toml:"require_lower"`
+	RequireDigit         bool   `yaml:"require_digit" toml:"require_digit"`
+	RequireSymbol        bool   `yaml:"require_symbol" toml:"require_symbol"`
+	BreachedPasswordsDir string `yaml:"breached_passwords_dir" toml:"breached_passwords_dir"`
+}
+
+// LoginProtectionConfig define a proteção contra força bruta no login: atraso progressivo a partir de
+// BaseDelay (dobrando até MaxDelay) e bloqueio por LockoutDuration após MaxFailures falhas do mesmo e-mail
+// dentro de FailureWindow (por origem). OriginMaxFailures limita as falhas somadas de um cliente (IP) na origem.
+type LoginProtectionConfig struct {
+	MaxFailures       int      `yaml:"max_failures" toml:"max_failures"`
+	FailureWindow     Duration `yaml:"failure_window" toml:"failure_window"`
+	LockoutDuration   Duration `yaml:"lockout_duration" toml:"lockout_duration"`
+	BaseDelay         Duration `yaml:"base_delay" toml:"base_delay"`
+	MaxDelay          Duration `yaml:"max_delay" toml:"max_delay"`
+	OriginMaxFailures int      `yaml:"origin_max_failures" toml:"origin_max_failures"`
+}
+
+// Argon2Config contém os parâmetros de custo do Argon2id. MemoryKiB é a memória usada por hash, em KiB.
+type Argon2Config struct {
+	MemoryKiB   int `yaml:"memory_kib" toml:"memory_kib"`
+	Iterations  int `yaml:"iterations" toml:"iterations"`
+	Parallelism int `yaml:"parallelism" toml:"parallelism"`
+}
+
+// JWTConfig contém as chaves de assinatura e as claims padrão dos tokens.
+type JWTConfig struct {
+	SecretKey        string   `yaml:"secret_key" toml:"secret_key"`
+	PrivateKeyFile   string   `yaml:"private_key_file" toml:"private_key_file"`
+	KeyID            string   `yaml:"key_id" toml:"key_id"`
+	VerificationKeys string   `yaml:"verification_keys" toml:"verification_keys"`
+	Issuer           string   `yaml:"issuer" toml:"issuer"`
+	Audience         string   `yaml:"audience" toml:"audience"`
+	Leeway           Duration `yaml:"leeway" toml:"leeway"`
+}
+
+// MailerConfig define como os e-mails da aplicação são entregues.
+// Driver "log" escreve as mensagens no log, sem os tokens; "file" grava arquivos .eml completos em OutputDir.
+type MailerConfig struct {
+	Driver    string `yaml:"driver" toml:"driver"`
+	From      string `yaml:"from" toml:"from"`
+	OutputDir string `yaml:"output_dir" toml:"output_dir"`
+}
+
+// CasbinConfig controla a sincronização das políticas do Casbin entre instâncias.
+// WatcherChannel é o canal Redis onde as alterações são publicadas; ReloadInterval define a recarga
+// periódica de segurança a partir do banco (0 desativa).
+type CasbinConfig struct {
+	WatcherChannel string   `yaml:"watcher_channel" toml:"watcher_channel"`
+	ReloadInterval Duration `yaml:"reload_interval" toml:"reload_interval"`
+}
+
+// Duration permite escrever durações como texto ("15m", "24h") tanto no YAML quanto no TOML.
+type Duration struct {
+	time.Duration
+}
+
+// UnmarshalText converte o texto em uma duração usando time.ParseDuration.
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	d.Duration = parsed
+	return nil
+}
+
+// MarshalText devolve a duração no formato aceito por UnmarshalText.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.Duration.String()), nil
+}
+
+// Default retorna a configuração padrão, equivalente aos valores usados antes da configuração ser externalizada.
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Addr: "0.0.0.0:5001",
+		},
+		Database: DatabaseConfig{
+			TimeZone: "America/Sao_Paulo",
+		},
+		Redis: RedisConfig{
+			PoolSize: 10,
+		},
+		Auth: AuthConfig{
+			AccessTokenTTL:        Duration{time.Hour * 24},
+			RefreshTokenTTL:       Duration{time.Hour * 24 * 90},
+			ApiKeyCacheTTL:        Duration{time.Hour * 24},
+			PasswordHashAlgorithm: "argon2id",
+			BcryptCost:            bcrypt.DefaultCost,
+			Argon2id: Argon2Config{
+				MemoryKiB:   64 * 1024,
+				Iterations:  3,
+				Parallelism: 2,
+			},
+			JWT: JWTConfig{
+				Issuer:   "go-base-api",
+				Audience: "go-base-api",
+				Leeway:   Duration{time.Second * 30},
+			},
+			PasswordResetTTL:      Duration{time.Minute * 30},
+			EmailVerificationTTL:  Duration{time.Hour * 48},
+			TOTPIssuer:            "Go Base API",
+			TwoFactorChallengeTTL: Duration{time.Minute * 5},
+			Login: LoginProtectionConfig{
+				MaxFailures:       5,
+				FailureWindow:     Duration{time.Minute * 15},
+				LockoutDuration:   Duration{time.Minute * 15},
+				BaseDelay:         Duration{time.Second},
+				MaxDelay:          Duration{time.Second * 30},
+				OriginMaxFailures: 100,
+			},
+			PasswordPolicy: PasswordPolicyConfig{
+				MinLength:    8,
+				RequireUpper: true,
+				RequireLower: true,
+				RequireDigit: true,
+			},
+		},
+		Mailer: MailerConfig{
+			Driver:    "log",
+			From:      "no-reply@localhost",
+			OutputDir: "./tmp/mails",
+		},
+		Casbin: CasbinConfig{
+			WatcherChannel: "casbin:policy_updates",
+			ReloadInterval: Duration{time.Minute * 5},
+		},
+	}
+}
+
+// Load monta a configuração a partir dos valores padrão, do arquivo em CONFIG_FILE (se definido)
+// e das variáveis de ambiente, e valida o resultado.
+func Load() (*Config, error) {
+	cfg := Default()
+
+	if path := os.Getenv(ConfigFileEnv); path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := cfg.loadEnv(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// loadFile sobrepõe a configuração com os valores do arquivo YAML ou TOML.
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("erro ao ler arquivo de configuração %s: %w", path, err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, c)
+	case ".toml":
+		err = toml.Unmarshal(data, c)
+	default:
+		return fmt.Errorf("formato de arquivo de configuração não suportado: %s", path)
+	}
+	if err != nil {
+		return fmt.Errorf("erro ao interpretar arquivo de configuração %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// loadEnv sobrepõe a configuração com as variáveis de ambiente definidas; variáveis vazias são ignoradas.
+func (c *Config) loadEnv() error {
+	var errs []error
+
+	envString("SERVER_ADDR", &c.Server.Addr)
+
+	envString("DB_HOST", &c.Database.Host)
+	envString("DB_PORT", &c.Database.Port)
+	envString("DB_USER", &c.Database.User)
+	envString("DB_PASSWORD", &c.Database.Password)
+	envString("DB_NAME", &c.Database.Name)
+	envString("DB_TIMEZONE", &c.Database.TimeZone)
+
+	envString("REDIS_HOST", &c.Redis.Host)
+	envString("REDIS_PORT", &c.Redis.Port)
+	envString("REDIS_PASSWORD", &c.Redis.Password)
+	errs = append(errs, envInt("REDIS_DB", &c.Redis.DB))
+	errs = append(errs, envInt("REDIS_POOL_SIZE", &c.Redis.PoolSize))
+
+	errs = append(errs, envDuration("JWT_ACCESS_DURATION", &c.Auth.AccessTokenTTL))
+	errs = append(errs, envDuration("JWT_REFRESH_DURATION", &c.Auth.RefreshTokenTTL))
+	errs = append(errs, envDuration("API_KEY_CACHE_DURATION", &c.Auth.ApiKeyCacheTTL))
+	envString("PASSWORD_HASH_ALGORITHM", &c.Auth.PasswordHashAlgorithm)
+	errs = append(errs, envInt("BCRYPT_COST", &c.Auth.BcryptCost))
+	errs = append(errs, envInt("ARGON2_MEMORY_KIB", &c.Auth.Argon2id.MemoryKiB))
+	errs = append(errs, envInt("ARGON2_ITERATIONS", &c.Auth.Argon2id.Iterations))
+	errs = append(errs, envInt("ARGON2_PARALLELISM", &c.Auth.Argon2id.Parallelism))
+
+	envString("JWT_SECRET_KEY", &c.Auth.JWT.SecretKey)
+	envString("JWT_PRIVATE_KEY_FILE", &c.Auth.JWT.PrivateKeyFile)
+	envString("JWT_KEY_ID", &c.Auth.JWT.KeyID)
+	envString("JWT_VERIFICATION_KEYS", &c.Auth.JWT.VerificationKeys)
+	envString("JWT_ISSUER", &c.Auth.JWT.Issuer)
+	envString("JWT_AUDIENCE", &c.Auth.JWT.Audience)
+	errs = append(errs, envDuration("JWT_LEEWAY", &c.Auth.JWT.Leeway))
+	errs = append(errs, envDuration("PASSWORD_RESET_DURATION", &c.Auth.PasswordResetTTL))
+	envString("PASSWORD_RESET_URL", &c.Auth.PasswordResetURL)
+	errs = append(errs, envDuration("EMAIL_VERIFICATION_DURATION", &c.Auth.EmailVerificationTTL))
+	envString("EMAIL_VERIFICATION_URL", &c.Auth.EmailVerificationURL)
+	envString("TOTP_ISSUER", &c.Auth.TOTPIssuer)
+	errs = append(errs, envDuration("TWO_FACTOR_CHALLENGE_DURATION", &c.Auth.TwoFactorChallengeTTL))
+	errs = append(errs, envInt("LOGIN_MAX_FAILURES", &c.Auth.Login.MaxFailures))
+	errs = append(errs, envDuration("LOGIN_FAILURE_WINDOW", &c.Auth.Login.FailureWindow))
+	errs = append(errs, envDuration("LOGIN_LOCKOUT_DURATION", &c.Auth.Login.LockoutDuration))
+	errs = append(errs, envDuration("LOGIN_DELAY_BASE", &c.Auth.Login.BaseDelay))
+	errs = append(errs, envDuration("LOGIN_DELAY_MAX", &c.Auth.Login.MaxDelay))
+	errs = append(errs, envInt("LOGIN_ORIGIN_MAX_FAILURES", &c.Auth.Login.OriginMaxFailures))
+	errs = append(errs, envInt("PASSWORD_MIN_LENGTH", &c.Auth.PasswordPolicy.MinLength))
+	errs = append(errs, envBool("PASSWORD_REQUIRE_UPPER", &c.Auth.PasswordPolicy.RequireUpper))
+	errs = append(errs, envBool("PASSWORD_REQUIRE_LOWER", &c.Auth.PasswordPolicy.RequireLower))
+	errs = append(errs, envBool("PASSWORD_REQUIRE_DIGIT", &c.Auth.PasswordPolicy.RequireDigit))
+	errs = append(errs, envBool("PASSWORD_REQUIRE_SYMBOL", &c.Auth.PasswordPolicy.RequireSymbol))
+	envString("PASSWORD_BREACHED_DIR", &c.Auth.PasswordPolicy.BreachedPasswordsDir)
+
+	envString("MAILER_DRIVER", &c.Mailer.Driver)
+	envString("MAILER_FROM", &c.Mailer.From)
+	envString("MAILER_OUTPUT_DIR", &c.Mailer.OutputDir)
+
+	envString("CASBIN_WATCHER_CHANNEL", &c.Casbin.WatcherChannel)
+	errs = append(errs, envDuration("CASBIN_RELOAD_INTERVAL", &c.Casbin.ReloadInterval))
+
+	return errors.Join(errs...)
+}
+
+// Validate verifica se a configuração é consistente, retornando todos os problemas encontrados.
+func (c *Config) Validate() error {
+	var errs []error
+
+	if c.Server.Addr == "" {
+		errs = append(errs, errors.New("server.addr é obrigatório"))
+	}
+	if c.Database.TimeZone == "" {
+		errs = append(errs, errors.New("database.time_zone é obrigatório"))
+	} else if _, err := time.LoadLocation(c.Database.TimeZone); err != nil {
+		errs = append(errs, fmt.Errorf("database.time_zone inválido: %s", c.Database.TimeZone))
+	}
+	if c.Redis.DB < 0 {
+		errs = append(errs, errors.New("redis.db não pode ser negativo"))
+	}
+	if c.Redis.PoolSize <= 0 {
+		errs = append(errs, errors.New("redis.pool_size deve ser maior que zero"))
+	}
+	if c.Auth.AccessTokenTTL.Duration <= 0 {
+		errs = append(errs, errors.New("auth.access_token_ttl deve ser maior que zero"))
+	}
+	if c.Auth.RefreshTokenTTL.Duration <= c.Auth.AccessTokenTTL.Duration {
+		errs = append(errs, errors.New("auth.refresh_token_ttl deve ser maior que auth.access_token_ttl"))
+	}
+	if c.Auth.ApiKeyCacheTTL.Duration <= 0 {
+		errs = append(errs, errors.New("auth.api_key_cache_ttl deve ser maior que zero"))
+	}
+	if c.Auth.BcryptCost < bcrypt.MinCost || c.Auth.BcryptCost > bcrypt.MaxCost {
+		errs = append(errs, fmt.Errorf("auth.bcrypt_cost deve estar entre %d e %d", bcrypt.MinCost, bcrypt.MaxCost))
+	}
+	switch c.Auth.PasswordHashAlgorithm {
+	case "argon2id":
+		if c.Auth.Argon2id.MemoryKiB < 8*1024 || c.Auth.Argon2id.Iterations < 1 ||
+			c.Auth.Argon2id.Parallelism < 1 || c.Auth.Argon2id.Parallelism > 255 {
+			errs = append(errs, errors.New("auth.argon2id inválido: memory_kib >= 8192, iterations >= 1 e parallelism entre 1 e 255"))
+		}
+	case "bcrypt":
+		// O bcrypt considera apenas os primeiros 72 bytes da senha; um mínimo acima disso recusaria todas.
+		if c.Auth.PasswordPolicy.MinLength > 72 {
+			errs = append(errs, errors.New("auth.password_policy.min_length não pode passar de 72 com o bcrypt"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("auth.password_hash_algorithm inválido: %s (use argon2id ou bcrypt)", c.Auth.PasswordHashAlgorithm))
+	}
+	if c.Auth.JWT.SecretKey == "" && c.Auth.JWT.PrivateKeyFile == "" {
+		errs = append(errs, errors.New("auth.jwt.secret_key ou auth.jwt.private_key_file é obrigatório"))
+	}
+	if c.Auth.JWT.Leeway.Duration < 0 {
+		errs = append(errs, errors.New("auth.jwt.leeway não pode ser negativo"))
+	}
+	if c.Auth.PasswordResetTTL.Duration <= 0 {
+		errs = append(errs, errors.New("auth.password_reset_ttl deve ser maior que zero"))
+	}
+	if c.Auth.EmailVerificationTTL.Duration <= 0 {
+		errs = append(errs, errors.New("auth.email_verification_ttl deve ser maior que zero"))
+	}
+	if c.Auth.TOTPIssuer == "" {
+		errs = append(errs, errors.New("auth.totp_issuer é obrigatório"))
+	}
+	if c.Auth.TwoFactorChallengeTTL.Duration <= 0 {
+		errs = append(errs, errors.New("auth.two_factor_challenge_ttl deve ser maior que zero"))
+	}
+	if c.Auth.Login.MaxFailures <= 0 {
+		errs = append(errs, errors.New("auth.login.max_failures deve ser maior que zero"))
+	}
+	if c.Auth.Login.FailureWindow.Duration <= 0 || c.Auth.Login.LockoutDuration.Duration <= 0 {
+		errs = append(errs, errors.New("auth.login.failure_window e auth.login.lockout_duration devem ser maiores que zero"))
+	}
+	if c.Auth.Login.BaseDelay.Duration <= 0 || c.Auth.Login.MaxDelay.Duration < c.Auth.Login.BaseDelay.Duration {
+		errs = append(errs, errors.New("auth.login.base_delay deve ser maior que zero e menor ou igual a auth.login.max_delay"))
+	}
+	if c.Auth.Login.OriginMaxFailures < 0 {
+		errs = append(errs, errors.New("auth.login.origin_max_failures não pode ser negativo"))
+	}
+	if c.Auth.PasswordPolicy.MinLength < 8 {
+		errs = append(errs, errors.New("auth.password_policy.min_length deve ser pelo menos 8"))
+	}
+	if dir := c.Auth.PasswordPolicy.BreachedPasswordsDir; dir != "" {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			errs = append(errs, fmt.Errorf("auth.password_policy.breached_passwords_dir não é um diretório válido: %s", dir))
+		}
+	}
+	switch c.Mailer.Driver {
+	case "log":
+	case "file":
+		if c.Mailer.OutputDir == "" {
+			errs = append(errs, errors.New("mailer.output_dir é obrigatório para o driver file"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("mailer.driver inválido: %s (use log ou file)", c.Mailer.Driver))
+	}
+	if c.Casbin.WatcherChannel == "" {
+		errs = append(errs, errors.New("casbin.watcher_channel é obrigatório"))
+	}
+	if c.Casbin.ReloadInterval.Duration < 0 {
+		errs = append(errs, errors.New("casbin.reload_interval não pode ser negativo"))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("configuração inválida: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+func envString(key string, target *string) {
+	if value := os.Getenv(key); value != "" {
+		*target = value
+	}
+}
+
+func envInt(key string, target *int) error {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("%s deve ser um número inteiro: %w", key, err)
+	}
+	*target = parsed
+	return nil
+}
+
+func envBool(key string, target *bool) error {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("%s deve ser true ou false: %w", key, err)
+	}
+	*target = parsed
+	return nil
+}
+
+func envDuration(key string, target *Duration) error {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("%s deve ser uma duração válida (ex.: 15m, 24h): %w", key, err)
+	}
+	target.Duration = parsed
+	return nil
+}
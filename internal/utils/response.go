@@ -3,25 +3,62 @@
 package utils
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
+	autherrors "github.com/jeancarlosdanese/go-base-api/internal/domain/auth_errors"
 	"github.com/jeancarlosdanese/go-base-api/internal/logging"
 )
 
 func HandleAuthenticationError(c *gin.Context, err error) {
-	logging.ErrorLogger.Printf("Erro ao autenticar usuário: %v", err)
 	var httpStatus int
-	var errorMsg string
+	var errorMsg, errorCode string
 
-	switch err.Error() {
-	case "usuário ou origem não encontrado", "senha inválida", "invalid credentials":
+	var blocked *autherrors.LoginBlockedError
+	if errors.As(err, &blocked) {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(blocked.RetryAfter.Seconds()))))
+	}
+
+	switch {
+	case errors.Is(err, autherrors.ErrAccountLocked):
+		logging.WarnLogger.Printf("Login recusado: %v", err)
+		httpStatus = http.StatusLocked
+		errorMsg = "Conta temporariamente bloqueada por excesso de tentativas de login"
+	case errors.Is(err, autherrors.ErrTooManyLoginAttempts):
+		logging.WarnLogger.Printf("Login recusado: %v", err)
+		httpStatus = http.StatusTooManyRequests
+		errorMsg = "Muitas tentativas de login, aguarde para tentar novamente"
+	case errors.Is(err, autherrors.ErrTenantSuspended):
+		logging.WarnLogger.Printf("Login recusado: %v", err)
+		httpStatus = http.StatusForbidden
+		errorMsg = "Tenant suspenso"
+		errorCode = "TENANT_SUSPENDED"
+	case errors.Is(err, autherrors.ErrUserPending):
+		logging.InfoLogger.Printf("Login recusado: %v", err)
+		httpStatus = http.StatusForbidden
+		errorMsg = "E-mail ainda não verificado"
+		errorCode = "USER_PENDING"
+	case errors.Is(err, autherrors.ErrUserDisabled):
+		logging.WarnLogger.Printf("Login recusado: %v", err)
+		httpStatus = http.StatusForbidden
+		errorMsg = "Usuário desativado"
+		errorCode = "USER_DISABLED"
+	case errors.Is(err, autherrors.ErrUserOrOriginNotFound), errors.Is(err, autherrors.ErrInvalidPassword):
+		logging.InfoLogger.Printf("Erro ao autenticar usuário: %v", err)
 		httpStatus = http.StatusUnauthorized
 		errorMsg = "Credenciais inválidas"
 	default:
+		logging.ErrorLogger.Printf("Erro ao autenticar usuário: %v", err)
 		httpStatus = http.StatusInternalServerError
 		errorMsg = "Erro interno do servidor"
 	}
 
+	if errorCode != "" {
+		c.JSON(httpStatus, gin.H{"error": errorMsg, "code": errorCode})
+		return
+	}
 	c.JSON(httpStatus, gin.H{"error": errorMsg})
 }
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "public"."users_recovery_codes";
+ALTER TABLE "public"."users"
+    DROP COLUMN IF EXISTS "totp_enabled",
+    DROP COLUMN IF EXISTS "totp_secret";
@@ -0,0 +1,18 @@
+-- Segundo fator (TOTP, RFC 6238)
+ALTER TABLE "public"."users"
+    ADD COLUMN "totp_secret" varchar(64),
+    ADD COLUMN "totp_enabled" boolean NOT NULL DEFAULT false;
+
+-- Table Definition
+CREATE TABLE "public"."users_recovery_codes" (
+    "id" bigserial NOT NULL,
+    "user_id" uuid NOT NULL,
+    "code_hash" varchar(64) NOT NULL,
+    "used_at" timestamptz,
+    "created_at" timestamptz DEFAULT now(),
+    CONSTRAINT "fk_users_recovery_codes_user" FOREIGN KEY ("user_id") REFERENCES "public"."users"("id") ON DELETE CASCADE ON UPDATE RESTRICT,
+    PRIMARY KEY ("id")
+);
+-- Indices
+CREATE INDEX idx_users_recovery_codes_user_id ON public.users_recovery_codes USING btree (user_id);
+CREATE UNIQUE INDEX uni_users_recovery_codes_user_id_code_hash ON public.users_recovery_codes USING btree (user_id, code_hash);
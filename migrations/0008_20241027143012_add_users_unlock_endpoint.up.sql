@@ -0,0 +1,12 @@
+-- Endpoint de desbloqueio de login (POST /api/v1/users/:id/unlock) para os papéis master e admin
+INSERT INTO "public"."endpoints" ("name")
+VALUES ('/api/v1/users/:id/unlock')
+ON CONFLICT ("name") DO NOTHING;
+
+INSERT INTO "public"."policies_roles" ("role_id", "endpoint_id", "actions")
+SELECT roles.id, endpoints.id, 'POST'
+FROM roles
+    CROSS JOIN endpoints
+WHERE roles.name IN ('master', 'admin')
+    AND endpoints.name = '/api/v1/users/:id/unlock'
+ON CONFLICT ("role_id", "endpoint_id") DO NOTHING;
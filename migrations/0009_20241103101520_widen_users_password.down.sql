@@ -0,0 +1,3 @@
+-- Falha se houver hashes Argon2id gravados; redefina essas senhas com bcrypt antes de reverter
+ALTER TABLE "public"."users"
+    ALTER COLUMN "password" TYPE varchar(60);
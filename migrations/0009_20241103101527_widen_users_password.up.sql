@@ -0,0 +1,3 @@
+-- Hashes PHC do Argon2id ($argon2id$v=19$m=...,t=...,p=...$salt$hash) não cabem em varchar(60), tamanho do bcrypt
+ALTER TABLE "public"."users"
+    ALTER COLUMN "password" TYPE varchar(255);
@@ -0,0 +1,34 @@
+DELETE FROM "public"."policies_roles"
+WHERE endpoint_id IN (
+        SELECT id
+        FROM "public"."endpoints"
+        WHERE name IN (
+                '/api/v1/tenants/:id/api-keys',
+                '/api/v1/tenants/:id/api-keys/:key_id',
+                '/api/v1/tenants/:id/api-keys/:key_id/rotate'
+            )
+    );
+
+DELETE FROM "public"."policies_users"
+WHERE endpoint_id IN (
+        SELECT id
+        FROM "public"."endpoints"
+        WHERE name IN (
+                '/api/v1/tenants/:id/api-keys',
+                '/api/v1/tenants/:id/api-keys/:key_id',
+                '/api/v1/tenants/:id/api-keys/:key_id/rotate'
+            )
+    );
+
+DELETE FROM "public"."endpoints"
+WHERE name IN (
+        '/api/v1/tenants/:id/api-keys',
+        '/api/v1/tenants/:id/api-keys/:key_id',
+        '/api/v1/tenants/:id/api-keys/:key_id/rotate'
+    );
+
+-- As chaves em claro não podem ser recuperadas a partir dos hashes; os tenants voltam sem api_key
+ALTER TABLE "public"."tenants" ADD COLUMN IF NOT EXISTS "api_key" varchar(254);
+CREATE UNIQUE INDEX IF NOT EXISTS uni_tenants_api_key ON public.tenants USING btree (api_key) WHERE api_key IS NOT NULL;
+
+DROP TABLE IF EXISTS "public"."api_keys";
@@ -0,0 +1,56 @@
+-- Chaves de API por tenant. Apenas o hash SHA-256 (hex) e um prefixo curto da chave são armazenados.
+CREATE TABLE "public"."api_keys" (
+    "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+    "tenant_id" uuid NOT NULL,
+    "name" varchar(100) NOT NULL,
+    "prefix" varchar(16) NOT NULL,
+    "key_hash" varchar(64) NOT NULL,
+    "scopes" jsonb NOT NULL DEFAULT '[]'::jsonb,
+    "expires_at" timestamptz,
+    "last_used_at" timestamptz,
+    "revoked_at" timestamptz,
+    "created_at" timestamptz DEFAULT now(),
+    "updated_at" timestamptz DEFAULT now(),
+    CONSTRAINT "fk_api_keys_tenant" FOREIGN KEY ("tenant_id") REFERENCES "public"."tenants"("id") ON DELETE CASCADE ON UPDATE RESTRICT,
+    PRIMARY KEY ("id")
+);
+-- Indices
+CREATE UNIQUE INDEX uni_api_keys_key_hash ON public.api_keys USING btree (key_hash);
+CREATE INDEX idx_api_keys_tenant_id ON public.api_keys USING btree (tenant_id);
+
+-- Migra as chaves existentes (em claro) para a nova tabela, guardando apenas o hash
+INSERT INTO "public"."api_keys" ("tenant_id", "name", "prefix", "key_hash")
+SELECT id,
+    'default',
+    left(api_key, 8),
+    encode(sha256(convert_to(api_key, 'UTF8')), 'hex')
+FROM tenants
+WHERE api_key IS NOT NULL;
+
+DROP INDEX IF EXISTS uni_tenants_api_key;
+ALTER TABLE "public"."tenants" DROP COLUMN IF EXISTS "api_key";
+
+-- Endpoints de gestão das chaves (somente master)
+INSERT INTO "public"."endpoints" ("name")
+VALUES ('/api/v1/tenants/:id/api-keys'),
+    ('/api/v1/tenants/:id/api-keys/:key_id'),
+    ('/api/v1/tenants/:id/api-keys/:key_id/rotate')
+ON CONFLICT ("name") DO NOTHING;
+
+INSERT INTO "public"."policies_roles" ("role_id", "endpoint_id", "actions")
+SELECT roles.id,
+    endpoints.id,
+    CASE endpoints.name
+        WHEN '/api/v1/tenants/:id/api-keys' THEN 'GET|POST'
+        WHEN '/api/v1/tenants/:id/api-keys/:key_id' THEN 'GET|PATCH|DELETE'
+        ELSE 'POST'
+    END
+FROM roles
+    CROSS JOIN endpoints
+WHERE roles.name = 'master'
+    AND endpoints.name IN (
+        '/api/v1/tenants/:id/api-keys',
+        '/api/v1/tenants/:id/api-keys/:key_id',
+        '/api/v1/tenants/:id/api-keys/:key_id/rotate'
+    )
+ON CONFLICT ("role_id", "endpoint_id") DO NOTHING;
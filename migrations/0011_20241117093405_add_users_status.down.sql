@@ -0,0 +1,34 @@
+DELETE FROM "public"."policies_roles"
+WHERE endpoint_id IN (
+        SELECT id
+        FROM "public"."endpoints"
+        WHERE name IN (
+                '/api/v1/users/:id/disable',
+                '/api/v1/users/:id/enable',
+                '/api/v1/users/:id/verification-email'
+            )
+    );
+
+DELETE FROM "public"."policies_users"
+WHERE endpoint_id IN (
+        SELECT id
+        FROM "public"."endpoints"
+        WHERE name IN (
+                '/api/v1/users/:id/disable',
+                '/api/v1/users/:id/enable',
+                '/api/v1/users/:id/verification-email'
+            )
+    );
+
+DELETE FROM "public"."endpoints"
+WHERE name IN (
+        '/api/v1/users/:id/disable',
+        '/api/v1/users/:id/enable',
+        '/api/v1/users/:id/verification-email'
+    );
+
+DROP INDEX IF EXISTS "public"."idx_users_status";
+ALTER TABLE "public"."users"
+    DROP COLUMN IF EXISTS "email_verified_at",
+    DROP COLUMN IF EXISTS "status";
+DROP TYPE IF EXISTS "public"."user_status";
@@ -0,0 +1,28 @@
+-- Status do usuário e verificação de e-mail. Os usuários existentes continuam ativos.
+DROP TYPE IF EXISTS "public"."user_status";
+CREATE TYPE "public"."user_status" AS ENUM ('PENDENTE', 'ATIVO', 'DESATIVADO');
+
+ALTER TABLE "public"."users"
+    ADD COLUMN "status" "public"."user_status" NOT NULL DEFAULT 'ATIVO'::user_status,
+    ADD COLUMN "email_verified_at" timestamptz;
+
+CREATE INDEX idx_users_status ON public.users USING btree (status);
+
+-- Endpoints de desativação, reativação e reenvio da verificação de e-mail para os papéis master e admin
+INSERT INTO "public"."endpoints" ("name")
+VALUES ('/api/v1/users/:id/disable'),
+    ('/api/v1/users/:id/enable'),
+    ('/api/v1/users/:id/verification-email')
+ON CONFLICT ("name") DO NOTHING;
+
+INSERT INTO "public"."policies_roles" ("role_id", "endpoint_id", "actions")
+SELECT roles.id, endpoints.id, 'POST'
+FROM roles
+    CROSS JOIN endpoints
+WHERE roles.name IN ('master', 'admin')
+    AND endpoints.name IN (
+        '/api/v1/users/:id/disable',
+        '/api/v1/users/:id/enable',
+        '/api/v1/users/:id/verification-email'
+    )
+ON CONFLICT ("role_id", "endpoint_id") DO NOTHING;
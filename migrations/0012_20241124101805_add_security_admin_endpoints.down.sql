@@ -0,0 +1,49 @@
+DELETE FROM "public"."policies_roles"
+WHERE endpoint_id IN (
+        SELECT id
+        FROM "public"."endpoints"
+        WHERE name IN (
+                '/api/v1/roles',
+                '/api/v1/roles/:id',
+                '/api/v1/roles/:id/policies',
+                '/api/v1/roles/:id/policies/:endpoint_id',
+                '/api/v1/endpoints',
+                '/api/v1/endpoints/:id',
+                '/api/v1/users/:id/roles',
+                '/api/v1/users/:id/roles/:role_id',
+                '/api/v1/users/:id/policies',
+                '/api/v1/users/:id/policies/:endpoint_id'
+            )
+    );
+
+DELETE FROM "public"."policies_users"
+WHERE endpoint_id IN (
+        SELECT id
+        FROM "public"."endpoints"
+        WHERE name IN (
+                '/api/v1/roles',
+                '/api/v1/roles/:id',
+                '/api/v1/roles/:id/policies',
+                '/api/v1/roles/:id/policies/:endpoint_id',
+                '/api/v1/endpoints',
+                '/api/v1/endpoints/:id',
+                '/api/v1/users/:id/roles',
+                '/api/v1/users/:id/roles/:role_id',
+                '/api/v1/users/:id/policies',
+                '/api/v1/users/:id/policies/:endpoint_id'
+            )
+    );
+
+DELETE FROM "public"."endpoints"
+WHERE name IN (
+        '/api/v1/roles',
+        '/api/v1/roles/:id',
+        '/api/v1/roles/:id/policies',
+        '/api/v1/roles/:id/policies/:endpoint_id',
+        '/api/v1/endpoints',
+        '/api/v1/endpoints/:id',
+        '/api/v1/users/:id/roles',
+        '/api/v1/users/:id/roles/:role_id',
+        '/api/v1/users/:id/policies',
+        '/api/v1/users/:id/policies/:endpoint_id'
+    );
@@ -0,0 +1,32 @@
+-- Endpoints da administração de roles, endpoints e políticas, concedidos apenas ao papel master
+INSERT INTO "public"."endpoints" ("name")
+VALUES ('/api/v1/roles'),
+    ('/api/v1/roles/:id'),
+    ('/api/v1/roles/:id/policies'),
+    ('/api/v1/roles/:id/policies/:endpoint_id'),
+    ('/api/v1/endpoints'),
+    ('/api/v1/endpoints/:id'),
+    ('/api/v1/users/:id/roles'),
+    ('/api/v1/users/:id/roles/:role_id'),
+    ('/api/v1/users/:id/policies'),
+    ('/api/v1/users/:id/policies/:endpoint_id')
+ON CONFLICT ("name") DO NOTHING;
+
+INSERT INTO "public"."policies_roles" ("role_id", "endpoint_id", "actions")
+SELECT roles.id, endpoints.id, grants.actions
+FROM (
+        VALUES ('/api/v1/roles', 'GET|POST'),
+            ('/api/v1/roles/:id', 'GET|PUT|DELETE'),
+            ('/api/v1/roles/:id/policies', 'GET|POST'),
+            ('/api/v1/roles/:id/policies/:endpoint_id', 'PUT|DELETE'),
+            ('/api/v1/endpoints', 'GET|POST'),
+            ('/api/v1/endpoints/:id', 'GET|PUT|DELETE'),
+            ('/api/v1/users/:id/roles', 'GET|POST'),
+            ('/api/v1/users/:id/roles/:role_id', 'DELETE'),
+            ('/api/v1/users/:id/policies', 'GET|POST'),
+            ('/api/v1/users/:id/policies/:endpoint_id', 'PUT|DELETE')
+    ) AS grants (name, actions)
+    INNER JOIN endpoints ON endpoints.name = grants.name
+    CROSS JOIN roles
+WHERE roles.name = 'master'
+ON CONFLICT ("role_id", "endpoint_id") DO NOTHING;
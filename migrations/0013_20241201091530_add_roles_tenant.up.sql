@@ -0,0 +1,86 @@
+-- Roles por tenant (RBAC com domínios): o tenant é o domínio do Casbin. Roles sem tenant são globais
+-- (ex.: master e admin) e valem em todos os tenants.
+ALTER TABLE "public"."roles"
+    ADD COLUMN "tenant_id" uuid,
+    ADD CONSTRAINT "fk_roles_tenant" FOREIGN KEY ("tenant_id") REFERENCES "public"."tenants"("id") ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+-- O nome é único entre os roles globais e dentro de cada tenant
+DROP INDEX IF EXISTS "public"."uni_roles_name";
+CREATE UNIQUE INDEX uni_roles_global_name ON public.roles USING btree (name)
+WHERE tenant_id IS NULL;
+CREATE UNIQUE INDEX uni_roles_tenant_name ON public.roles USING btree (tenant_id, name)
+WHERE tenant_id IS NOT NULL;
+
+-- id	ptype	v0				v1			v2					v3				v4		v5
+-- 1	p		admin			*			/api/v1/users		GET|POST		NULL	NULL
+-- 2	p		vendas			<tenant>	/api/v1/users/:id	GET				NULL	NULL
+-- 3	g		<user>			admin		*					NULL			NULL	NULL
+-- Políticas de roles globais e atribuições de roles globais usam o domínio '*'.
+DROP VIEW IF EXISTS "public"."casbin_rules_view";
+CREATE VIEW casbin_rules_view AS WITH policies AS (
+        SELECT 'p' AS ptype,
+            roles.name AS v0,
+            COALESCE(roles.tenant_id::VARCHAR(36), '*') AS v1,
+            endpoints.name AS v2,
+            policies_roles.actions AS v3
+        FROM policies_roles
+            INNER JOIN roles ON policies_roles.role_id = roles.id
+            INNER JOIN endpoints ON policies_roles.endpoint_id = endpoints.id
+        UNION
+        SELECT 'p' AS ptype,
+            users.id::VARCHAR(36) AS v0,
+            users.tenant_id::VARCHAR(36) AS v1,
+            endpoints.name AS v2,
+            policies_users.actions AS v3
+        FROM policies_users
+            INNER JOIN users ON policies_users.user_id = users.id
+            INNER JOIN endpoints ON policies_users.endpoint_id = endpoints.id
+        UNION
+        SELECT 'g' AS ptype,
+            users.id::VARCHAR(36) AS v0,
+            roles.name AS v1,
+            COALESCE(roles.tenant_id::VARCHAR(36), '*') AS v2,
+            NULL AS v3
+        FROM users_roles
+            INNER JOIN users ON users_roles.user_id = users.id
+            INNER JOIN roles ON users_roles.role_id = roles.id
+    )
+SELECT ROW_NUMBER() OVER (
+        ORDER BY ptype DESC,
+            v0,
+            v1,
+            v2
+    ) AS id,
+    ptype,
+    v0,
+    v1,
+    v2,
+    v3,
+    NULL AS v4,
+    NULL AS v5
+FROM policies
+ORDER BY ptype DESC,
+    v0,
+    v1,
+    v2;
+
+-- O role admin (global) administra os roles e as permissões do próprio tenant
+INSERT INTO "public"."policies_roles" ("role_id", "endpoint_id", "actions")
+SELECT roles.id, endpoints.id, grants.actions
+FROM (
+        VALUES ('/api/v1/roles', 'GET|POST'),
+            ('/api/v1/roles/:id', 'GET|PUT|DELETE'),
+            ('/api/v1/roles/:id/policies', 'GET|POST'),
+            ('/api/v1/roles/:id/policies/:endpoint_id', 'PUT|DELETE'),
+            ('/api/v1/endpoints', 'GET'),
+            ('/api/v1/endpoints/:id', 'GET'),
+            ('/api/v1/users/:id/roles', 'GET|POST'),
+            ('/api/v1/users/:id/roles/:role_id', 'DELETE'),
+            ('/api/v1/users/:id/policies', 'GET|POST'),
+            ('/api/v1/users/:id/policies/:endpoint_id', 'PUT|DELETE')
+    ) AS grants (name, actions)
+    INNER JOIN endpoints ON endpoints.name = grants.name
+    CROSS JOIN roles
+WHERE roles.name = 'admin'
+    AND roles.tenant_id IS NULL
+ON CONFLICT ("role_id", "endpoint_id") DO NOTHING;
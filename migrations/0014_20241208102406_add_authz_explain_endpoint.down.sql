@@ -0,0 +1,16 @@
+DELETE FROM "public"."policies_roles"
+WHERE endpoint_id IN (
+        SELECT id
+        FROM "public"."endpoints"
+        WHERE name = '/api/v1/authz/explain'
+    );
+
+DELETE FROM "public"."policies_users"
+WHERE endpoint_id IN (
+        SELECT id
+        FROM "public"."endpoints"
+        WHERE name = '/api/v1/authz/explain'
+    );
+
+DELETE FROM "public"."endpoints"
+WHERE name = '/api/v1/authz/explain';
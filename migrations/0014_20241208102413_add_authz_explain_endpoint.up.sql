@@ -0,0 +1,13 @@
+-- Endpoint de diagnóstico de permissões (POST /api/v1/authz/explain) para os papéis globais master e admin
+INSERT INTO "public"."endpoints" ("name")
+VALUES ('/api/v1/authz/explain')
+ON CONFLICT ("name") DO NOTHING;
+
+INSERT INTO "public"."policies_roles" ("role_id", "endpoint_id", "actions")
+SELECT roles.id, endpoints.id, 'POST'
+FROM roles
+    CROSS JOIN endpoints
+WHERE roles.name IN ('master', 'admin')
+    AND roles.tenant_id IS NULL
+    AND endpoints.name = '/api/v1/authz/explain'
+ON CONFLICT ("role_id", "endpoint_id") DO NOTHING;
@@ -0,0 +1,50 @@
+DROP VIEW IF EXISTS "public"."casbin_rules_view";
+CREATE VIEW casbin_rules_view AS WITH policies AS (
+        SELECT 'p' AS ptype,
+            roles.name AS v0,
+            COALESCE(roles.tenant_id::VARCHAR(36), '*') AS v1,
+            endpoints.name AS v2,
+            policies_roles.actions AS v3
+        FROM policies_roles
+            INNER JOIN roles ON policies_roles.role_id = roles.id
+            INNER JOIN endpoints ON policies_roles.endpoint_id = endpoints.id
+        UNION
+        SELECT 'p' AS ptype,
+            users.id::VARCHAR(36) AS v0,
+            users.tenant_id::VARCHAR(36) AS v1,
+            endpoints.name AS v2,
+            policies_users.actions AS v3
+        FROM policies_users
+            INNER JOIN users ON policies_users.user_id = users.id
+            INNER JOIN endpoints ON policies_users.endpoint_id = endpoints.id
+        UNION
+        SELECT 'g' AS ptype,
+            users.id::VARCHAR(36) AS v0,
+            roles.name AS v1,
+            COALESCE(roles.tenant_id::VARCHAR(36), '*') AS v2,
+            NULL AS v3
+        FROM users_roles
+            INNER JOIN users ON users_roles.user_id = users.id
+            INNER JOIN roles ON users_roles.role_id = roles.id
+    )
+SELECT ROW_NUMBER() OVER (
+        ORDER BY ptype DESC,
+            v0,
+            v1,
+            v2
+    ) AS id,
+    ptype,
+    v0,
+    v1,
+    v2,
+    v3,
+    NULL AS v4,
+    NULL AS v5
+FROM policies
+ORDER BY ptype DESC,
+    v0,
+    v1,
+    v2;
+
+ALTER TABLE "public"."policies_users" DROP COLUMN "condition";
+ALTER TABLE "public"."policies_roles" DROP COLUMN "condition";
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "public"."idx_tenants_name";
+DROP INDEX IF EXISTS "public"."idx_users_tenant_id_created_at";
+DROP INDEX IF EXISTS "public"."idx_users_tenant_id_name";
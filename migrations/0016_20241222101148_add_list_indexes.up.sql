@@ -0,0 +1,4 @@
+-- Índices para a ordenação padrão e a paginação por cursor das listagens (o id desempata a ordenação)
+CREATE INDEX idx_users_tenant_id_name ON public.users USING btree (tenant_id, name, id);
+CREATE INDEX idx_users_tenant_id_created_at ON public.users USING btree (tenant_id, created_at, id);
+CREATE INDEX idx_tenants_name ON public.tenants USING btree (name, id);
@@ -0,0 +1,30 @@
+DELETE FROM "public"."policies_roles"
+WHERE endpoint_id IN (
+        SELECT id
+        FROM "public"."endpoints"
+        WHERE name IN ('/api/v1/tenants/search', '/api/v1/users/search')
+    );
+
+DELETE FROM "public"."policies_users"
+WHERE endpoint_id IN (
+        SELECT id
+        FROM "public"."endpoints"
+        WHERE name IN ('/api/v1/tenants/search', '/api/v1/users/search')
+    );
+
+DELETE FROM "public"."endpoints"
+WHERE name IN ('/api/v1/tenants/search', '/api/v1/users/search');
+
+DROP INDEX IF EXISTS "public"."idx_users_search_text";
+DROP INDEX IF EXISTS "public"."idx_users_search_vector";
+ALTER TABLE "public"."users"
+    DROP COLUMN IF EXISTS "search_text",
+    DROP COLUMN IF EXISTS "search_vector";
+
+DROP INDEX IF EXISTS "public"."idx_tenants_search_text";
+DROP INDEX IF EXISTS "public"."idx_tenants_search_vector";
+ALTER TABLE "public"."tenants"
+    DROP COLUMN IF EXISTS "search_text",
+    DROP COLUMN IF EXISTS "search_vector";
+
+DROP FUNCTION IF EXISTS public.immutable_unaccent(text);
@@ -0,0 +1,60 @@
+-- Busca textual de tenants e usuários: tsvector gerado (sem acentos, sem stemming, pois são nomes)
+-- e texto para similaridade por trigramas, que cobre erros de digitação
+CREATE EXTENSION IF NOT EXISTS unaccent;
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- unaccent() não é IMMUTABLE e por isso não pode ser usada em colunas geradas
+CREATE OR REPLACE FUNCTION public.immutable_unaccent(text) RETURNS text
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT
+    AS $$ SELECT public.unaccent('public.unaccent'::regdictionary, $1) $$;
+
+ALTER TABLE "public"."tenants"
+    ADD COLUMN "search_vector" tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', public.immutable_unaccent(coalesce(name, ''))), 'A') ||
+        setweight(to_tsvector('simple', regexp_replace(coalesce(cpf_cnpj, ''), '\D', '', 'g')), 'A') ||
+        setweight(to_tsvector('simple', public.immutable_unaccent(coalesce(city, ''))), 'B') ||
+        setweight(to_tsvector('simple', translate(coalesce(email, ''), '@.', '  ')), 'C')
+    ) STORED,
+    ADD COLUMN "search_text" text GENERATED ALWAYS AS (
+        public.immutable_unaccent(lower(coalesce(name, '') || ' ' || coalesce(city, '')))
+    ) STORED;
+
+CREATE INDEX idx_tenants_search_vector ON public.tenants USING gin (search_vector);
+CREATE INDEX idx_tenants_search_text ON public.tenants USING gin (search_text gin_trgm_ops);
+
+ALTER TABLE "public"."users"
+    ADD COLUMN "search_vector" tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', public.immutable_unaccent(name)), 'A') ||
+        setweight(to_tsvector('simple', public.immutable_unaccent(username)), 'A') ||
+        setweight(to_tsvector('simple', translate(email, '@.', '  ')), 'B')
+    ) STORED,
+    ADD COLUMN "search_text" text GENERATED ALWAYS AS (
+        public.immutable_unaccent(lower(name || ' ' || username))
+    ) STORED;
+
+CREATE INDEX idx_users_search_vector ON public.users USING gin (search_vector);
+CREATE INDEX idx_users_search_text ON public.users USING gin (search_text gin_trgm_ops);
+
+-- Quem pode listar (GET) tenants ou usuários também pode buscá-los
+INSERT INTO "public"."endpoints" ("name")
+VALUES ('/api/v1/tenants/search'),
+    ('/api/v1/users/search')
+ON CONFLICT ("name") DO NOTHING;
+
+INSERT INTO "public"."policies_roles" ("role_id", "endpoint_id", "actions", "condition")
+SELECT policies_roles.role_id, search.id, 'GET', policies_roles.condition
+FROM policies_roles
+    INNER JOIN endpoints ON policies_roles.endpoint_id = endpoints.id
+    INNER JOIN endpoints search ON search.name = endpoints.name || '/search'
+WHERE endpoints.name IN ('/api/v1/tenants', '/api/v1/users')
+    AND 'GET' = ANY (string_to_array(policies_roles.actions, '|'))
+ON CONFLICT ("role_id", "endpoint_id") DO NOTHING;
+
+INSERT INTO "public"."policies_users" ("user_id", "endpoint_id", "actions", "condition")
+SELECT policies_users.user_id, search.id, 'GET', policies_users.condition
+FROM policies_users
+    INNER JOIN endpoints ON policies_users.endpoint_id = endpoints.id
+    INNER JOIN endpoints search ON search.name = endpoints.name || '/search'
+WHERE endpoints.name IN ('/api/v1/tenants', '/api/v1/users')
+    AND 'GET' = ANY (string_to_array(policies_users.actions, '|'))
+ON CONFLICT ("user_id", "endpoint_id") DO NOTHING;
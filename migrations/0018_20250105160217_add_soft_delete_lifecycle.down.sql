@@ -0,0 +1,48 @@
+DELETE FROM "public"."policies_roles"
+WHERE endpoint_id IN (
+        SELECT id
+        FROM "public"."endpoints"
+        WHERE name IN (
+                '/api/v1/tenants/trash',
+                '/api/v1/tenants/:id/restore',
+                '/api/v1/tenants/:id/purge',
+                '/api/v1/users/trash',
+                '/api/v1/users/:id/restore',
+                '/api/v1/users/:id/purge'
+            )
+    );
+
+DELETE FROM "public"."policies_users"
+WHERE endpoint_id IN (
+        SELECT id
+        FROM "public"."endpoints"
+        WHERE name IN (
+                '/api/v1/tenants/trash',
+                '/api/v1/tenants/:id/restore',
+                '/api/v1/tenants/:id/purge',
+                '/api/v1/users/trash',
+                '/api/v1/users/:id/restore',
+                '/api/v1/users/:id/purge'
+            )
+    );
+
+DELETE FROM "public"."endpoints"
+WHERE name IN (
+        '/api/v1/tenants/trash',
+        '/api/v1/tenants/:id/restore',
+        '/api/v1/tenants/:id/purge',
+        '/api/v1/users/trash',
+        '/api/v1/users/:id/restore',
+        '/api/v1/users/:id/purge'
+    );
+
+-- Falha se um registro removido tiver os mesmos dados únicos de um ativo; remova-o definitivamente antes
+DROP INDEX IF EXISTS "public"."uni_users_tenant_id_username";
+DROP INDEX IF EXISTS "public"."uni_users_tenant_id_email";
+CREATE UNIQUE INDEX uni_users_tenant_id_email ON public.users USING btree (tenant_id, email);
+CREATE UNIQUE INDEX uni_users_tenant_id_username ON public.users USING btree (tenant_id, username);
+
+DROP INDEX IF EXISTS "public"."uni_tenants_email";
+DROP INDEX IF EXISTS "public"."uni_tenants_cpf_cnpj";
+CREATE UNIQUE INDEX uni_tenants_cpf_cnpj ON public.tenants USING btree (cpf_cnpj) WHERE cpf_cnpj IS NOT NULL;
+CREATE UNIQUE INDEX uni_tenants_email ON public.tenants USING btree (email) WHERE email IS NOT NULL;
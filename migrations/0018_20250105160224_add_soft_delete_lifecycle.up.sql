@@ -0,0 +1,53 @@
+-- Os índices únicos passam a valer apenas para os registros ativos, para que os removidos (lixeira)
+-- não impeçam o reuso de CPF/CNPJ, e-mail e username
+DROP INDEX IF EXISTS "public"."uni_tenants_cpf_cnpj";
+DROP INDEX IF EXISTS "public"."uni_tenants_email";
+CREATE UNIQUE INDEX uni_tenants_cpf_cnpj ON public.tenants USING btree (cpf_cnpj) WHERE cpf_cnpj IS NOT NULL AND deleted_at IS NULL;
+CREATE UNIQUE INDEX uni_tenants_email ON public.tenants USING btree (email) WHERE email IS NOT NULL AND deleted_at IS NULL;
+
+DROP INDEX IF EXISTS "public"."uni_users_tenant_id_email";
+DROP INDEX IF EXISTS "public"."uni_users_tenant_id_username";
+CREATE UNIQUE INDEX uni_users_tenant_id_email ON public.users USING btree (tenant_id, email) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX uni_users_tenant_id_username ON public.users USING btree (tenant_id, username) WHERE deleted_at IS NULL;
+
+-- Lixeira, restauração e remoção definitiva (DELETE ?hard=true é autorizado em .../:id/purge):
+-- tenants somente para o master; usuários para os papéis globais master e admin
+INSERT INTO "public"."endpoints" ("name")
+VALUES ('/api/v1/tenants/trash'),
+    ('/api/v1/tenants/:id/restore'),
+    ('/api/v1/tenants/:id/purge'),
+    ('/api/v1/users/trash'),
+    ('/api/v1/users/:id/restore'),
+    ('/api/v1/users/:id/purge')
+ON CONFLICT ("name") DO NOTHING;
+
+INSERT INTO "public"."policies_roles" ("role_id", "endpoint_id", "actions")
+SELECT roles.id,
+    endpoints.id,
+    CASE
+        WHEN endpoints.name LIKE '%/trash' THEN 'GET'
+        WHEN endpoints.name LIKE '%/restore' THEN 'POST'
+        ELSE 'DELETE'
+    END
+FROM roles
+    CROSS JOIN endpoints
+WHERE roles.tenant_id IS NULL
+    AND (
+        (
+            roles.name = 'master'
+            AND endpoints.name IN (
+                '/api/v1/tenants/trash',
+                '/api/v1/tenants/:id/restore',
+                '/api/v1/tenants/:id/purge'
+            )
+        )
+        OR (
+            roles.name IN ('master', 'admin')
+            AND endpoints.name IN (
+                '/api/v1/users/trash',
+                '/api/v1/users/:id/restore',
+                '/api/v1/users/:id/purge'
+            )
+        )
+    )
+ON CONFLICT ("role_id", "endpoint_id") DO NOTHING;
@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS users_increment_version ON "public"."users";
+DROP TRIGGER IF EXISTS tenants_increment_version ON "public"."tenants";
+DROP FUNCTION IF EXISTS public.increment_row_version();
+
+ALTER TABLE "public"."users" DROP COLUMN IF EXISTS "version";
+ALTER TABLE "public"."tenants" DROP COLUMN IF EXISTS "version";
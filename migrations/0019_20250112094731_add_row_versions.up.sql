@@ -0,0 +1,16 @@
+-- Versão dos registros para o controle de concorrência otimista (ETag / If-Match). O trigger incrementa a
+-- versão em todo UPDATE, inclusive nos feitos fora do repositório genérico (status, senha, exclusão lógica)
+ALTER TABLE "public"."tenants" ADD COLUMN "version" integer NOT NULL DEFAULT 1;
+ALTER TABLE "public"."users" ADD COLUMN "version" integer NOT NULL DEFAULT 1;
+
+CREATE OR REPLACE FUNCTION public.increment_row_version() RETURNS trigger AS $$
+BEGIN
+    NEW.version := OLD.version + 1;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tenants_increment_version BEFORE UPDATE ON "public"."tenants"
+    FOR EACH ROW EXECUTE FUNCTION public.increment_row_version();
+CREATE TRIGGER users_increment_version BEFORE UPDATE ON "public"."users"
+    FOR EACH ROW EXECUTE FUNCTION public.increment_row_version();
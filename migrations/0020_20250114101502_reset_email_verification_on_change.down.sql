@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS users_reset_email_verification ON "public"."users";
+DROP FUNCTION IF EXISTS public.reset_email_verification();
@@ -0,0 +1,13 @@
+-- A verificação vale para o e-mail verificado: ao trocar o e-mail, o usuário precisa verificá-lo de novo.
+-- Feito no banco para valer em qualquer UPDATE, inclusive no PUT, em que o GORM ignora campos nulos
+CREATE OR REPLACE FUNCTION public.reset_email_verification() RETURNS trigger AS $$
+BEGIN
+    IF NEW.email IS DISTINCT FROM OLD.email THEN
+        NEW.email_verified_at := NULL;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER users_reset_email_verification BEFORE UPDATE OF email ON "public"."users"
+    FOR EACH ROW EXECUTE FUNCTION public.reset_email_verification();
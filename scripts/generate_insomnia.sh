@@ -300,7 +300,7 @@ environments:
     isPrivate: false
   data:
     base_url: http://localhost:5001
-    api_key: VER_NO_DB
+    api_key: CRIAR_EM_TENANTS_API_KEYS
     origin: localhost
     auth_token: TOKEN_JWT
     refresh_token: REFRESH_TOKEN
@@ -42,3 +42,13 @@ func TestTenant_Validation(t *testing.T) {
 	assert.Nil(t, tenant.Phone, "Phone deve ser vazio inicialmente")
 	assert.Nil(t, tenant.CellPhone, "CellPhone deve ser vazio inicialmente")
 }
+
+func TestTenant_Origins(t *testing.T) {
+	allowedOrigins := datatypes.JSON([]byte(`["app.example.com", "localhost"]`))
+	tenant := models.Tenant{AllowedOrigins: &allowedOrigins}
+	assert.Equal(t, []string{"app.example.com", "localhost"}, tenant.Origins())
+
+	invalid := datatypes.JSON([]byte(`{"origin": "localhost"}`))
+	assert.Empty(t, (&models.Tenant{AllowedOrigins: &invalid}).Origins())
+	assert.Empty(t, (&models.Tenant{}).Origins())
+}
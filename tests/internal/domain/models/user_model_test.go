@@ -55,3 +55,18 @@ func TestUser_ExtractPolicies(t *testing.T) {
 	assert.Contains(t, extractedPolicies, "/api/v1/resource2:POST")
 	assert.Len(t, extractedPolicies, 2)
 }
+
+func TestNewPermissionMatrix(t *testing.T) {
+	matrix := models.NewPermissionMatrix([]string{
+		"/api/v1/users:GET|POST",
+		"/api/v1/users:DELETE|GET",
+		"/api/v1/users/:id/roles/:role_id:DELETE",
+		"invalida",
+	})
+
+	assert.Equal(t, []models.EndpointPermission{
+		{Endpoint: "/api/v1/users", Actions: []string{"GET", "POST", "DELETE"}},
+		{Endpoint: "/api/v1/users/:id/roles/:role_id", Actions: []string{"DELETE"}},
+	}, matrix)
+	assert.Empty(t, models.NewPermissionMatrix(nil))
+}
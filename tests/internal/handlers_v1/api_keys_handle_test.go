@@ -0,0 +1,118 @@
+// tests/internal/handlers_v1/api_keys_handle_test.go
+
+package handlers_v1_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/handlers_v1"
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+	"github.com/jeancarlosdanese/go-base-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func setupApiKeysRouter(t *testing.T) (*gin.Engine, *mocks.MockTenantRepository, *mocks.MockApiKeyRepository, *mocks.RedisService) {
+	tenantRepo := new(mocks.MockTenantRepository)
+	apiKeyRepo := new(mocks.MockApiKeyRepository)
+	redisService := mocks.NewRedisService(t)
+	apiKeyService := services.NewApiKeyService(apiKeyRepo, redisService)
+	handler := handlers_v1.NewApiKeysHandler(services.NewTenantService(tenantRepo, apiKeyService, mocks.NewTenantStatusService(t)), apiKeyService)
+
+	router := gin.New()
+	handler.RegisterRoutes(router.Group("/tenants"))
+	return router, tenantRepo, apiKeyRepo, redisService
+}
+
+func TestApiKeysHandler_Create(t *testing.T) {
+	t.Run("Retorna a chave em claro uma única vez", func(t *testing.T) {
+		router, tenantRepo, apiKeyRepo, _ := setupApiKeysRouter(t)
+		tenantID := uuid.New()
+		tenantRepo.On("GetByID", mock.Anything, tenantID).Return(&models.Tenant{BaseModel: models.BaseModel{ID: tenantID}}, nil)
+		apiKeyRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.ApiKey")).Return(func(_ *gin.Context, key *models.ApiKey) *models.ApiKey {
+			key.ID = uuid.New()
+			return key
+		}, nil)
+
+		body, _ := json.Marshal(models.ApiKeyCreate{Name: "erp", Scopes: []string{"orders:read"}})
+		req := httptest.NewRequest(http.MethodPost, "/tenants/"+tenantID.String()+"/api-keys", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var response map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.NotEmpty(t, response["key"])
+		assert.NotEmpty(t, response["prefix"])
+		assert.NotContains(t, response, "key_hash")
+		apiKeyRepo.AssertExpectations(t)
+	})
+
+	t.Run("Tenant inexistente", func(t *testing.T) {
+		router, tenantRepo, apiKeyRepo, _ := setupApiKeysRouter(t)
+		tenantID := uuid.New()
+		tenantRepo.On("GetByID", mock.Anything, tenantID).Return((*models.Tenant)(nil), gorm.ErrRecordNotFound)
+
+		req := httptest.NewRequest(http.MethodPost, "/tenants/"+tenantID.String()+"/api-keys", bytes.NewBufferString(`{"name":"erp"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		apiKeyRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestApiKeysHandler_GetAll(t *testing.T) {
+	router, tenantRepo, apiKeyRepo, _ := setupApiKeysRouter(t)
+	tenantID := uuid.New()
+	tenantRepo.On("GetByID", mock.Anything, tenantID).Return(&models.Tenant{BaseModel: models.BaseModel{ID: tenantID}}, nil)
+	apiKeyRepo.On("FindApiKeysByTenant", mock.Anything, tenantID).Return([]models.ApiKey{
+		{ID: uuid.New(), TenantID: tenantID, Name: "erp", Prefix: "abcdefgh", KeyHash: "secret-hash"},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/tenants/"+tenantID.String()+"/api-keys", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "secret-hash")
+	var response []models.ApiKey
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response, 1)
+	assert.Equal(t, "abcdefgh", response[0].Prefix)
+}
+
+func TestApiKeysHandler_Rotate(t *testing.T) {
+	router, tenantRepo, apiKeyRepo, redisService := setupApiKeysRouter(t)
+	tenantID := uuid.New()
+	oldKey := &models.ApiKey{ID: uuid.New(), TenantID: tenantID, Name: "erp", Prefix: "abcdefgh", KeyHash: "old-hash"}
+	tenantRepo.On("GetByID", mock.Anything, tenantID).Return(&models.Tenant{BaseModel: models.BaseModel{ID: tenantID}}, nil)
+	apiKeyRepo.On("FindApiKeyByTenant", mock.Anything, tenantID, oldKey.ID).Return(oldKey, nil)
+	apiKeyRepo.On("RotateApiKey", mock.Anything, oldKey, mock.AnythingOfType("*models.ApiKey"), mock.MatchedBy(func(validUntil time.Time) bool {
+		return validUntil.After(time.Now().Add(59 * time.Minute))
+	})).Return(nil)
+	expectApiKeyCacheInvalidation(redisService, tenantID)
+
+	req := httptest.NewRequest(http.MethodPost, "/tenants/"+tenantID.String()+"/api-keys/"+oldKey.ID.String()+"/rotate", bytes.NewBufferString(`{"grace_period":3600}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response models.ApiKeyCreated
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.NotEmpty(t, response.Key)
+	assert.Equal(t, "erp", response.Name)
+	apiKeyRepo.AssertExpectations(t)
+}
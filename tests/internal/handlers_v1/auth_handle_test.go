@@ -11,8 +11,11 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	autherrors "github.com/jeancarlosdanese/go-base-api/internal/domain/auth_errors"
+	contextkeys "github.com/jeancarlosdanese/go-base-api/internal/domain/context_keys"
 	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
 	"github.com/jeancarlosdanese/go-base-api/internal/handlers_v1"
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
 	"github.com/jeancarlosdanese/go-base-api/tests/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -22,7 +25,9 @@ func TestAuthHandler_Login(t *testing.T) {
 	mockUserService := mocks.NewUserService(t)
 	mockTokenService := mocks.NewTokenService(t)
 	mockTokenRedisService := mocks.NewTokenRedisService(t)
-	handler := handlers_v1.NewAuthHandler(mockUserService, mockTokenService, mockTokenRedisService)
+	mockLoginAttempts := mocks.NewLoginAttemptService(t)
+	mockTenantStatus := mocks.NewTenantStatusService(t)
+	handler := handlers_v1.NewAuthHandler(mockUserService, mockTokenService, mockTokenRedisService, mocks.NewTwoFactorService(t), mockLoginAttempts, mockTenantStatus)
 
 	t.Run("successful login", func(t *testing.T) {
 		userID := uuid.New()
@@ -33,10 +38,17 @@ func TestAuthHandler_Login(t *testing.T) {
 			Email:     "john@example.com",
 		}
 
+		mockLoginAttempts.On("Check", "john@example.com", "localhost", "192.0.2.1").Return(nil)
+		mockLoginAttempts.On("Reset", "john@example.com", "localhost").Return(nil)
 		mockUserService.On("Authenticate", mock.Anything, "john@example.com", "password123", "localhost").Return(user, nil)
 		mockTokenService.On("GetAccessDuration").Return(time.Hour * 24) // Adicionando esta linha
-		mockTokenService.On("CreateTokens", user.ID, mock.Anything, mock.Anything).Return("access-token", "refresh-token", nil)
-		mockTokenRedisService.On("SaveUserRedis", user, "access-token", "refresh-token", mock.AnythingOfType("time.Duration")).Return(nil)
+		mockTokenService.On("GetRefreshDuration").Return(time.Hour * 24 * 90)
+		mockTokenService.On("CreateTokens", mock.MatchedBy(func(subject services.TokenSubject) bool {
+			return subject.UserID == user.ID && subject.FamilyID != ""
+		})).Return("access-token", "refresh-token", nil)
+		mockTokenRedisService.On("SaveUserRedis", user, mock.MatchedBy(func(session *models.UserSession) bool {
+			return session.ID != "" && session.AccessToken == "access-token" && session.RefreshToken == "refresh-token"
+		}), mock.AnythingOfType("time.Duration"), mock.AnythingOfType("time.Duration")).Return(nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
@@ -60,7 +72,8 @@ func TestAuthHandler_Login(t *testing.T) {
 	})
 
 	t.Run("invalid login credentials", func(t *testing.T) {
-		mockUserService.On("Authenticate", mock.Anything, "john@example.com", "wrongpassword", "localhost").Return(nil, errors.New("invalid credentials"))
+		mockUserService.On("Authenticate", mock.Anything, "john@example.com", "wrongpassword", "localhost").Return(nil, autherrors.ErrInvalidPassword).Once()
+		mockLoginAttempts.On("RegisterFailure", "john@example.com", "localhost", "192.0.2.1").Return(nil).Once()
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
@@ -82,19 +95,146 @@ func TestAuthHandler_Login(t *testing.T) {
 		mockTokenRedisService.AssertExpectations(t)
 	})
 
+	t.Run("failure that locks the account", func(t *testing.T) {
+		mockUserService.On("Authenticate", mock.Anything, "john@example.com", "wrongpassword", "localhost").Return(nil, autherrors.ErrInvalidPassword).Once()
+		mockLoginAttempts.On("RegisterFailure", "john@example.com", "localhost", "192.0.2.1").
+			Return(&autherrors.LoginBlockedError{Err: autherrors.ErrAccountLocked, RetryAfter: 15 * time.Minute}).Once()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("Origin", "localhost")
+		body := `{"email":"john@example.com","password":"wrongpassword"}`
+		c.Request = httptest.NewRequest("POST", "/login", bytes.NewBufferString(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.Login(c)
+
+		assert.Equal(t, http.StatusLocked, w.Code)
+		assert.Equal(t, "900", w.Header().Get("Retry-After"))
+	})
+
+	t.Run("locked account does not check the password", func(t *testing.T) {
+		mockLoginAttempts.On("Check", "locked@example.com", "localhost", "192.0.2.1").
+			Return(&autherrors.LoginBlockedError{Err: autherrors.ErrAccountLocked, RetryAfter: 90 * time.Second})
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("Origin", "localhost")
+		body := `{"email":"locked@example.com","password":"password123"}`
+		c.Request = httptest.NewRequest("POST", "/login", bytes.NewBufferString(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.Login(c)
+
+		assert.Equal(t, http.StatusLocked, w.Code)
+		assert.Equal(t, "90", w.Header().Get("Retry-After"))
+		mockUserService.AssertNotCalled(t, "Authenticate", mock.Anything, "locked@example.com", mock.Anything, mock.Anything)
+	})
+
+	t.Run("progressive delay", func(t *testing.T) {
+		mockLoginAttempts.On("Check", "slow@example.com", "localhost", "192.0.2.1").
+			Return(&autherrors.LoginBlockedError{Err: autherrors.ErrTooManyLoginAttempts, RetryAfter: 1500 * time.Millisecond})
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("Origin", "localhost")
+		body := `{"email":"slow@example.com","password":"password123"}`
+		c.Request = httptest.NewRequest("POST", "/login", bytes.NewBufferString(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.Login(c)
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "2", w.Header().Get("Retry-After"))
+	})
+
+	t.Run("suspended tenant is not a login failure", func(t *testing.T) {
+		mockLoginAttempts.On("Check", "suspended@example.com", "localhost", "192.0.2.1").Return(nil)
+		mockUserService.On("Authenticate", mock.Anything, "suspended@example.com", "password123", "localhost").Return(nil, autherrors.ErrTenantSuspended)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("Origin", "localhost")
+		body := `{"email":"suspended@example.com","password":"password123"}`
+		c.Request = httptest.NewRequest("POST", "/login", bytes.NewBufferString(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.Login(c)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		response := make(map[string]interface{})
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, "Tenant suspenso", response["error"])
+		mockLoginAttempts.AssertNotCalled(t, "RegisterFailure", "suspended@example.com", mock.Anything, mock.Anything)
+	})
+
+	t.Run("pending and disabled users get a specific code", func(t *testing.T) {
+		cases := map[string]struct {
+			err  error
+			code string
+		}{
+			"pending@example.com":  {autherrors.ErrUserPending, "USER_PENDING"},
+			"disabled@example.com": {autherrors.ErrUserDisabled, "USER_DISABLED"},
+		}
+		for email, tc := range cases {
+			mockLoginAttempts.On("Check", email, "localhost", "192.0.2.1").Return(nil)
+			mockUserService.On("Authenticate", mock.Anything, email, "password123", "localhost").Return(nil, tc.err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Set("Origin", "localhost")
+			body := `{"email":"` + email + `","password":"password123"}`
+			c.Request = httptest.NewRequest("POST", "/login", bytes.NewBufferString(body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler.Login(c)
+
+			assert.Equal(t, http.StatusForbidden, w.Code)
+			response := make(map[string]interface{})
+			json.Unmarshal(w.Body.Bytes(), &response)
+			assert.Equal(t, tc.code, response["code"])
+			mockLoginAttempts.AssertNotCalled(t, "RegisterFailure", email, mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("refresh of a suspended tenant", func(t *testing.T) {
+		userID, tenantID := uuid.New(), uuid.New()
+		mockTokenService.On("RefreshTokens", "suspended-refresh-token").Return(&services.RefreshClaims{UserID: userID, TenantID: tenantID, FamilyID: "session-2"}, nil)
+		mockTenantStatus.On("IsSuspended", tenantID.String()).Return(true, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		body := `{"refreshToken":"suspended-refresh-token"}`
+		c.Request = httptest.NewRequest("POST", "/refresh", bytes.NewBufferString(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.Refresh(c)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		mockTokenRedisService.AssertNotCalled(t, "ConsumeRefreshToken", userID.String(), mock.Anything, mock.Anything)
+	})
+
 	t.Run("successful token refresh", func(t *testing.T) {
 		userID := uuid.New()
 		user := &models.User{
 			BaseModel: models.BaseModel{ID: userID},
+			TenantID:  uuid.New(),
 			Name:      "John Doe",
 			Email:     "john@example.com",
 		}
 
-		mockTokenService.On("RefreshTokens", "valid-refresh-token").Return(user.ID, nil)
+		mockTokenService.On("RefreshTokens", "valid-refresh-token").Return(&services.RefreshClaims{UserID: user.ID, TenantID: user.TenantID, FamilyID: "session-1"}, nil)
+		mockTenantStatus.On("IsSuspended", user.TenantID.String()).Return(false, nil)
+		mockTokenRedisService.On("ConsumeRefreshToken", user.ID.String(), "session-1", "valid-refresh-token").Return(&models.UserRedis{ID: user.ID.String(), TenantID: user.TenantID.String(), SessionID: "session-1"}, nil)
 		mockUserService.On("GetOnlyByID", mock.Anything, user.ID).Return(user, nil)
 		mockTokenService.On("GetAccessDuration").Return(time.Hour * 24) // Assume que o token expira em 24 horas
-		mockTokenService.On("CreateTokens", user.ID, mock.Anything, mock.Anything).Return("new-access-token", "new-refresh-token", nil)
-		mockTokenRedisService.On("SaveUserRedis", user, "new-access-token", "new-refresh-token", mock.AnythingOfType("time.Duration")).Return(nil)
+		mockTokenService.On("GetRefreshDuration").Return(time.Hour * 24 * 90)
+		mockTokenService.On("CreateTokens", mock.MatchedBy(func(subject services.TokenSubject) bool {
+			return subject.UserID == user.ID && subject.TenantID == user.TenantID && subject.FamilyID == "session-1"
+		})).Return("new-access-token", "new-refresh-token", nil)
+		mockTokenRedisService.On("SaveUserRedis", user, mock.MatchedBy(func(session *models.UserSession) bool {
+			return session.ID == "session-1" && session.AccessToken == "new-access-token"
+		}), mock.AnythingOfType("time.Duration"), mock.AnythingOfType("time.Duration")).Return(nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
@@ -116,3 +256,212 @@ func TestAuthHandler_Login(t *testing.T) {
 		mockTokenRedisService.AssertExpectations(t)
 	})
 }
+
+func TestAuthHandler_RefreshRejectedToken(t *testing.T) {
+	mockUserService := mocks.NewUserService(t)
+	mockTokenService := mocks.NewTokenService(t)
+	mockTokenRedisService := mocks.NewTokenRedisService(t)
+	mockTenantStatus := mocks.NewTenantStatusService(t)
+	handler := handlers_v1.NewAuthHandler(mockUserService, mockTokenService, mockTokenRedisService, mocks.NewTwoFactorService(t), mocks.NewLoginAttemptService(t), mockTenantStatus)
+
+	userID := uuid.New()
+	mockTenantStatus.On("IsSuspended", uuid.Nil.String()).Return(false, nil)
+
+	for name, consumeErr := range map[string]error{
+		"revoked-refresh-token": services.ErrRefreshTokenRevoked,
+		"reused-refresh-token":  services.ErrRefreshTokenReused,
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockTokenService.On("RefreshTokens", name).Return(&services.RefreshClaims{UserID: userID, FamilyID: "session-1"}, nil)
+			mockTokenRedisService.On("ConsumeRefreshToken", userID.String(), "session-1", name).Return(nil, consumeErr)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			body := `{"refreshToken":"` + name + `"}`
+			c.Request = httptest.NewRequest("POST", "/refresh", bytes.NewBufferString(body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler.Refresh(c)
+
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+		})
+	}
+
+	t.Run("redis failure", func(t *testing.T) {
+		mockTokenService.On("RefreshTokens", "redis-failure").Return(&services.RefreshClaims{UserID: userID, FamilyID: "session-1"}, nil)
+		mockTokenRedisService.On("ConsumeRefreshToken", userID.String(), "session-1", "redis-failure").Return(nil, errors.New("redis indisponível"))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/refresh", bytes.NewBufferString(`{"refreshToken":"redis-failure"}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.Refresh(c)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	mockUserService.AssertNotCalled(t, "GetOnlyByID", mock.Anything, mock.Anything)
+}
+
+func TestAuthHandler_Logout(t *testing.T) {
+	mockUserService := mocks.NewUserService(t)
+	mockTokenService := mocks.NewTokenService(t)
+	mockTokenRedisService := mocks.NewTokenRedisService(t)
+	handler := handlers_v1.NewAuthHandler(mockUserService, mockTokenService, mockTokenRedisService, mocks.NewTwoFactorService(t), mocks.NewLoginAttemptService(t), mocks.NewTenantStatusService(t))
+
+	userRedis := &models.UserRedis{ID: uuid.New().String(), SessionID: "session-1"}
+
+	t.Run("logout revokes current session", func(t *testing.T) {
+		mockTokenRedisService.On("RevokeSession", userRedis.ID, "session-1").Return(nil).Once()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set(string(contextkeys.UserDataKey), userRedis)
+		c.Request = httptest.NewRequest("POST", "/logout", nil)
+
+		handler.Logout(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("logout-all revokes every session", func(t *testing.T) {
+		mockTokenRedisService.On("RevokeAllSessions", userRedis.ID).Return(nil).Once()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set(string(contextkeys.UserDataKey), userRedis)
+		c.Request = httptest.NewRequest("POST", "/logout-all", nil)
+
+		handler.LogoutAll(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("logout without authenticated user", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/logout", nil)
+
+		handler.Logout(c)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestAuthHandler_LoginTwoFactor(t *testing.T) {
+	mockUserService := mocks.NewUserService(t)
+	mockTokenService := mocks.NewTokenService(t)
+	mockTokenRedisService := mocks.NewTokenRedisService(t)
+	mockTwoFactorService := mocks.NewTwoFactorService(t)
+	mockLoginAttempts := mocks.NewLoginAttemptService(t)
+	handler := handlers_v1.NewAuthHandler(mockUserService, mockTokenService, mockTokenRedisService, mockTwoFactorService, mockLoginAttempts, mocks.NewTenantStatusService(t))
+
+	user := &models.User{
+		BaseModel:   models.BaseModel{ID: uuid.New()},
+		Email:       "john@example.com",
+		TOTPEnabled: true,
+	}
+
+	t.Run("login returns challenge when 2FA is enabled", func(t *testing.T) {
+		mockLoginAttempts.On("Check", "john@example.com", "localhost", "192.0.2.1").Return(nil)
+		mockUserService.On("Authenticate", mock.Anything, "john@example.com", "password123", "localhost").Return(user, nil)
+		mockTwoFactorService.On("CreateChallenge", user, "localhost").Return(&models.TwoFactorChallenge{
+			TwoFactorRequired: true,
+			ChallengeToken:    "challenge-token",
+			ExpiresIn:         300,
+		}, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("Origin", "localhost")
+		body := `{"email":"john@example.com","password":"password123"}`
+		c.Request = httptest.NewRequest("POST", "/login", bytes.NewBufferString(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.Login(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		response := make(map[string]interface{})
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, true, response["two_factor_required"])
+		assert.Equal(t, "challenge-token", response["challenge_token"])
+		assert.Nil(t, response["token"])
+		mockTokenService.AssertNotCalled(t, "CreateTokens", mock.Anything)
+		// A senha sozinha não zera as tentativas: isso só acontece depois do segundo fator
+		mockLoginAttempts.AssertNotCalled(t, "Reset", mock.Anything, mock.Anything)
+	})
+
+	t.Run("valid code issues tokens", func(t *testing.T) {
+		mockTwoFactorService.On("CompleteChallenge", mock.Anything, "challenge-token", "123456", "localhost").Return(user, nil)
+		mockLoginAttempts.On("Reset", "john@example.com", "localhost").Return(nil).Once()
+		mockTokenService.On("GetAccessDuration").Return(time.Hour)
+		mockTokenService.On("GetRefreshDuration").Return(time.Hour * 24)
+		mockTokenService.On("CreateTokens", mock.MatchedBy(func(subject services.TokenSubject) bool {
+			return subject.UserID == user.ID
+		})).Return("access-token", "refresh-token", nil)
+		mockTokenRedisService.On("SaveUserRedis", user, mock.Anything, time.Hour, time.Hour*24).Return(nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("Origin", "localhost")
+		body := `{"challenge_token":"challenge-token","code":"123456"}`
+		c.Request = httptest.NewRequest("POST", "/login/2fa", bytes.NewBufferString(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.LoginTwoFactor(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		response := make(map[string]interface{})
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, "access-token", response["token"])
+	})
+
+	t.Run("tenant suspended after the password", func(t *testing.T) {
+		mockTwoFactorService.On("CompleteChallenge", mock.Anything, "challenge-token", "654321", "localhost").Return(nil, autherrors.ErrTenantSuspended)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("Origin", "localhost")
+		body := `{"challenge_token":"challenge-token","code":"654321"}`
+		c.Request = httptest.NewRequest("POST", "/login/2fa", bytes.NewBufferString(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.LoginTwoFactor(c)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("invalid code counts as a login failure", func(t *testing.T) {
+		mockTwoFactorService.On("CompleteChallenge", mock.Anything, "challenge-token", "000000", "localhost").Return(user, services.ErrInvalidTwoFactorCode).Once()
+		mockLoginAttempts.On("RegisterFailure", "john@example.com", "localhost", "192.0.2.1").Return(nil).Once()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("Origin", "localhost")
+		body := `{"challenge_token":"challenge-token","code":"000000"}`
+		c.Request = httptest.NewRequest("POST", "/login/2fa", bytes.NewBufferString(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.LoginTwoFactor(c)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("invalid codes lock the account", func(t *testing.T) {
+		mockTwoFactorService.On("CompleteChallenge", mock.Anything, "challenge-token", "111111", "localhost").Return(user, services.ErrInvalidTwoFactorCode).Once()
+		mockLoginAttempts.On("RegisterFailure", "john@example.com", "localhost", "192.0.2.1").
+			Return(&autherrors.LoginBlockedError{Err: autherrors.ErrAccountLocked, RetryAfter: 15 * time.Minute}).Once()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("Origin", "localhost")
+		body := `{"challenge_token":"challenge-token","code":"111111"}`
+		c.Request = httptest.NewRequest("POST", "/login/2fa", bytes.NewBufferString(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.LoginTwoFactor(c)
+
+		assert.Equal(t, http.StatusLocked, w.Code)
+	})
+}
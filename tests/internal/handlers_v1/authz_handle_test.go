@@ -0,0 +1,66 @@
+// tests/internal/handlers_v1/authz_handle_test.go
+
+package handlers_v1_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/handlers_v1"
+	"github.com/jeancarlosdanese/go-base-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthzHandler_Explain(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userService := mocks.NewUserService(t)
+	casbinService := mocks.NewCasbinService(t)
+	handler := handlers_v1.NewAuthzHandler(userService, casbinService)
+
+	tenantID := uuid.New()
+	userID := uuid.New()
+	caller := &models.UserRedis{ID: uuid.NewString(), TenantID: tenantID.String()}
+	user := &models.User{BaseModel: models.BaseModel{ID: userID}, TenantID: tenantID, Username: "ana", Email: "ana@example.com"}
+
+	t.Run("acesso negado explicado", func(t *testing.T) {
+		explanation := &models.AuthzExplanation{
+			Domain:     tenantID.String(),
+			Roles:      []string{"vendas"},
+			Candidates: []models.AuthzRule{{Subject: "vendas", Domain: tenantID.String(), Endpoint: "/api/v1/users/:id", Actions: "GET"}},
+			Reason:     "Nenhuma política do usuário ou dos seus roles permite DELETE em /api/v1/users/42",
+		}
+		userService.On("GetByID", mock.Anything, userID).Return(user, nil).Once()
+		// As condições são avaliadas com os atributos do usuário explicado, não os de quem pergunta
+		subject := &models.UserRedis{ID: userID.String(), TenantID: tenantID.String(), Username: "ana", Email: "ana@example.com"}
+		casbinService.On("Explain", subject, tenantID.String(), "/api/v1/users/42", "DELETE").Return(explanation, nil).Once()
+
+		w, c := newMeContext(caller, "POST", "/authz/explain", `{"user_id":"`+userID.String()+`","path":"/api/v1/users/42?force=true","method":"delete"}`)
+		handler.Explain(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"allowed":false`)
+		assert.Contains(t, w.Body.String(), `"candidates":[{"subject":"vendas"`)
+	})
+
+	t.Run("usuário de outro tenant", func(t *testing.T) {
+		otherID := uuid.New()
+		userService.On("GetByID", mock.Anything, otherID).Return(&models.User{BaseModel: models.BaseModel{ID: otherID}, TenantID: uuid.New()}, nil).Once()
+
+		w, c := newMeContext(caller, "POST", "/authz/explain", `{"user_id":"`+otherID.String()+`","path":"/api/v1/users","method":"GET"}`)
+		handler.Explain(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		casbinService.AssertNotCalled(t, "Explain", mock.MatchedBy(func(u *models.UserRedis) bool { return u.ID == otherID.String() }), mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("caminho inválido", func(t *testing.T) {
+		w, c := newMeContext(caller, "POST", "/authz/explain", `{"user_id":"`+userID.String()+`","path":"users","method":"GET"}`)
+		handler.Explain(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
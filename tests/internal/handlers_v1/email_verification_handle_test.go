@@ -0,0 +1,46 @@
+// tests/internal/handlers_v1/email_verification_handle_test.go
+
+package handlers_v1_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeancarlosdanese/go-base-api/internal/handlers_v1"
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+	"github.com/jeancarlosdanese/go-base-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEmailVerificationHandler_Verify(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockUserStatusService := mocks.NewUserStatusService(t)
+	handler := handlers_v1.NewEmailVerificationHandler(mockUserStatusService)
+
+	t.Run("e-mail verificado", func(t *testing.T) {
+		mockUserStatusService.On("VerifyEmail", mock.Anything, "valid-token").Return(nil).Once()
+
+		w, c := newPasswordRequest("/email/verify", `{"token":"valid-token"}`)
+		handler.Verify(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("token inválido", func(t *testing.T) {
+		mockUserStatusService.On("VerifyEmail", mock.Anything, "used-token").Return(services.ErrEmailVerificationTokenInvalid).Once()
+
+		w, c := newPasswordRequest("/email/verify", `{"token":"used-token"}`)
+		handler.Verify(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("token ausente", func(t *testing.T) {
+		w, c := newPasswordRequest("/email/verify", `{}`)
+		handler.Verify(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
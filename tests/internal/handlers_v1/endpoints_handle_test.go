@@ -0,0 +1,52 @@
+// tests/internal/handlers_v1/endpoints_handle_test.go
+
+package handlers_v1_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/handlers_v1"
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+	"github.com/jeancarlosdanese/go-base-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEndpointsHandler_Create(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	securityService := mocks.NewSecurityService(t)
+	handler := handlers_v1.NewEndpointsHandler(securityService)
+
+	t.Run("endpoint criado", func(t *testing.T) {
+		input := models.EndpointInput{Name: "/api/v1/reports/:id"}
+		securityService.On("CreateEndpoint", mock.Anything, input).Return(&models.Endpoint{ID: 7, Name: input.Name}, nil).Once()
+
+		w, c := newSecurityRequest("POST", "/endpoints", `{"name":"/api/v1/reports/:id"}`, nil)
+		handler.Create(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("nome fora do padrão de rota", func(t *testing.T) {
+		w, c := newSecurityRequest("POST", "/endpoints", `{"name":"api/v1/reports"}`, nil)
+		handler.Create(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestEndpointsHandler_DeleteInUse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	securityService := mocks.NewSecurityService(t)
+	handler := handlers_v1.NewEndpointsHandler(securityService)
+
+	securityService.On("DeleteEndpoint", mock.Anything, uint(3)).Return(services.ErrSecurityInUse).Once()
+
+	w, c := newSecurityRequest("DELETE", "/endpoints/3", "", gin.Params{{Key: "id", Value: "3"}})
+	handler.Delete(c)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
@@ -0,0 +1,201 @@
+// tests/internal/handlers_v1/idempotency_test.go
+
+package handlers_v1_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	contextkeys "github.com/jeancarlosdanese/go-base-api/internal/domain/context_keys"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/routes"
+	"github.com/jeancarlosdanese/go-base-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const idempotencyRedisKey = "idempotency:tenant-1:user-1:chave-1"
+
+// idempotencyRouter monta um POST /api/v1/tenants que conta as execuções e responde 201 (ou status, se informado).
+func idempotencyRouter(redisService *mocks.RedisService, status int, calls *int) *gin.Engine {
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set(string(contextkeys.UserDataKey), &models.UserRedis{ID: "user-1"})
+		c.Set(string(contextkeys.TenantIDKey), "tenant-1")
+	})
+	r.Use(routes.IdempotencyMiddleware(redisService))
+	r.POST("/api/v1/tenants", func(c *gin.Context) {
+		*calls++
+		c.Header("ETag", `"1"`)
+		c.JSON(status, gin.H{"id": "novo", "calls": *calls})
+	})
+	return r
+}
+
+func postIdempotent(r *gin.Engine, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/tenants", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotencyMiddleware_Replay(t *testing.T) {
+	redisService := mocks.NewRedisService(t)
+	var calls int
+	r := idempotencyRouter(redisService, http.StatusCreated, &calls)
+
+	// A primeira requisição reserva a chave e guarda a resposta
+	var stored string
+	redisService.On("SetNX", idempotencyRedisKey, mock.Anything, time.Minute).Return(true, nil).Once()
+	redisService.On("Set", idempotencyRedisKey, mock.Anything, 24*time.Hour).
+		Run(func(args mock.Arguments) { stored = args.String(1) }).
+		Return(nil).Once()
+
+	first := postIdempotent(r, "chave-1", `{"name": "Acme"}`)
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, 1, calls)
+
+	// A repetição recebe a mesma resposta, sem executar o handler de novo
+	redisService.On("SetNX", idempotencyRedisKey, mock.Anything, time.Minute).Return(false, nil)
+	redisService.On("Get", idempotencyRedisKey).Return(func(string) string { return stored }, nil)
+
+	replay := postIdempotent(r, "chave-1", `{"name": "Acme"}`)
+	assert.Equal(t, http.StatusCreated, replay.Code)
+	assert.Equal(t, first.Body.String(), replay.Body.String())
+	assert.Equal(t, `"1"`, replay.Header().Get("ETag"))
+	assert.Equal(t, "true", replay.Header().Get("Idempotent-Replayed"))
+	assert.Equal(t, 1, calls)
+
+	// A mesma chave com outro corpo é recusada
+	conflict := postIdempotent(r, "chave-1", `{"name": "Outra"}`)
+	assert.Equal(t, http.StatusConflict, conflict.Code)
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotencyMiddleware_InProgress(t *testing.T) {
+	redisService := mocks.NewRedisService(t)
+	var calls int
+	r := idempotencyRouter(redisService, http.StatusCreated, &calls)
+
+	// A primeira requisição ainda não terminou: só a reserva está no Redis
+	var pending string
+	redisService.On("SetNX", idempotencyRedisKey, mock.Anything, time.Minute).
+		Run(func(args mock.Arguments) { pending = args.String(1) }).
+		Return(true, nil).Once()
+	redisService.On("Set", idempotencyRedisKey, mock.Anything, 24*time.Hour).Return(nil).Once()
+	postIdempotent(r, "chave-1", `{"name": "Acme"}`)
+
+	redisService.On("SetNX", idempotencyRedisKey, mock.Anything, time.Minute).Return(false, nil)
+	redisService.On("Get", idempotencyRedisKey).Return(func(string) string { return pending }, nil)
+
+	w := postIdempotent(r, "chave-1", `{"name": "Acme"}`)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotencyMiddleware_ServerErrorReleasesKey(t *testing.T) {
+	redisService := mocks.NewRedisService(t)
+	var calls int
+	r := idempotencyRouter(redisService, http.StatusInternalServerError, &calls)
+
+	redisService.On("SetNX", idempotencyRedisKey, mock.Anything, time.Minute).Return(true, nil).Twice()
+	redisService.On("Delete", idempotencyRedisKey).Return(nil).Twice()
+
+	assert.Equal(t, http.StatusInternalServerError, postIdempotent(r, "chave-1", `{}`).Code)
+	assert.Equal(t, http.StatusInternalServerError, postIdempotent(r, "chave-1", `{}`).Code)
+	assert.Equal(t, 2, calls)
+	redisService.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestIdempotencyMiddleware_WithoutKey(t *testing.T) {
+	// Sem o header, o Redis não é consultado (o mock falharia em qualquer chamada inesperada)
+	redisService := mocks.NewRedisService(t)
+	var calls int
+	r := idempotencyRouter(redisService, http.StatusCreated, &calls)
+
+	assert.Equal(t, http.StatusCreated, postIdempotent(r, "", `{}`).Code)
+	assert.Equal(t, http.StatusCreated, postIdempotent(r, "", `{}`).Code)
+	assert.Equal(t, 2, calls)
+
+	assert.Equal(t, http.StatusBadRequest, postIdempotent(r, strings.Repeat("k", 256), `{}`).Code)
+
+	redisService.On("SetNX", idempotencyRedisKey, mock.Anything, time.Minute).Return(false, errors.New("redis indisponível")).Once()
+	assert.Equal(t, http.StatusInternalServerError, postIdempotent(r, "chave-1", `{}`).Code)
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotencyMiddleware_SecretsNotStored(t *testing.T) {
+	redisService := mocks.NewRedisService(t)
+	r := gin.New()
+	r.Use(routes.IdempotencyMiddleware(redisService, "api_key"))
+	r.POST("/api/v1/tenants", func(c *gin.Context) {
+		c.JSON(http.StatusCreated, struct {
+			Name   string `json:"name"`
+			ApiKey string `json:"api_key"`
+			ID     string `json:"id"`
+		}{Name: "Acme", ApiKey: "chave-em-claro", ID: "novo"})
+	})
+
+	var stored string
+	redisService.On("SetNX", mock.Anything, mock.Anything, time.Minute).Return(true, nil).Once()
+	redisService.On("Set", mock.Anything, mock.Anything, 24*time.Hour).
+		Run(func(args mock.Arguments) { stored = args.String(1) }).
+		Return(nil).Once()
+
+	// O cliente recebe a chave na primeira resposta, mas ela não vai para o Redis nem volta na repetição
+	first := postIdempotent(r, "chave-1", `{"name": "Acme"}`)
+	assert.Contains(t, first.Body.String(), "chave-em-claro")
+	assert.NotContains(t, stored, "chave-em-claro")
+
+	redisService.On("SetNX", mock.Anything, mock.Anything, time.Minute).Return(false, nil)
+	redisService.On("Get", mock.Anything).Return(func(string) string { return stored }, nil)
+
+	// Os demais campos voltam na ordem e com os bytes originais
+	replay := postIdempotent(r, "chave-1", `{"name": "Acme"}`)
+	assert.Equal(t, http.StatusCreated, replay.Code)
+	assert.Equal(t, `{"name":"Acme","id":"novo"}`, replay.Body.String())
+}
+
+func TestIdempotencyMiddleware_BodyStoredUnchanged(t *testing.T) {
+	// Sem campos secretos configurados para a rota, nem mesmo um campo "key" é alterado
+	redisService := mocks.NewRedisService(t)
+	r := gin.New()
+	r.Use(routes.IdempotencyMiddleware(redisService))
+	r.POST("/api/v1/users", func(c *gin.Context) {
+		c.JSON(http.StatusCreated, struct {
+			Name string `json:"name"`
+			Key  string `json:"key"`
+		}{Name: "John", Key: "username"})
+	})
+
+	var stored string
+	redisService.On("SetNX", mock.Anything, mock.Anything, time.Minute).Return(true, nil).Once()
+	redisService.On("Set", mock.Anything, mock.Anything, 24*time.Hour).
+		Run(func(args mock.Arguments) { stored = args.String(1) }).
+		Return(nil).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users", strings.NewReader(`{}`))
+	req.Header.Set("Idempotency-Key", "chave-1")
+	first := httptest.NewRecorder()
+	r.ServeHTTP(first, req)
+
+	redisService.On("SetNX", mock.Anything, mock.Anything, time.Minute).Return(false, nil)
+	redisService.On("Get", mock.Anything).Return(func(string) string { return stored }, nil)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/users", strings.NewReader(`{}`))
+	req.Header.Set("Idempotency-Key", "chave-1")
+	replay := httptest.NewRecorder()
+	r.ServeHTTP(replay, req)
+
+	assert.Equal(t, `{"name":"John","key":"username"}`, first.Body.String())
+	assert.Equal(t, first.Body.String(), replay.Body.String())
+}
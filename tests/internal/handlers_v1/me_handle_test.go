@@ -0,0 +1,187 @@
+// tests/internal/handlers_v1/me_handle_test.go
+
+package handlers_v1_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	contextkeys "github.com/jeancarlosdanese/go-base-api/internal/domain/context_keys"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/handlers_v1"
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+	"github.com/jeancarlosdanese/go-base-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newMeContext(userRedis *models.UserRedis, method, path, body string) (*httptest.ResponseRecorder, *gin.Context) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	if userRedis != nil {
+		c.Set(string(contextkeys.UserDataKey), userRedis)
+	}
+	return w, c
+}
+
+func TestMeHandler_Profile(t *testing.T) {
+	mockUserService := mocks.NewUserService(t)
+	handler := handlers_v1.NewMeHandler(mockUserService, mocks.NewTokenRedisService(t), mocks.NewCasbinService(t))
+
+	userID := uuid.New()
+	userRedis := &models.UserRedis{ID: userID.String(), SessionID: "session-1"}
+
+	t.Run("get profile", func(t *testing.T) {
+		mockUserService.On("GetByID", mock.Anything, userID).Return(&models.User{BaseModel: models.BaseModel{ID: userID}, Name: "John"}, nil).Once()
+
+		w, c := newMeContext(userRedis, "GET", "/me", "")
+		handler.GetProfile(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"name":"John"`)
+	})
+
+	t.Run("update profile only changes informed fields", func(t *testing.T) {
+		mockUserService.On("UpdateProfile", mock.Anything, userID, mock.MatchedBy(func(profile *models.UserProfileUpdate) bool {
+			return profile.Name != nil && *profile.Name == "Johnny" && profile.Username == nil
+		})).Return(&models.User{BaseModel: models.BaseModel{ID: userID}, Name: "Johnny"}, nil).Once()
+
+		w, c := newMeContext(userRedis, "PATCH", "/me", `{"name":"Johnny"}`)
+		handler.UpdateProfile(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		w, c := newMeContext(nil, "GET", "/me", "")
+		handler.GetProfile(c)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestMeHandler_Permissions(t *testing.T) {
+	mockCasbinService := mocks.NewCasbinService(t)
+	handler := handlers_v1.NewMeHandler(mocks.NewUserService(t), mocks.NewTokenRedisService(t), mockCasbinService)
+	// As políticas da sessão ficaram desatualizadas: a matriz vem do enforcer
+	userRedis := &models.UserRedis{
+		ID:       uuid.NewString(),
+		TenantID: "tenant-a",
+		Roles:    []string{"vendas"},
+		Policies: []string{"/api/v1/users/:id:PUT"},
+	}
+	permissions := &models.UserPermissions{
+		Roles: []string{"vendas"},
+		Permissions: []models.EndpointPermission{
+			{Endpoint: "/api/v1/reports", Actions: []string{"GET"}},
+			{Endpoint: "/api/v1/users/:id", Actions: []string{"GET", "PATCH"}},
+		},
+	}
+
+	t.Run("current policies", func(t *testing.T) {
+		mockCasbinService.On("Permissions", userRedis, "tenant-a").Return(permissions, nil).Once()
+
+		w, c := newMeContext(userRedis, "GET", "/me/permissions", "")
+		handler.GetPermissions(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.UserPermissions
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, *permissions, response)
+	})
+
+	t.Run("enforcer error", func(t *testing.T) {
+		mockCasbinService.On("Permissions", userRedis, "tenant-a").Return(nil, errors.New("falha")).Once()
+
+		w, c := newMeContext(userRedis, "GET", "/me/permissions", "")
+		handler.GetPermissions(c)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestMeHandler_ChangePassword(t *testing.T) {
+	mockUserService := mocks.NewUserService(t)
+	mockTokenRedisService := mocks.NewTokenRedisService(t)
+	handler := handlers_v1.NewMeHandler(mockUserService, mockTokenRedisService, mocks.NewCasbinService(t))
+
+	userID := uuid.New()
+	userRedis := &models.UserRedis{ID: userID.String(), SessionID: "session-1"}
+
+	t.Run("password changed and other sessions revoked", func(t *testing.T) {
+		mockUserService.On("ChangePassword", mock.Anything, userID, "senha-atual", "nova-senha-123").Return(nil).Once()
+		mockTokenRedisService.On("RevokeOtherSessions", userID.String(), "session-1").Return(nil).Once()
+
+		w, c := newMeContext(userRedis, "POST", "/me/password", `{"current_password":"senha-atual","new_password":"nova-senha-123"}`)
+		handler.ChangePassword(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("wrong current password", func(t *testing.T) {
+		mockUserService.On("ChangePassword", mock.Anything, userID, "errada", "nova-senha-123").Return(services.ErrInvalidCurrentPassword).Once()
+
+		w, c := newMeContext(userRedis, "POST", "/me/password", `{"current_password":"errada","new_password":"nova-senha-123"}`)
+		handler.ChangePassword(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestMeHandler_Sessions(t *testing.T) {
+	mockTokenRedisService := mocks.NewTokenRedisService(t)
+	handler := handlers_v1.NewMeHandler(mocks.NewUserService(t), mockTokenRedisService, mocks.NewCasbinService(t))
+
+	userRedis := &models.UserRedis{ID: uuid.New().String(), SessionID: "session-1"}
+	sessions := []models.UserSession{
+		{ID: "session-1", AccessToken: "access-1", RefreshToken: "refresh-1", UserAgent: "curl", RefreshedAt: time.Now()},
+		{ID: "session-2", AccessToken: "access-2", RefreshToken: "refresh-2", UserAgent: "firefox", RefreshedAt: time.Now().Add(-time.Hour)},
+	}
+
+	t.Run("list sessions without tokens", func(t *testing.T) {
+		mockTokenRedisService.On("ListSessions", userRedis.ID).Return(sessions, nil).Once()
+
+		w, c := newMeContext(userRedis, "GET", "/me/sessions", "")
+		handler.GetSessions(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "access-1")
+		assert.NotContains(t, w.Body.String(), "refresh-1")
+
+		var response []models.SessionInfo
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response, 2)
+		assert.True(t, response[0].Current)
+		assert.False(t, response[1].Current)
+	})
+
+	t.Run("delete own session", func(t *testing.T) {
+		mockTokenRedisService.On("ListSessions", userRedis.ID).Return(sessions, nil).Once()
+		mockTokenRedisService.On("RevokeSession", userRedis.ID, "session-2").Return(nil).Once()
+
+		w, c := newMeContext(userRedis, "DELETE", "/me/sessions/session-2", "")
+		c.Params = gin.Params{{Key: "id", Value: "session-2"}}
+		handler.DeleteSession(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("delete unknown session", func(t *testing.T) {
+		mockTokenRedisService.On("ListSessions", userRedis.ID).Return(sessions, nil).Once()
+
+		w, c := newMeContext(userRedis, "DELETE", "/me/sessions/other", "")
+		c.Params = gin.Params{{Key: "id", Value: "other"}}
+		handler.DeleteSession(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
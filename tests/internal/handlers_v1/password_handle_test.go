@@ -0,0 +1,115 @@
+// tests/internal/handlers_v1/password_handle_test.go
+
+package handlers_v1_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/handlers_v1"
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+	"github.com/jeancarlosdanese/go-base-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newPasswordRequest(path, body string) (*httptest.ResponseRecorder, *gin.Context) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", path, bytes.NewBufferString(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("Origin", "localhost")
+	return w, c
+}
+
+func TestPasswordHandler_Forgot(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockPasswordResetService := mocks.NewPasswordResetService(t)
+	handler := handlers_v1.NewPasswordHandler(mockPasswordResetService)
+
+	t.Run("pedido aceito", func(t *testing.T) {
+		mockPasswordResetService.On("RequestPasswordReset", mock.Anything, "john@example.com", "localhost").Return(nil).Once()
+
+		w, c := newPasswordRequest("/password/forgot", `{"email":"john@example.com"}`)
+		handler.Forgot(c)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+	})
+
+	t.Run("falha no envio responde igual a um pedido aceito", func(t *testing.T) {
+		mockPasswordResetService.On("RequestPasswordReset", mock.Anything, "john@example.com", "localhost").Return(errors.New("redis indisponível")).Once()
+
+		w, c := newPasswordRequest("/password/forgot", `{"email":"john@example.com"}`)
+		handler.Forgot(c)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+	})
+
+	t.Run("e-mail inválido", func(t *testing.T) {
+		w, c := newPasswordRequest("/password/forgot", `{"email":"not-an-email"}`)
+		handler.Forgot(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestPasswordHandler_Reset(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockPasswordResetService := mocks.NewPasswordResetService(t)
+	handler := handlers_v1.NewPasswordHandler(mockPasswordResetService)
+
+	t.Run("senha redefinida", func(t *testing.T) {
+		mockPasswordResetService.On("ResetPassword", mock.Anything, "valid-token", "nova-senha-123").Return(nil).Once()
+
+		w, c := newPasswordRequest("/password/reset", `{"token":"valid-token","password":"nova-senha-123"}`)
+		handler.Reset(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("token inválido ou já usado", func(t *testing.T) {
+		mockPasswordResetService.On("ResetPassword", mock.Anything, "used-token", "nova-senha-123").Return(services.ErrPasswordResetTokenInvalid).Once()
+
+		w, c := newPasswordRequest("/password/reset", `{"token":"used-token","password":"nova-senha-123"}`)
+		handler.Reset(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("erro interno", func(t *testing.T) {
+		mockPasswordResetService.On("ResetPassword", mock.Anything, "valid-token", "outra-senha-123").Return(errors.New("db down")).Once()
+
+		w, c := newPasswordRequest("/password/reset", `{"token":"valid-token","password":"outra-senha-123"}`)
+		handler.Reset(c)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("senha recusada pela política", func(t *testing.T) {
+		policyErr := &services.PasswordPolicyError{Violations: []models.PasswordViolation{
+			{Code: services.PasswordViolationUpper, Message: "a senha deve ter ao menos uma letra maiúscula"},
+		}}
+		mockPasswordResetService.On("ResetPassword", mock.Anything, "valid-token", "senha-fraca-123").Return(policyErr).Once()
+
+		w, c := newPasswordRequest("/password/reset", `{"token":"valid-token","password":"senha-fraca-123"}`)
+		handler.Reset(c)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		var response models.PasswordPolicyErrorResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, services.PasswordViolationUpper, response.Violations[0].Code)
+	})
+
+	t.Run("senha curta", func(t *testing.T) {
+		w, c := newPasswordRequest("/password/reset", `{"token":"valid-token","password":"123"}`)
+		handler.Reset(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
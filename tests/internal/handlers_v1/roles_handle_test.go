@@ -0,0 +1,136 @@
+// tests/internal/handlers_v1/roles_handle_test.go
+
+package handlers_v1_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/handlers_v1"
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+	"github.com/jeancarlosdanese/go-base-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newSecurityRequest(method, path, body string, params gin.Params) (*httptest.ResponseRecorder, *gin.Context) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = params
+	c.Request = httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	return w, c
+}
+
+func TestRolesHandler_Create(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	securityService := mocks.NewSecurityService(t)
+	handler := handlers_v1.NewRolesHandler(securityService)
+
+	t.Run("role criado", func(t *testing.T) {
+		securityService.On("CreateRole", mock.Anything, models.RoleInput{Name: "financeiro"}).Return(&models.Role{ID: 3, Name: "financeiro"}, nil).Once()
+
+		w, c := newSecurityRequest("POST", "/roles", `{"name":"financeiro"}`, nil)
+		handler.Create(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("nome já existe", func(t *testing.T) {
+		securityService.On("CreateRole", mock.Anything, models.RoleInput{Name: "admin"}).Return(nil, services.ErrSecurityConflict).Once()
+
+		w, c := newSecurityRequest("POST", "/roles", `{"name":"admin"}`, nil)
+		handler.Create(c)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("role global sem o role master", func(t *testing.T) {
+		securityService.On("CreateRole", mock.Anything, models.RoleInput{Name: "auditoria", Global: true}).Return(nil, services.ErrGlobalRole).Once()
+
+		w, c := newSecurityRequest("POST", "/roles", `{"name":"auditoria","global":true}`, nil)
+		handler.Create(c)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("nome ausente", func(t *testing.T) {
+		w, c := newSecurityRequest("POST", "/roles", `{}`, nil)
+		handler.Create(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestRolesHandler_Delete(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	securityService := mocks.NewSecurityService(t)
+	handler := handlers_v1.NewRolesHandler(securityService)
+
+	t.Run("role master", func(t *testing.T) {
+		securityService.On("DeleteRole", mock.Anything, uint(1)).Return(services.ErrProtectedRole).Once()
+
+		w, c := newSecurityRequest("DELETE", "/roles/1", "", gin.Params{{Key: "id", Value: "1"}})
+		handler.Delete(c)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("ID inválido", func(t *testing.T) {
+		w, c := newSecurityRequest("DELETE", "/roles/abc", "", gin.Params{{Key: "id", Value: "abc"}})
+		handler.Delete(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestRolesHandler_CreatePolicy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	securityService := mocks.NewSecurityService(t)
+	handler := handlers_v1.NewRolesHandler(securityService)
+	params := gin.Params{{Key: "id", Value: "2"}}
+
+	t.Run("política criada", func(t *testing.T) {
+		input := models.PolicyInput{EndpointID: 7, Actions: "GET|POST"}
+		securityService.On("CreateRolePolicy", mock.Anything, uint(2), input).Return(&models.PolicyRole{RoleID: 2, EndpointID: 7, Actions: "GET|POST"}, nil).Once()
+
+		w, c := newSecurityRequest("POST", "/roles/2/policies", `{"endpoint_id":7,"actions":"GET|POST"}`, params)
+		handler.CreatePolicy(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("ações inválidas", func(t *testing.T) {
+		input := models.PolicyInput{EndpointID: 7, Actions: "HEAD"}
+		securityService.On("CreateRolePolicy", mock.Anything, uint(2), input).Return(nil, services.ErrInvalidActions).Once()
+
+		w, c := newSecurityRequest("POST", "/roles/2/policies", `{"endpoint_id":7,"actions":"HEAD"}`, params)
+		handler.CreatePolicy(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("ações que o usuário não possui", func(t *testing.T) {
+		input := models.PolicyInput{EndpointID: 7, Actions: "DELETE"}
+		securityService.On("CreateRolePolicy", mock.Anything, uint(2), input).Return(nil, services.ErrActionsNotGranted).Once()
+
+		w, c := newSecurityRequest("POST", "/roles/2/policies", `{"endpoint_id":7,"actions":"DELETE"}`, params)
+		handler.CreatePolicy(c)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("endpoint não encontrado", func(t *testing.T) {
+		input := models.PolicyInput{EndpointID: 99, Actions: "GET"}
+		securityService.On("CreateRolePolicy", mock.Anything, uint(2), input).Return(nil, services.ErrEndpointNotFound).Once()
+
+		w, c := newSecurityRequest("POST", "/roles/2/policies", `{"endpoint_id":99,"actions":"GET"}`, params)
+		handler.CreatePolicy(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -7,7 +7,11 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	contextkeys "github.com/jeancarlosdanese/go-base-api/internal/domain/context_keys"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
 	"github.com/jeancarlosdanese/go-base-api/internal/routes"
+	"github.com/jeancarlosdanese/go-base-api/tests/mocks"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -88,3 +92,34 @@ func TestRequestSizeLimit(t *testing.T) {
 	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
 	assert.Contains(t, w.Body.String(), "Request too large")
 }
+
+func TestPolicyMiddleware_HardDelete(t *testing.T) {
+	casbinService := mocks.NewCasbinService(t)
+	user := &models.UserRedis{ID: uuid.New().String()}
+	tenantID := uuid.New()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set(string(contextkeys.UserDataKey), user)
+		c.Set(string(contextkeys.TenantIDKey), tenantID)
+	})
+	r.Use(routes.PolicyMiddleware(casbinService))
+	r.DELETE("/api/v1/users/:id", func(c *gin.Context) {
+		c.String(http.StatusOK, "OK")
+	})
+
+	userID := uuid.New().String()
+	// A exclusão lógica usa a política do próprio endpoint; a definitiva, a do endpoint .../:id/purge
+	casbinService.On("CheckPermission", user, tenantID.String(), "/api/v1/users/"+userID, http.MethodDelete).Return(true)
+	casbinService.On("CheckPermission", user, tenantID.String(), "/api/v1/users/"+userID+"/purge", http.MethodDelete).Return(false)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/api/v1/users/"+userID, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req, _ = http.NewRequest(http.MethodDelete, "/api/v1/users/"+userID+"?hard=true", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
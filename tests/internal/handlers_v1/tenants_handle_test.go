@@ -8,20 +8,24 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	contextkeys "github.com/jeancarlosdanese/go-base-api/internal/domain/context_keys"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/enums"
 	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
 	"github.com/jeancarlosdanese/go-base-api/internal/handlers_v1"
 	"github.com/jeancarlosdanese/go-base-api/internal/services"
 	"github.com/jeancarlosdanese/go-base-api/tests/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 func TestTenantsHandler_GetAll(t *testing.T) {
 	mockRepo := new(mocks.MockTenantRepository)
-	service := services.NewTenantService(mockRepo)
+	service := services.NewTenantService(mockRepo, nil, nil)
 	handler := handlers_v1.NewTenantsHandler(service)
 
 	tenants := []models.Tenant{
@@ -34,24 +38,117 @@ func TestTenantsHandler_GetAll(t *testing.T) {
 			Name:      "Tenant 2",
 		},
 	}
-	mockRepo.On("GetAll", mock.Anything).Return(tenants, nil)
+	defaultQuery := models.ListQuery{Sort: []models.ListSort{{Column: "name"}}, Limit: 50}
+	mockRepo.On("GetAll", mock.Anything, defaultQuery).Return(&models.Page[models.Tenant]{Items: tenants, Total: 2}, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/tenants", nil)
 
 	handler.GetAll(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
-	var response []models.Tenant
+	var response models.ListResponse[models.Tenant]
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.Len(t, response, 2)
+	assert.Len(t, response.Data, 2)
+	assert.Equal(t, models.Pagination{Total: 2, Limit: 50}, response.Pagination)
+	assert.Equal(t, `</api/v1/tenants>; rel="first", </api/v1/tenants>; rel="last"`, w.Header().Get("Link"))
 	mockRepo.AssertExpectations(t)
 }
 
+func TestTenantsHandler_GetAllQuery(t *testing.T) {
+	get := func(handler *handlers_v1.TenantsHandler, target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+		handler.GetAll(c)
+		return w
+	}
+	tenants := []models.Tenant{{BaseModel: models.BaseModel{ID: uuid.New()}, Name: "Acme"}}
+
+	t.Run("Filtros, ordenação e offset", func(t *testing.T) {
+		mockRepo := new(mocks.MockTenantRepository)
+		handler := handlers_v1.NewTenantsHandler(services.NewTenantService(mockRepo, nil, nil))
+		query := models.ListQuery{
+			Filters: []models.ListFilter{{Column: "name", Op: models.FilterLike, Values: []string{"acme"}}},
+			Sort:    []models.ListSort{{Column: "status", Desc: true}, {Column: "name"}},
+			Limit:   10,
+			Offset:  10,
+		}
+		mockRepo.On("GetAll", mock.Anything, query).
+			Return(&models.Page[models.Tenant]{Items: tenants, Total: 35, NextCursor: []string{"ATIVO", "Acme", "id-1"}}, nil)
+
+		w := get(handler, "/api/v1/tenants?name[like]=acme&sort=-status,name&limit=10&offset=10")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.ListResponse[models.Tenant]
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, int64(35), response.Pagination.Total)
+		assert.Equal(t, 10, response.Pagination.Offset)
+		assert.NotEmpty(t, response.Pagination.NextCursor)
+
+		links := w.Header().Get("Link")
+		assert.Contains(t, links, `</api/v1/tenants?limit=10&name%5Blike%5D=acme&sort=-status%2Cname>; rel="first"`)
+		assert.Contains(t, links, `</api/v1/tenants?limit=10&name%5Blike%5D=acme&sort=-status%2Cname>; rel="prev"`)
+		assert.Contains(t, links, `</api/v1/tenants?limit=10&name%5Blike%5D=acme&offset=20&sort=-status%2Cname>; rel="next"`)
+		assert.Contains(t, links, `</api/v1/tenants?limit=10&name%5Blike%5D=acme&offset=30&sort=-status%2Cname>; rel="last"`)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Cursor da página anterior", func(t *testing.T) {
+		mockRepo := new(mocks.MockTenantRepository)
+		handler := handlers_v1.NewTenantsHandler(services.NewTenantService(mockRepo, nil, nil))
+		mockRepo.On("GetAll", mock.Anything, mock.Anything).
+			Return(&models.Page[models.Tenant]{Items: tenants, Total: 3, NextCursor: []string{"Acme", "id-1"}}, nil).Once()
+
+		w := get(handler, "/api/v1/tenants?limit=1")
+		var response models.ListResponse[models.Tenant]
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		cursor := response.Pagination.NextCursor
+
+		query := models.ListQuery{Sort: []models.ListSort{{Column: "name"}}, Limit: 1, Cursor: []string{"Acme", "id-1"}}
+		mockRepo.On("GetAll", mock.Anything, query).Return(&models.Page[models.Tenant]{Items: tenants, Total: 3}, nil).Once()
+
+		w = get(handler, "/api/v1/tenants?limit=1&cursor="+cursor)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Header().Get("Link"), `rel="next"`)
+		mockRepo.AssertExpectations(t)
+
+		// O cursor gerado para uma ordenação não vale para outra
+		w = get(handler, "/api/v1/tenants?limit=1&sort=-name&cursor="+cursor)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Parâmetros inválidos", func(t *testing.T) {
+		mockRepo := new(mocks.MockTenantRepository)
+		handler := handlers_v1.NewTenantsHandler(services.NewTenantService(mockRepo, nil, nil))
+
+		for _, target := range []string{
+			"/api/v1/tenants?limit=0",
+			"/api/v1/tenants?limit=500",
+			"/api/v1/tenants?offset=-1",
+			"/api/v1/tenants?allowed_origins=x",
+			"/api/v1/tenants?state[like]=S",
+			"/api/v1/tenants?status=BLOQUEADO",
+			"/api/v1/tenants?created_at[gte]=ontem",
+			"/api/v1/tenants?sort=cpf_cnpj",
+			"/api/v1/tenants?sort=name,-name",
+			"/api/v1/tenants?cursor=abc",
+			"/api/v1/tenants?offset=10&cursor=abc",
+		} {
+			w := get(handler, target)
+			assert.Equal(t, http.StatusBadRequest, w.Code, target)
+		}
+		mockRepo.AssertNotCalled(t, "GetAll", mock.Anything, mock.Anything)
+	})
+}
+
 func TestTenantsHandler_Create(t *testing.T) {
 	mockRepo := new(mocks.MockTenantRepository)
-	service := services.NewTenantService(mockRepo)
+	apiKeyRepo := new(mocks.MockApiKeyRepository)
+	service := services.NewTenantService(mockRepo, services.NewApiKeyService(apiKeyRepo, mocks.NewRedisService(t)), nil)
 	handler := handlers_v1.NewTenantsHandler(service)
 
 	tenant := models.Tenant{
@@ -59,6 +156,10 @@ func TestTenantsHandler_Create(t *testing.T) {
 		Name:      "New Tenant",
 	}
 	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Tenant")).Return(&tenant, nil)
+	apiKeyRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.ApiKey")).Return(func(_ *gin.Context, key *models.ApiKey) *models.ApiKey {
+		key.ID = uuid.New()
+		return key
+	}, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -74,17 +175,21 @@ func TestTenantsHandler_Create(t *testing.T) {
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
 	assert.Equal(t, tenant.Name, response.Name)
+	if assert.NotNil(t, response.ApiKey) {
+		assert.NotEmpty(t, *response.ApiKey)
+	}
 	mockRepo.AssertExpectations(t)
+	apiKeyRepo.AssertExpectations(t)
 }
 
 func TestTenantsHandler_GetByID(t *testing.T) {
 	mockRepo := new(mocks.MockTenantRepository)
-	service := services.NewTenantService(mockRepo)
+	service := services.NewTenantService(mockRepo, nil, nil)
 	handler := handlers_v1.NewTenantsHandler(service)
 
 	tenantID := uuid.New()
 	tenant := models.Tenant{
-		BaseModel: models.BaseModel{ID: tenantID},
+		BaseModel: models.BaseModel{ID: tenantID, Version: 3},
 		Name:      "Tenant 1",
 	}
 	mockRepo.On("GetByID", mock.Anything, tenantID).Return(&tenant, nil)
@@ -100,15 +205,60 @@ func TestTenantsHandler_GetByID(t *testing.T) {
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
 	assert.Equal(t, tenantID, response.ID)
+	assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTenantsHandler_IfMatch(t *testing.T) {
+	mockRepo := new(mocks.MockTenantRepository)
+	handler := handlers_v1.NewTenantsHandler(services.NewTenantService(mockRepo, nil, nil))
+	tenantID := uuid.New()
+	request := func(method, ifMatch string, body []byte) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{gin.Param{Key: "id", Value: tenantID.String()}}
+		c.Request = httptest.NewRequest(method, "/api/v1/tenants/"+tenantID.String(), bytes.NewBuffer(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Request.Header.Set("If-Match", ifMatch)
+		if method == http.MethodDelete {
+			handler.Delete(c)
+		} else {
+			handler.UpdatePatch(c)
+		}
+		return w
+	}
+	// As versões do If-Match chegam ao repositório pelo contexto
+	withVersions := func(versions ...int) interface{} {
+		return mock.MatchedBy(func(c *gin.Context) bool {
+			value, _ := c.Get(string(contextkeys.IfMatchKey))
+			return assert.ObjectsAreEqual(versions, value)
+		})
+	}
+
+	updateData := map[string]interface{}{"city": "Chapecó"}
+	mockRepo.On("UpdatePartial", withVersions(3), tenantID, updateData).Return((*models.Tenant)(nil), models.ErrVersionMismatch).Once()
+	w := request(http.MethodPatch, `"3"`, []byte(`{"city": "Chapecó"}`))
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+
+	mockRepo.On("Delete", withVersions(3, 4), tenantID).Return(models.ErrVersionMismatch).Once()
+	w = request(http.MethodDelete, `"3", "4"`, nil)
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+
+	// ETag fraca nunca corresponde a uma versão: 412 sem consultar o banco
+	w = request(http.MethodPatch, `W/"3"`, []byte(`{"city": "Chapecó"}`))
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
 	mockRepo.AssertExpectations(t)
 }
 
 func TestTenantsHandler_Update(t *testing.T) {
 	mockRepo := new(mocks.MockTenantRepository)
-	service := services.NewTenantService(mockRepo)
+	redisService := mocks.NewRedisService(t)
+	tenantStatusService := mocks.NewTenantStatusService(t)
+	service := services.NewTenantService(mockRepo, services.NewApiKeyService(new(mocks.MockApiKeyRepository), redisService), tenantStatusService)
 	handler := handlers_v1.NewTenantsHandler(service)
 
 	tenantID := uuid.New()
+	expectApiKeyCacheInvalidation(redisService, tenantID)
 	tenant := models.Tenant{
 		BaseModel: models.BaseModel{ID: tenantID},
 		Name:      "Updated Tenant",
@@ -116,6 +266,7 @@ func TestTenantsHandler_Update(t *testing.T) {
 
 	// Corrige a configuração do mock para incluir o UUID
 	mockRepo.On("Update", mock.Anything, tenantID, &tenant).Return(&tenant, nil)
+	tenantStatusService.On("Reactivate", tenantID).Return(nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -137,11 +288,15 @@ func TestTenantsHandler_Update(t *testing.T) {
 
 func TestTenantsHandler_Delete(t *testing.T) {
 	mockRepo := new(mocks.MockTenantRepository)
-	service := services.NewTenantService(mockRepo)
+	redisService := mocks.NewRedisService(t)
+	tenantStatusService := mocks.NewTenantStatusService(t)
+	service := services.NewTenantService(mockRepo, services.NewApiKeyService(new(mocks.MockApiKeyRepository), redisService), tenantStatusService)
 	handler := handlers_v1.NewTenantsHandler(service)
 
 	tenantID := uuid.New()
+	expectApiKeyCacheInvalidation(redisService, tenantID)
 	mockRepo.On("Delete", mock.Anything, tenantID).Return(nil)
+	tenantStatusService.On("Suspend", mock.Anything, tenantID).Return(nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -154,3 +309,148 @@ func TestTenantsHandler_Delete(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 	mockRepo.AssertExpectations(t)
 }
+
+// expectApiKeyCacheInvalidation configura a remoção das API Keys do tenant do cache, sem entradas em cache.
+func expectApiKeyCacheInvalidation(redisService *mocks.RedisService, tenantID uuid.UUID) {
+	redisService.On("Incr", "apiKey_generation", time.Duration(0)).Return(int64(1), nil)
+	redisService.On("HGetAll", "apiKey_tenant:"+tenantID.String()).Return(map[string]string{}, nil)
+	redisService.On("Delete", "apiKey_tenant:"+tenantID.String()).Return(nil)
+}
+
+func TestTenantsHandler_Search(t *testing.T) {
+	mockRepo := new(mocks.MockTenantRepository)
+	handler := handlers_v1.NewTenantsHandler(services.NewTenantService(mockRepo, nil, nil))
+	search := func(target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+		handler.Search(c)
+		return w
+	}
+
+	tenants := []models.Tenant{{BaseModel: models.BaseModel{ID: uuid.New()}, Name: "Padaria São João"}}
+	mockRepo.On("SearchTenants", mock.Anything, "sao joao", 20).Return(tenants, nil).Once()
+	mockRepo.On("SearchTenants", mock.Anything, "12.345", 5).Return([]models.Tenant{}, nil).Once()
+
+	w := search("/api/v1/tenants/search?q=sao+joao")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.SearchResponse[models.Tenant]
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, tenants[0].ID, response.Data[0].ID)
+
+	w = search("/api/v1/tenants/search?q=12.345&limit=5")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"data": []}`, w.Body.String())
+
+	for _, target := range []string{"/api/v1/tenants/search", "/api/v1/tenants/search?q=a", "/api/v1/tenants/search?q=acme&limit=51"} {
+		assert.Equal(t, http.StatusBadRequest, search(target).Code, target)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTenantsHandler_Trash(t *testing.T) {
+	mockRepo := new(mocks.MockTenantRepository)
+	handler := handlers_v1.NewTenantsHandler(services.NewTenantService(mockRepo, nil, nil))
+
+	tenants := []models.Tenant{{BaseModel: models.BaseModel{ID: uuid.New()}, Name: "Tenant removido"}}
+	query := models.ListQuery{
+		Filters: []models.ListFilter{{Column: "deleted_at", Op: models.FilterGte, Values: []string{"2025-01-01"}}},
+		Sort:    []models.ListSort{{Column: "deleted_at", Desc: true}},
+		Limit:   50,
+	}
+	mockRepo.On("FindDeletedTenants", mock.Anything, query).Return(&models.Page[models.Tenant]{Items: tenants, Total: 1}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/tenants/trash?deleted_at[gte]=2025-01-01", nil)
+
+	handler.GetTrash(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.ListResponse[models.Tenant]
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, tenants[0].ID, response.Data[0].ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTenantsHandler_Restore(t *testing.T) {
+	tenantID := uuid.New()
+	restore := func(handler *handlers_v1.TenantsHandler, id string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{gin.Param{Key: "id", Value: id}}
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/tenants/"+id+"/restore", nil)
+		handler.Restore(c)
+		return w
+	}
+
+	t.Run("Restaurado", func(t *testing.T) {
+		mockRepo := new(mocks.MockTenantRepository)
+		tenantStatusService := mocks.NewTenantStatusService(t)
+		handler := handlers_v1.NewTenantsHandler(services.NewTenantService(mockRepo, nil, tenantStatusService))
+
+		mockRepo.On("RestoreTenant", mock.Anything, tenantID).Return(&models.Tenant{BaseModel: models.BaseModel{ID: tenantID}, Status: enums.Ativo}, nil)
+		tenantStatusService.On("Reactivate", tenantID).Return(nil)
+
+		w := restore(handler, tenantID.String())
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.Tenant
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, tenantID, response.ID)
+	})
+
+	t.Run("Fora da lixeira, em conflito ou com ID inválido", func(t *testing.T) {
+		mockRepo := new(mocks.MockTenantRepository)
+		handler := handlers_v1.NewTenantsHandler(services.NewTenantService(mockRepo, nil, nil))
+
+		mockRepo.On("RestoreTenant", mock.Anything, tenantID).Return(nil, gorm.ErrRecordNotFound).Once()
+		assert.Equal(t, http.StatusNotFound, restore(handler, tenantID.String()).Code)
+
+		mockRepo.On("RestoreTenant", mock.Anything, tenantID).Return(nil, gorm.ErrDuplicatedKey).Once()
+		assert.Equal(t, http.StatusConflict, restore(handler, tenantID.String()).Code)
+
+		assert.Equal(t, http.StatusBadRequest, restore(handler, "invalido").Code)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestTenantsHandler_DeleteHard(t *testing.T) {
+	tenantID := uuid.New()
+	purge := func(handler *handlers_v1.TenantsHandler) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{gin.Param{Key: "id", Value: tenantID.String()}}
+		c.Request = httptest.NewRequest(http.MethodDelete, "/api/v1/tenants/"+tenantID.String()+"?hard=true", nil)
+		handler.Delete(c)
+		return w
+	}
+
+	t.Run("Removido definitivamente", func(t *testing.T) {
+		mockRepo := new(mocks.MockTenantRepository)
+		redisService := mocks.NewRedisService(t)
+		tenantStatusService := mocks.NewTenantStatusService(t)
+		handler := handlers_v1.NewTenantsHandler(services.NewTenantService(mockRepo, services.NewApiKeyService(new(mocks.MockApiKeyRepository), redisService), tenantStatusService))
+
+		mockRepo.On("PurgeTenant", mock.Anything, tenantID).Return(nil)
+		expectApiKeyCacheInvalidation(redisService, tenantID)
+		tenantStatusService.On("Reactivate", tenantID).Return(nil)
+
+		w := purge(handler)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Não encontrado ou com dependentes", func(t *testing.T) {
+		mockRepo := new(mocks.MockTenantRepository)
+		handler := handlers_v1.NewTenantsHandler(services.NewTenantService(mockRepo, nil, nil))
+
+		mockRepo.On("PurgeTenant", mock.Anything, tenantID).Return(gorm.ErrRecordNotFound).Once()
+		assert.Equal(t, http.StatusNotFound, purge(handler).Code)
+
+		mockRepo.On("PurgeTenant", mock.Anything, tenantID).Return(gorm.ErrForeignKeyViolated).Once()
+		assert.Equal(t, http.StatusConflict, purge(handler).Code)
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,90 @@
+// tests/internal/handlers_v1/user_access_handle_test.go
+
+package handlers_v1_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/handlers_v1"
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+	"github.com/jeancarlosdanese/go-base-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUserAccessHandler_AddRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userService := mocks.NewUserService(t)
+	securityService := mocks.NewSecurityService(t)
+	handler := handlers_v1.NewUserAccessHandler(userService, securityService)
+
+	userID := uuid.New()
+	params := gin.Params{{Key: "id", Value: userID.String()}}
+
+	t.Run("role atribuído", func(t *testing.T) {
+		userService.On("GetByID", mock.Anything, userID).Return(&models.User{BaseModel: models.BaseModel{ID: userID}}, nil).Once()
+		securityService.On("AddUserRole", mock.Anything, userID, uint(2)).Return(nil).Once()
+
+		w, c := newSecurityRequest("POST", "/users/"+userID.String()+"/roles", `{"role_id":2}`, params)
+		handler.AddRole(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("role já atribuído", func(t *testing.T) {
+		userService.On("GetByID", mock.Anything, userID).Return(&models.User{BaseModel: models.BaseModel{ID: userID}}, nil).Once()
+		securityService.On("AddUserRole", mock.Anything, userID, uint(2)).Return(services.ErrSecurityConflict).Once()
+
+		w, c := newSecurityRequest("POST", "/users/"+userID.String()+"/roles", `{"role_id":2}`, params)
+		handler.AddRole(c)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("usuário de outro tenant", func(t *testing.T) {
+		otherID := uuid.New()
+		userService.On("GetByID", mock.Anything, otherID).Return(nil, errors.New("record not found")).Once()
+
+		w, c := newSecurityRequest("POST", "/users/"+otherID.String()+"/roles", `{"role_id":2}`, gin.Params{{Key: "id", Value: otherID.String()}})
+		handler.AddRole(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		securityService.AssertNotCalled(t, "AddUserRole", mock.Anything, otherID, mock.Anything)
+	})
+}
+
+func TestUserAccessHandler_UpdatePolicy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userService := mocks.NewUserService(t)
+	securityService := mocks.NewSecurityService(t)
+	handler := handlers_v1.NewUserAccessHandler(userService, securityService)
+
+	userID := uuid.New()
+	params := gin.Params{{Key: "id", Value: userID.String()}, {Key: "endpoint_id", Value: "4"}}
+	userService.On("GetByID", mock.Anything, userID).Return(&models.User{BaseModel: models.BaseModel{ID: userID}}, nil)
+
+	t.Run("política atualizada", func(t *testing.T) {
+		input := models.PolicyActionsInput{Actions: "GET"}
+		securityService.On("UpdateUserPolicy", mock.Anything, userID, uint(4), input).Return(&models.PolicyUser{UserID: userID, EndpointID: 4, Actions: "GET"}, nil).Once()
+
+		w, c := newSecurityRequest("PUT", "/users/"+userID.String()+"/policies/4", `{"actions":"GET"}`, params)
+		handler.UpdatePolicy(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("política inexistente", func(t *testing.T) {
+		input := models.PolicyActionsInput{Actions: "GET|PUT"}
+		securityService.On("UpdateUserPolicy", mock.Anything, userID, uint(4), input).Return(nil, services.ErrPolicyNotFound).Once()
+
+		w, c := newSecurityRequest("PUT", "/users/"+userID.String()+"/policies/4", `{"actions":"GET|PUT"}`, params)
+		handler.UpdatePolicy(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -5,25 +5,30 @@ package handlers_v1_test
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	contextkeys "github.com/jeancarlosdanese/go-base-api/internal/domain/context_keys"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/enums"
 	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
 	"github.com/jeancarlosdanese/go-base-api/internal/handlers_v1"
 	"github.com/jeancarlosdanese/go-base-api/internal/services"
 	"github.com/jeancarlosdanese/go-base-api/tests/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/datatypes"
 )
 
 func TestUsersHandler_GetAll(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	userService := services.NewUserService(mockRepo)
-	handler := handlers_v1.NewUsersHandler(userService)
+	userService := services.NewUserService(mockRepo, services.NewBcryptPasswordHasher(bcrypt.MinCost), nil, mocks.NewCasbinService(t), mocks.NewTokenRedisService(t))
+	handler := handlers_v1.NewUsersHandler(userService, mocks.NewLoginAttemptService(t), mocks.NewUserStatusService(t))
 
 	users := []models.User{
 		{
@@ -37,25 +42,52 @@ func TestUsersHandler_GetAll(t *testing.T) {
 			Name:      "User Two",
 		},
 	}
-	mockRepo.On("GetAll", mock.Anything).Return(users, nil)
+	query := models.ListQuery{
+		Filters: []models.ListFilter{{Column: "status", Op: models.FilterIn, Values: []string{"ATIVO", "PENDENTE"}}},
+		Sort:    []models.ListSort{{Column: "created_at", Desc: true}},
+		Limit:   50,
+	}
+	mockRepo.On("GetAll", mock.Anything, query).Return(&models.Page[models.User]{Items: users, Total: 2}, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/users?status[in]=ATIVO,PENDENTE&sort=-created_at", nil)
 
 	handler.GetAll(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
-	var response []models.User
+	var response models.ListResponse[models.User]
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.Len(t, response, 2)
+	assert.Len(t, response.Data, 2)
+	assert.Equal(t, int64(2), response.Pagination.Total)
 	mockRepo.AssertExpectations(t)
 }
 
+func TestUsersHandler_Search(t *testing.T) {
+	userService := mocks.NewUserService(t)
+	handler := handlers_v1.NewUsersHandler(userService, mocks.NewLoginAttemptService(t), mocks.NewUserStatusService(t))
+
+	users := []models.User{{BaseModel: models.BaseModel{ID: uuid.New()}, Name: "José Silva", Username: "jsilva"}}
+	userService.On("Search", mock.Anything, "jose silv", 20).Return(users, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/users/search?q=jose+silv", nil)
+
+	handler.Search(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.SearchResponse[models.User]
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response.Data, 1)
+}
+
 func TestUsersHandler_Create(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	service := services.NewUserService(mockRepo)
-	handler := handlers_v1.NewUsersHandler(service)
+	service := services.NewUserService(mockRepo, services.NewBcryptPasswordHasher(bcrypt.MinCost), nil, mocks.NewCasbinService(t), mocks.NewTokenRedisService(t))
+	userStatus := mocks.NewUserStatusService(t)
+	handler := handlers_v1.NewUsersHandler(service, mocks.NewLoginAttemptService(t), userStatus)
 
 	user := models.User{
 		BaseModel: models.BaseModel{ID: uuid.New()},
@@ -63,7 +95,10 @@ func TestUsersHandler_Create(t *testing.T) {
 		Name:      "New User",
 		Email:     "newuser@example.com",
 	}
-	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.User")).Return(&user, nil)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *models.User) bool {
+		return u.Status == enums.UserPendente
+	})).Return(&user, nil)
+	userStatus.On("SendVerificationEmail", mock.Anything, &user).Return(nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -96,8 +131,8 @@ func TestUsersHandler_Create(t *testing.T) {
 
 func TestUsersHandler_GetByID(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	service := services.NewUserService(mockRepo)
-	handler := handlers_v1.NewUsersHandler(service)
+	service := services.NewUserService(mockRepo, services.NewBcryptPasswordHasher(bcrypt.MinCost), nil, mocks.NewCasbinService(t), mocks.NewTokenRedisService(t))
+	handler := handlers_v1.NewUsersHandler(service, mocks.NewLoginAttemptService(t), mocks.NewUserStatusService(t))
 
 	userID := uuid.New()
 	user := models.User{
@@ -125,8 +160,8 @@ func TestUsersHandler_GetByID(t *testing.T) {
 
 func TestUsersHandler_Update(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	service := services.NewUserService(mockRepo)
-	handler := handlers_v1.NewUsersHandler(service)
+	service := services.NewUserService(mockRepo, services.NewBcryptPasswordHasher(bcrypt.MinCost), nil, mocks.NewCasbinService(t), mocks.NewTokenRedisService(t))
+	handler := handlers_v1.NewUsersHandler(service, mocks.NewLoginAttemptService(t), mocks.NewUserStatusService(t))
 
 	userID := uuid.New()
 	tenantID := uuid.New() // Suponha que esta é a identificação do tenant necessária
@@ -139,6 +174,8 @@ func TestUsersHandler_Update(t *testing.T) {
 	}
 
 	// Configurando o mock para esperar o contexto, o UUID do usuário e o objeto usuário
+	// O e-mail não muda: o link de verificação pendente continua valendo
+	mockRepo.On("GetByID", mock.Anything, userID).Return(&models.User{BaseModel: models.BaseModel{ID: userID}, Email: user.Email}, nil)
 	mockRepo.On("Update", mock.Anything, userID, &user).Return(&user, nil)
 
 	w := httptest.NewRecorder()
@@ -164,8 +201,8 @@ func TestUsersHandler_Update(t *testing.T) {
 
 func TestUsersHandler_UpdatePartial(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	service := services.NewUserService(mockRepo)
-	handler := handlers_v1.NewUsersHandler(service)
+	service := services.NewUserService(mockRepo, services.NewBcryptPasswordHasher(bcrypt.MinCost), nil, mocks.NewCasbinService(t), mocks.NewTokenRedisService(t))
+	handler := handlers_v1.NewUsersHandler(service, mocks.NewLoginAttemptService(t), mocks.NewUserStatusService(t))
 
 	userID := uuid.New()
 	updateData := map[string]interface{}{
@@ -195,10 +232,39 @@ func TestUsersHandler_UpdatePartial(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestUsersHandler_UpdatePartialEmailChange(t *testing.T) {
+	userService := mocks.NewUserService(t)
+	userStatus := mocks.NewUserStatusService(t)
+	handler := handlers_v1.NewUsersHandler(userService, mocks.NewLoginAttemptService(t), userStatus)
+	userID := uuid.New()
+	updateData := map[string]interface{}{"email": "novo@example.com"}
+
+	verifiedAt := time.Now()
+	userService.On("GetByID", mock.Anything, userID).
+		Return(&models.User{BaseModel: models.BaseModel{ID: userID}, Email: "antigo@example.com", EmailVerifiedAt: &verifiedAt}, nil)
+	// A verificação é desfeita no banco; o link enviado ao e-mail anterior é invalidado
+	userService.On("UpdatePartial", mock.Anything, userID, updateData).
+		Return(&models.User{BaseModel: models.BaseModel{ID: userID, Version: 2}, Email: "novo@example.com"}, nil)
+	userStatus.On("CancelEmailVerification", userID).Return(nil).Once()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: userID.String()}}
+	c.Request = httptest.NewRequest(http.MethodPatch, "/api/v1/users/"+userID.String(), bytes.NewBufferString(`{"email": "novo@example.com", "email_verified_at": "2025-01-01T00:00:00Z"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	handler.UpdatePartial(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.User
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Nil(t, response.EmailVerifiedAt)
+}
+
 func TestUsersHandler_Delete(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	service := services.NewUserService(mockRepo)
-	handler := handlers_v1.NewUsersHandler(service)
+	service := services.NewUserService(mockRepo, services.NewBcryptPasswordHasher(bcrypt.MinCost), nil, mocks.NewCasbinService(t), mocks.NewTokenRedisService(t))
+	handler := handlers_v1.NewUsersHandler(service, mocks.NewLoginAttemptService(t), mocks.NewUserStatusService(t))
 
 	userID := uuid.New()
 	mockRepo.On("Delete", mock.Anything, userID).Return(nil)
@@ -214,3 +280,229 @@ func TestUsersHandler_Delete(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestUsersHandler_Trash(t *testing.T) {
+	userService := mocks.NewUserService(t)
+	handler := handlers_v1.NewUsersHandler(userService, mocks.NewLoginAttemptService(t), mocks.NewUserStatusService(t))
+
+	users := []models.User{{BaseModel: models.BaseModel{ID: uuid.New()}, Username: "removido"}}
+	query := models.ListQuery{Sort: []models.ListSort{{Column: "deleted_at", Desc: true}}, Limit: 50}
+	userService.On("GetTrash", mock.Anything, query).Return(&models.Page[models.User]{Items: users, Total: 1}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/users/trash", nil)
+
+	handler.GetTrash(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.ListResponse[models.User]
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, users[0].ID, response.Data[0].ID)
+}
+
+func TestUsersHandler_Restore(t *testing.T) {
+	userService := mocks.NewUserService(t)
+	handler := handlers_v1.NewUsersHandler(userService, mocks.NewLoginAttemptService(t), mocks.NewUserStatusService(t))
+	userID := uuid.New()
+	restore := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{gin.Param{Key: "id", Value: userID.String()}}
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/users/"+userID.String()+"/restore", nil)
+		handler.Restore(c)
+		return w
+	}
+
+	userService.On("Restore", mock.Anything, userID).Return(&models.User{BaseModel: models.BaseModel{ID: userID}}, nil).Once()
+	assert.Equal(t, http.StatusOK, restore().Code)
+
+	userService.On("Restore", mock.Anything, userID).Return(nil, services.ErrNotFound).Once()
+	assert.Equal(t, http.StatusNotFound, restore().Code)
+
+	userService.On("Restore", mock.Anything, userID).Return(nil, services.ErrRestoreConflict).Once()
+	w := restore()
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), services.ErrRestoreConflict.Error())
+}
+
+func TestUsersHandler_DeleteHard(t *testing.T) {
+	userService := mocks.NewUserService(t)
+	handler := handlers_v1.NewUsersHandler(userService, mocks.NewLoginAttemptService(t), mocks.NewUserStatusService(t))
+	userID := uuid.New()
+	purge := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{gin.Param{Key: "id", Value: userID.String()}}
+		c.Request = httptest.NewRequest(http.MethodDelete, "/api/v1/users/"+userID.String()+"?hard=true", nil)
+		handler.Delete(c)
+		return w
+	}
+
+	userService.On("Purge", mock.Anything, userID).Return(nil).Once()
+	assert.Equal(t, http.StatusOK, purge().Code)
+
+	userService.On("Purge", mock.Anything, userID).Return(services.ErrNotFound).Once()
+	assert.Equal(t, http.StatusNotFound, purge().Code)
+	userService.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestUsersHandler_UpdatePartialIfMatch(t *testing.T) {
+	userService := mocks.NewUserService(t)
+	handler := handlers_v1.NewUsersHandler(userService, mocks.NewLoginAttemptService(t), mocks.NewUserStatusService(t))
+	userID := uuid.New()
+	patch := func(ifMatch string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{gin.Param{Key: "id", Value: userID.String()}}
+		c.Request = httptest.NewRequest(http.MethodPatch, "/api/v1/users/"+userID.String(), bytes.NewBufferString(`{"name": "Maria"}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+		if ifMatch != "" {
+			c.Request.Header.Set("If-Match", ifMatch)
+		}
+		handler.UpdatePartial(c)
+		return w
+	}
+	withVersion := func(version int) interface{} {
+		return mock.MatchedBy(func(c *gin.Context) bool {
+			value, _ := c.Get(string(contextkeys.IfMatchKey))
+			return assert.ObjectsAreEqual([]int{version}, value)
+		})
+	}
+	updateData := map[string]interface{}{"name": "Maria"}
+
+	userService.On("UpdatePartial", withVersion(4), userID, updateData).
+		Return(&models.User{BaseModel: models.BaseModel{ID: userID, Version: 5}, Name: "Maria"}, nil).Once()
+	w := patch(`"4"`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"5"`, w.Header().Get("ETag"))
+
+	userService.On("UpdatePartial", withVersion(4), userID, updateData).Return(nil, models.ErrVersionMismatch).Once()
+	assert.Equal(t, http.StatusPreconditionFailed, patch(`"4"`).Code)
+
+	// Sem If-Match (ou com *), a alteração não é condicionada
+	userService.On("UpdatePartial", mock.MatchedBy(func(c *gin.Context) bool {
+		_, exists := c.Get(string(contextkeys.IfMatchKey))
+		return !exists
+	}), userID, updateData).Return(&models.User{BaseModel: models.BaseModel{ID: userID, Version: 6}}, nil).Twice()
+	assert.Equal(t, http.StatusOK, patch("").Code)
+	assert.Equal(t, http.StatusOK, patch("*").Code)
+}
+
+func TestUsersHandler_Unlock(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	service := services.NewUserService(mockRepo, services.NewBcryptPasswordHasher(bcrypt.MinCost), nil, mocks.NewCasbinService(t), mocks.NewTokenRedisService(t))
+	loginAttempts := mocks.NewLoginAttemptService(t)
+	handler := handlers_v1.NewUsersHandler(service, loginAttempts, mocks.NewUserStatusService(t))
+
+	userID := uuid.New()
+	user := &models.User{BaseModel: models.BaseModel{ID: userID}, Email: "john@example.com"}
+	origins := datatypes.JSON(`["app.acme.com", "admin.acme.com"]`)
+	mockRepo.On("GetByID", mock.Anything, userID).Return(user, nil)
+	mockRepo.On("FindByIDWithPolicies", mock.Anything, userID).Return(&models.User{
+		BaseModel: user.BaseModel,
+		Email:     user.Email,
+		Tenant:    &models.Tenant{AllowedOrigins: &origins},
+	}, nil)
+	// Apenas as origens do tenant do usuário são desbloqueadas
+	loginAttempts.On("Reset", "john@example.com", "app.acme.com", "admin.acme.com").Return(nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: userID.String()}}
+
+	c.Request = httptest.NewRequest("POST", "/users/"+userID.String()+"/unlock", nil)
+
+	handler.Unlock(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUsersHandler_Disable(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	service := services.NewUserService(mockRepo, services.NewBcryptPasswordHasher(bcrypt.MinCost), nil, mocks.NewCasbinService(t), mocks.NewTokenRedisService(t))
+	userStatus := mocks.NewUserStatusService(t)
+	handler := handlers_v1.NewUsersHandler(service, mocks.NewLoginAttemptService(t), userStatus)
+
+	userID := uuid.New()
+	user := &models.User{BaseModel: models.BaseModel{ID: userID}, Status: enums.UserAtivo}
+	mockRepo.On("GetByID", mock.Anything, userID).Return(user, nil)
+	userStatus.On("Disable", mock.Anything, user).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.User).Status = enums.UserDesativado
+	}).Return(nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: userID.String()}}
+	c.Request = httptest.NewRequest("POST", "/users/"+userID.String()+"/disable", nil)
+
+	handler.Disable(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.User
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, enums.UserDesativado, response.Status)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUsersHandler_Disable_NotFound(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	service := services.NewUserService(mockRepo, services.NewBcryptPasswordHasher(bcrypt.MinCost), nil, mocks.NewCasbinService(t), mocks.NewTokenRedisService(t))
+	handler := handlers_v1.NewUsersHandler(service, mocks.NewLoginAttemptService(t), mocks.NewUserStatusService(t))
+
+	userID := uuid.New()
+	mockRepo.On("GetByID", mock.Anything, userID).Return((*models.User)(nil), errors.New("record not found"))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: userID.String()}}
+	c.Request = httptest.NewRequest("POST", "/users/"+userID.String()+"/disable", nil)
+
+	handler.Disable(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestUsersHandler_Enable(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	service := services.NewUserService(mockRepo, services.NewBcryptPasswordHasher(bcrypt.MinCost), nil, mocks.NewCasbinService(t), mocks.NewTokenRedisService(t))
+	userStatus := mocks.NewUserStatusService(t)
+	handler := handlers_v1.NewUsersHandler(service, mocks.NewLoginAttemptService(t), userStatus)
+
+	userID := uuid.New()
+	user := &models.User{BaseModel: models.BaseModel{ID: userID}, Status: enums.UserDesativado}
+	mockRepo.On("GetByID", mock.Anything, userID).Return(user, nil)
+	userStatus.On("Enable", mock.Anything, user).Return(nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: userID.String()}}
+	c.Request = httptest.NewRequest("POST", "/users/"+userID.String()+"/enable", nil)
+
+	handler.Enable(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUsersHandler_SendVerificationEmail_AlreadyVerified(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	service := services.NewUserService(mockRepo, services.NewBcryptPasswordHasher(bcrypt.MinCost), nil, mocks.NewCasbinService(t), mocks.NewTokenRedisService(t))
+	userStatus := mocks.NewUserStatusService(t)
+	handler := handlers_v1.NewUsersHandler(service, mocks.NewLoginAttemptService(t), userStatus)
+
+	userID := uuid.New()
+	user := &models.User{BaseModel: models.BaseModel{ID: userID}, Status: enums.UserAtivo}
+	mockRepo.On("GetByID", mock.Anything, userID).Return(user, nil)
+	userStatus.On("SendVerificationEmail", mock.Anything, user).Return(services.ErrEmailAlreadyVerified)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: userID.String()}}
+	c.Request = httptest.NewRequest("POST", "/users/"+userID.String()+"/verification-email", nil)
+
+	handler.SendVerificationEmail(c)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
@@ -0,0 +1,66 @@
+// tests/internal/mailer/mailer_test.go
+
+package mailer_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jeancarlosdanese/go-base-api/internal/logging"
+	"github.com/jeancarlosdanese/go-base-api/internal/mailer"
+	"github.com/jeancarlosdanese/go-base-api/internal/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileMailer_Send(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mails")
+	m, err := mailer.NewMailer(settings.MailerConfig{Driver: "file", From: "no-reply@example.com", OutputDir: dir})
+	require.NoError(t, err)
+
+	err = m.Send(context.Background(), mailer.Message{To: "john@example.com", Subject: "Redefinição de senha", Body: "token-123"})
+	require.NoError(t, err)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	content, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "To: john@example.com")
+	assert.Contains(t, string(content), "Subject: Redefinição de senha")
+	assert.Contains(t, string(content), "token-123")
+}
+
+func TestLogMailer_SendRedactsSecret(t *testing.T) {
+	var output bytes.Buffer
+	previous := logging.InfoLogger
+	logging.InfoLogger = log.New(&output, "", 0)
+	t.Cleanup(func() { logging.InfoLogger = previous })
+
+	m, err := mailer.NewMailer(settings.MailerConfig{Driver: "log", From: "no-reply@example.com"})
+	require.NoError(t, err)
+
+	err = m.Send(context.Background(), mailer.Message{
+		To:      "john@example.com",
+		Subject: "Redefinição de senha",
+		Body:    "Acesse http://localhost/reset?token=a%2Bb para redefinir. Token: a+b",
+		Secret:  "a+b",
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, output.String(), "john@example.com")
+	assert.Contains(t, output.String(), "?token=[REDACTED]")
+	assert.NotContains(t, output.String(), "a+b")
+	assert.NotContains(t, output.String(), "a%2Bb")
+}
+
+func TestNewMailer_UnknownDriver(t *testing.T) {
+	_, err := mailer.NewMailer(settings.MailerConfig{Driver: "smtp"})
+
+	assert.Error(t, err)
+}
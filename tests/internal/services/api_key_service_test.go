@@ -0,0 +1,299 @@
+// tests/internal/services/api_key_service_test.go
+
+package services_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	autherrors "github.com/jeancarlosdanese/go-base-api/internal/domain/auth_errors"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/enums"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+	"github.com/jeancarlosdanese/go-base-api/tests/mocks"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// expectApiKeyCacheInvalidation configura a remoção das API Keys do tenant do cache: a entrada indexada e o próprio índice.
+func expectApiKeyCacheInvalidation(redisService *mocks.RedisService, tenantID uuid.UUID) {
+	indexKey := "apiKey_tenant:" + tenantID.String()
+	redisService.On("Incr", "apiKey_generation", time.Duration(0)).Return(int64(1), nil)
+	redisService.On("HGetAll", indexKey).Return(map[string]string{"apiKey:hash:app.example.com": "1"}, nil)
+	redisService.On("Delete", "apiKey:hash:app.example.com", indexKey).Return(nil)
+}
+
+func returnApiKeyWithID(_ *gin.Context, key *models.ApiKey) *models.ApiKey {
+	key.ID = uuid.New()
+	return key
+}
+
+func TestApiKeyService_Create(t *testing.T) {
+	c, _ := gin.CreateTestContext(nil)
+	tenantID := uuid.New()
+
+	t.Run("Armazena apenas hash e prefixo", func(t *testing.T) {
+		repo := new(mocks.MockApiKeyRepository)
+		service := services.NewApiKeyService(repo, mocks.NewRedisService(t))
+
+		repo.On("Create", c, mock.AnythingOfType("*models.ApiKey")).Return(returnApiKeyWithID, nil)
+
+		created, err := service.Create(c, tenantID, models.ApiKeyCreate{Name: "integração", Scopes: []string{"orders:read"}})
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, created.Key)
+		assert.Equal(t, tenantID, created.TenantID)
+		assert.Equal(t, sha256Hex(created.Key), created.KeyHash)
+		assert.Equal(t, created.Key[:len(created.Prefix)], created.Prefix)
+		assert.Less(t, len(created.Prefix), len(created.Key))
+		assert.Equal(t, []string{"orders:read"}, []string(created.Scopes))
+
+		// A chave em claro não aparece na serialização do registro
+		stored, _ := json.Marshal(created.ApiKey)
+		assert.NotContains(t, string(stored), created.Key)
+		assert.NotContains(t, string(stored), created.KeyHash)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Recusa expiração no passado", func(t *testing.T) {
+		repo := new(mocks.MockApiKeyRepository)
+		service := services.NewApiKeyService(repo, mocks.NewRedisService(t))
+
+		past := time.Now().Add(-time.Minute)
+		_, err := service.Create(c, tenantID, models.ApiKeyCreate{Name: "expirada", ExpiresAt: &past})
+
+		assert.ErrorIs(t, err, services.ErrApiKeyExpiresInPast)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestApiKeyService_Rotate(t *testing.T) {
+	c, _ := gin.CreateTestContext(nil)
+	tenantID := uuid.New()
+	oldKey := &models.ApiKey{ID: uuid.New(), TenantID: tenantID, Name: "erp", Prefix: "abcdefgh", KeyHash: "old-hash", Scopes: []string{"orders:write"}}
+
+	t.Run("Mantém a chave antiga durante o período de carência", func(t *testing.T) {
+		repo := new(mocks.MockApiKeyRepository)
+		redisService := mocks.NewRedisService(t)
+		service := services.NewApiKeyService(repo, redisService)
+
+		var validUntil time.Time
+		repo.On("FindApiKeyByTenant", c, tenantID, oldKey.ID).Return(oldKey, nil)
+		repo.On("RotateApiKey", c, oldKey, mock.AnythingOfType("*models.ApiKey"), mock.AnythingOfType("time.Time")).
+			Run(func(args mock.Arguments) { validUntil = args.Get(3).(time.Time) }).
+			Return(nil)
+		expectApiKeyCacheInvalidation(redisService, tenantID)
+
+		created, err := service.Rotate(c, tenantID, oldKey.ID, time.Hour)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, created.Key)
+		assert.NotEqual(t, oldKey.KeyHash, created.KeyHash)
+		assert.Equal(t, oldKey.Name, created.Name)
+		assert.Equal(t, oldKey.Scopes, created.Scopes)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), validUntil, 5*time.Second)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Carência limitada pela expiração da chave antiga", func(t *testing.T) {
+		repo := new(mocks.MockApiKeyRepository)
+		redisService := mocks.NewRedisService(t)
+		service := services.NewApiKeyService(repo, redisService)
+
+		expiresAt := time.Now().Add(10 * time.Minute)
+		expiring := *oldKey
+		expiring.ExpiresAt = &expiresAt
+
+		repo.On("FindApiKeyByTenant", c, tenantID, oldKey.ID).Return(&expiring, nil)
+		repo.On("RotateApiKey", c, &expiring, mock.AnythingOfType("*models.ApiKey"), expiresAt).Return(nil)
+		expectApiKeyCacheInvalidation(redisService, tenantID)
+
+		created, err := service.Rotate(c, tenantID, oldKey.ID, time.Hour)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &expiresAt, created.ExpiresAt)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Chave revogada não pode ser rotacionada", func(t *testing.T) {
+		repo := new(mocks.MockApiKeyRepository)
+		service := services.NewApiKeyService(repo, mocks.NewRedisService(t))
+
+		revokedAt := time.Now().Add(-time.Hour)
+		revoked := *oldKey
+		revoked.RevokedAt = &revokedAt
+		repo.On("FindApiKeyByTenant", c, tenantID, oldKey.ID).Return(&revoked, nil)
+
+		_, err := service.Rotate(c, tenantID, oldKey.ID, 0)
+
+		assert.ErrorIs(t, err, services.ErrApiKeyNotFound)
+		repo.AssertNotCalled(t, "RotateApiKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestApiKeyService_Revoke(t *testing.T) {
+	c, _ := gin.CreateTestContext(nil)
+	tenantID := uuid.New()
+
+	t.Run("Revoga e remove do cache", func(t *testing.T) {
+		repo := new(mocks.MockApiKeyRepository)
+		redisService := mocks.NewRedisService(t)
+		service := services.NewApiKeyService(repo, redisService)
+
+		key := &models.ApiKey{ID: uuid.New(), TenantID: tenantID, KeyHash: "hash"}
+		repo.On("FindApiKeyByTenant", c, tenantID, key.ID).Return(key, nil)
+		repo.On("RevokeApiKey", c, tenantID, key.ID).Return(nil)
+		expectApiKeyCacheInvalidation(redisService, tenantID)
+
+		assert.NoError(t, service.Revoke(c, tenantID, key.ID))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Chave de outro tenant", func(t *testing.T) {
+		repo := new(mocks.MockApiKeyRepository)
+		service := services.NewApiKeyService(repo, mocks.NewRedisService(t))
+
+		keyID := uuid.New()
+		repo.On("FindApiKeyByTenant", c, tenantID, keyID).Return(nil, gorm.ErrRecordNotFound)
+
+		assert.ErrorIs(t, service.Revoke(c, tenantID, keyID), services.ErrApiKeyNotFound)
+	})
+
+	t.Run("Falha ao remover do cache", func(t *testing.T) {
+		repo := new(mocks.MockApiKeyRepository)
+		redisService := mocks.NewRedisService(t)
+		service := services.NewApiKeyService(repo, redisService)
+
+		key := &models.ApiKey{ID: uuid.New(), TenantID: tenantID, KeyHash: "hash"}
+		repo.On("FindApiKeyByTenant", c, tenantID, key.ID).Return(key, nil)
+		repo.On("RevokeApiKey", c, tenantID, key.ID).Return(nil)
+		redisService.On("Incr", "apiKey_generation", time.Duration(0)).Return(int64(0), errors.New("redis indisponível"))
+
+		// A chave ainda poderia ser aceita pelo cache: a revogação não é confirmada
+		assert.Error(t, service.Revoke(c, tenantID, key.ID))
+	})
+}
+
+func TestApiKeyService_AuthenticateSuspendedTenant(t *testing.T) {
+	repo := new(mocks.MockApiKeyRepository)
+	service := services.NewApiKeyService(repo, mocks.NewRedisService(t))
+
+	key := &models.ApiKey{ID: uuid.New(), TenantID: uuid.New(), KeyHash: sha256Hex("plain-key"), Tenant: &models.Tenant{Status: enums.Inativo}}
+	repo.On("FindActiveApiKeyByHash", key.KeyHash, "app.example.com").Return(key, nil)
+
+	_, err := service.Authenticate("plain-key", "app.example.com")
+
+	assert.ErrorIs(t, err, autherrors.ErrTenantSuspended)
+	repo.AssertNotCalled(t, "TouchApiKeyLastUsed", mock.Anything)
+}
+
+func TestApiKeyRedisService_GetTenantRedisFromApiKey(t *testing.T) {
+	tenantID := uuid.New()
+	email := "tenant@example.com"
+
+	t.Run("Sem cache autentica pelo hash e limita o cache à expiração", func(t *testing.T) {
+		repo := new(mocks.MockApiKeyRepository)
+		redisService := mocks.NewRedisService(t)
+		apiKeyService := services.NewApiKeyService(repo, redisService)
+		service := services.NewApiKeyRedisService(apiKeyService, redisService, 24*time.Hour)
+
+		expiresAt := time.Now().Add(30 * time.Minute)
+		key := &models.ApiKey{
+			ID:        uuid.New(),
+			TenantID:  tenantID,
+			KeyHash:   sha256Hex("plain-key"),
+			Scopes:    []string{"orders:read"},
+			ExpiresAt: &expiresAt,
+			Tenant:    &models.Tenant{Name: "Tenant", Email: &email},
+		}
+
+		cacheKey := "apiKey:" + key.KeyHash + ":app.example.com"
+		redisService.On("Get", cacheKey).Return("", redis.Nil)
+		redisService.On("Get", "apiKey_generation").Return("3", nil).Twice()
+		repo.On("FindActiveApiKeyByHash", key.KeyHash, "app.example.com").Return(key, nil)
+		repo.On("TouchApiKeyLastUsed", key.ID).Return(nil)
+		redisService.On("HSet", "apiKey_tenant:"+tenantID.String(), cacheKey, 1, 24*time.Hour).Return(nil)
+		redisService.On("Set", cacheKey, mock.Anything, mock.MatchedBy(func(ttl time.Duration) bool {
+			return ttl > 29*time.Minute && ttl <= 30*time.Minute
+		})).Return(nil)
+
+		tenant, err := service.GetTenantRedisFromApiKey("plain-key", "app.example.com")
+
+		assert.NoError(t, err)
+		assert.Equal(t, tenantID.String(), tenant.ID)
+		assert.Equal(t, "Tenant", tenant.Name)
+		assert.Equal(t, email, tenant.Email)
+		assert.Equal(t, key.ID.String(), tenant.ApiKeyID)
+		assert.Equal(t, []string{"orders:read"}, tenant.Scopes)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Cache registra o uso no máximo uma vez por intervalo", func(t *testing.T) {
+		repo := new(mocks.MockApiKeyRepository)
+		redisService := mocks.NewRedisService(t)
+		apiKeyService := services.NewApiKeyService(repo, redisService)
+		service := services.NewApiKeyRedisService(apiKeyService, redisService, 24*time.Hour)
+
+		apiKeyID := uuid.New()
+		cached, _ := json.Marshal(models.TenantRedis{ID: tenantID.String(), Name: "Tenant", ApiKeyID: apiKeyID.String()})
+		redisService.On("Get", "apiKey:"+sha256Hex("plain-key")+":app.example.com").Return(string(cached), nil)
+		redisService.On("SetNX", "apiKey_used:"+apiKeyID.String(), 1, time.Minute).Return(true, nil).Once()
+		redisService.On("SetNX", "apiKey_used:"+apiKeyID.String(), 1, time.Minute).Return(false, nil).Once()
+		repo.On("TouchApiKeyLastUsed", apiKeyID).Return(nil).Once()
+
+		for i := 0; i < 2; i++ {
+			tenant, err := service.GetTenantRedisFromApiKey("plain-key", "app.example.com")
+			assert.NoError(t, err)
+			assert.Equal(t, tenantID.String(), tenant.ID)
+		}
+		repo.AssertExpectations(t)
+		repo.AssertNotCalled(t, "FindActiveApiKeyByHash", mock.Anything, mock.Anything)
+	})
+	t.Run("Invalidação durante a consulta descarta a entrada gravada", func(t *testing.T) {
+		repo := new(mocks.MockApiKeyRepository)
+		redisService := mocks.NewRedisService(t)
+		apiKeyService := services.NewApiKeyService(repo, redisService)
+		service := services.NewApiKeyRedisService(apiKeyService, redisService, 24*time.Hour)
+
+		key := &models.ApiKey{ID: uuid.New(), TenantID: tenantID, KeyHash: sha256Hex("plain-key"), Tenant: &models.Tenant{Name: "Tenant"}}
+		cacheKey := "apiKey:" + key.KeyHash + ":app.example.com"
+		redisService.On("Get", cacheKey).Return("", redis.Nil)
+		redisService.On("Get", "apiKey_generation").Return("3", nil).Once()
+		repo.On("FindActiveApiKeyByHash", key.KeyHash, "app.example.com").Return(key, nil)
+		repo.On("TouchApiKeyLastUsed", key.ID).Return(nil)
+		redisService.On("HSet", "apiKey_tenant:"+tenantID.String(), cacheKey, 1, 24*time.Hour).Return(nil)
+		redisService.On("Set", cacheKey, mock.Anything, 24*time.Hour).Return(nil)
+		// O tenant foi alterado e o cache limpo entre a consulta ao banco e a gravação
+		redisService.On("Get", "apiKey_generation").Return("4", nil).Once()
+		redisService.On("Delete", cacheKey).Return(nil).Once()
+
+		tenant, err := service.GetTenantRedisFromApiKey("plain-key", "app.example.com")
+
+		assert.NoError(t, err)
+		assert.Equal(t, tenantID.String(), tenant.ID)
+	})
+
+	t.Run("Entrada de uma origem não vale para outra", func(t *testing.T) {
+		repo := new(mocks.MockApiKeyRepository)
+		redisService := mocks.NewRedisService(t)
+		apiKeyService := services.NewApiKeyService(repo, redisService)
+		service := services.NewApiKeyRedisService(apiKeyService, redisService, 24*time.Hour)
+
+		keyHash := sha256Hex("plain-key")
+		redisService.On("Get", "apiKey:"+keyHash+":evil.example.com").Return("", redis.Nil)
+		redisService.On("Get", "apiKey_generation").Return("", redis.Nil)
+		repo.On("FindActiveApiKeyByHash", keyHash, "evil.example.com").Return(nil, errors.New("tenant ou origem não encontrado"))
+
+		tenant, err := service.GetTenantRedisFromApiKey("plain-key", "evil.example.com")
+
+		assert.Error(t, err)
+		assert.Nil(t, tenant)
+		redisService.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
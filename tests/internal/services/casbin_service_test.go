@@ -0,0 +1,319 @@
+// tests/internal/services/casbin_service_test.go
+
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	stringadapter "github.com/casbin/casbin/v2/persist/string-adapter"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+	"github.com/jeancarlosdanese/go-base-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Mesmo formato da view casbin_rules_view: roles globais usam o domínio "*" e os roles do tenant, o ID do tenant;
+// políticas sem condição usam "*". O tenant-b tem um role próprio chamado admin, que não se confunde com o admin global.
+const casbinTestPolicies = `
+p, admin, *, /api/v1/users, GET|POST, *
+p, admin, *, /api/v1/tenants, GET, *
+p, admin, *, /api/v1/tenants/:id, GET|PUT, param.id == user.tenant_id
+p, vendas, tenant-a, /api/v1/reports, GET, *
+p, vendas, tenant-a, /api/v1/users/:id, GET|PATCH, param.id == user.id
+p, admin, tenant-b, /api/v1/reports, GET|POST, *
+p, user-c, tenant-a, /api/v1/orders, GET, *
+g, user-a, admin, *
+g, user-b, vendas, tenant-a
+g, user-d, admin, tenant-b
+`
+
+// subject é o usuário da sessão com o ID e o tenant informados.
+func subject(id, tenantID string) *models.UserRedis {
+	return &models.UserRedis{ID: id, TenantID: tenantID, Username: id}
+}
+
+func newCasbinService(t *testing.T, reloadInterval time.Duration) (*services.CasbinService, *mocks.CasbinWatcher) {
+	watcher := mocks.NewCasbinWatcher(t)
+	watcher.On("SetUpdateCallback", mock.Anything).Return(nil).Once()
+
+	cs, err := services.NewCasbinServiceFromAdapter(stringadapter.NewAdapter(casbinTestPolicies), watcher, reloadInterval)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		watcher.On("Close").Return().Maybe()
+		cs.Close()
+	})
+	return cs, watcher
+}
+
+func TestCasbinService_Domains(t *testing.T) {
+	cs, _ := newCasbinService(t, 0)
+
+	// Role global vale em qualquer tenant
+	assert.True(t, cs.CheckPermission(subject("user-a", "tenant-a"), "tenant-a", "/api/v1/users", "POST"))
+	assert.True(t, cs.CheckPermission(subject("user-a", "tenant-b"), "tenant-b", "/api/v1/tenants", "GET"))
+	assert.False(t, cs.CheckPermission(subject("user-a", "tenant-a"), "tenant-a", "/api/v1/reports", "GET"))
+
+	// Role do tenant vale apenas no tenant
+	assert.True(t, cs.CheckPermission(subject("user-b", "tenant-a"), "tenant-a", "/api/v1/reports", "GET"))
+	assert.False(t, cs.CheckPermission(subject("user-b", "tenant-b"), "tenant-b", "/api/v1/reports", "GET"))
+
+	// O admin do tenant-b não herda as políticas do admin global
+	assert.True(t, cs.CheckPermission(subject("user-d", "tenant-b"), "tenant-b", "/api/v1/reports", "POST"))
+	assert.False(t, cs.CheckPermission(subject("user-d", "tenant-b"), "tenant-b", "/api/v1/users", "GET"))
+
+	// Política do usuário vale apenas no tenant dele
+	assert.True(t, cs.CheckPermission(subject("user-c", "tenant-a"), "tenant-a", "/api/v1/orders", "GET"))
+	assert.False(t, cs.CheckPermission(subject("user-c", "tenant-b"), "tenant-b", "/api/v1/orders", "GET"))
+}
+
+func TestCasbinService_Conditions(t *testing.T) {
+	cs, _ := newCasbinService(t, 0)
+
+	// Apenas o próprio usuário
+	assert.True(t, cs.CheckPermission(subject("user-b", "tenant-a"), "tenant-a", "/api/v1/users/user-b", "PATCH"))
+	assert.False(t, cs.CheckPermission(subject("user-b", "tenant-a"), "tenant-a", "/api/v1/users/user-c", "PATCH"))
+	// A condição não amplia as ações da política
+	assert.False(t, cs.CheckPermission(subject("user-b", "tenant-a"), "tenant-a", "/api/v1/users/user-b", "DELETE"))
+
+	// Apenas o próprio tenant
+	assert.True(t, cs.CheckPermission(subject("user-a", "tenant-a"), "tenant-a", "/api/v1/tenants/tenant-a", "PUT"))
+	assert.False(t, cs.CheckPermission(subject("user-a", "tenant-a"), "tenant-a", "/api/v1/tenants/tenant-b", "PUT"))
+
+	// Sessão sem o atributo não satisfaz a condição
+	assert.False(t, cs.CheckPermission(&models.UserRedis{ID: "user-a"}, "tenant-a", "/api/v1/tenants/", "GET"))
+}
+
+func TestCasbinService_SetPolicyWithCondition(t *testing.T) {
+	cs, watcher := newCasbinService(t, 0)
+	watcher.On("UpdateForSetPolicy", "user-c", "tenant-a", "/api/v1/users/:id", "DELETE", "param.id != user.id").Return(nil).Once()
+
+	assert.NoError(t, cs.SetPolicy("user-c", "tenant-a", "/api/v1/users/:id", "DELETE", "param.id != user.id"))
+
+	assert.True(t, cs.CheckPermission(subject("user-c", "tenant-a"), "tenant-a", "/api/v1/users/user-b", "DELETE"))
+	assert.False(t, cs.CheckPermission(subject("user-c", "tenant-a"), "tenant-a", "/api/v1/users/user-c", "DELETE"))
+
+	cs.HandleUpdate(`{"instance_id":"outra","op":"set","sub":"user-c","dom":"tenant-a","obj":"/api/v1/users/:id","act":"DELETE"}`)
+	assert.True(t, cs.CheckPermission(subject("user-c", "tenant-a"), "tenant-a", "/api/v1/users/user-c", "DELETE"))
+}
+
+func TestCasbinService_Explain(t *testing.T) {
+	cs, _ := newCasbinService(t, 0)
+
+	t.Run("permitido por role global", func(t *testing.T) {
+		explanation, err := cs.Explain(subject("user-a", "tenant-a"), "tenant-a", "/api/v1/users", "POST")
+		require.NoError(t, err)
+
+		assert.True(t, explanation.Allowed)
+		assert.Equal(t, []string{"admin"}, explanation.Roles)
+		assert.Equal(t, &models.AuthzRule{Subject: "admin", Domain: "*", Endpoint: "/api/v1/users", Actions: "GET|POST"}, explanation.MatchedRule)
+	})
+
+	t.Run("permitido por política do usuário", func(t *testing.T) {
+		explanation, err := cs.Explain(subject("user-c", "tenant-a"), "tenant-a", "/api/v1/orders", "GET")
+		require.NoError(t, err)
+
+		assert.True(t, explanation.Allowed)
+		assert.Equal(t, "user-c", explanation.MatchedRule.Subject)
+		assert.Contains(t, explanation.Reason, "especial do usuário")
+	})
+
+	t.Run("método não permitido", func(t *testing.T) {
+		explanation, err := cs.Explain(subject("user-d", "tenant-b"), "tenant-b", "/api/v1/reports", "DELETE")
+		require.NoError(t, err)
+
+		assert.False(t, explanation.Allowed)
+		assert.Nil(t, explanation.MatchedRule)
+		assert.Equal(t, []string{"admin"}, explanation.Roles)
+		// Apenas o admin do tenant-b; o vendas do tenant-a não vale para o usuário
+		assert.Equal(t, []models.AuthzRule{{Subject: "admin", Domain: "tenant-b", Endpoint: "/api/v1/reports", Actions: "GET|POST"}}, explanation.Candidates)
+	})
+
+	t.Run("nenhuma política para o endpoint", func(t *testing.T) {
+		explanation, err := cs.Explain(subject("user-b", "tenant-a"), "tenant-a", "/api/v1/users", "GET")
+		require.NoError(t, err)
+
+		assert.False(t, explanation.Allowed)
+		assert.Empty(t, explanation.Candidates)
+		assert.Equal(t, []string{"vendas"}, explanation.Roles)
+		assert.NotEmpty(t, explanation.Reason)
+	})
+
+	t.Run("condição não atendida", func(t *testing.T) {
+		explanation, err := cs.Explain(subject("user-b", "tenant-a"), "tenant-a", "/api/v1/users/user-c", "PATCH")
+		require.NoError(t, err)
+
+		assert.False(t, explanation.Allowed)
+		assert.Equal(t, []models.AuthzRule{{Subject: "vendas", Domain: "tenant-a", Endpoint: "/api/v1/users/:id", Actions: "GET|PATCH", Condition: "param.id == user.id"}}, explanation.Candidates)
+		assert.Contains(t, explanation.Reason, "param.id == user.id")
+	})
+
+	t.Run("usuário sem roles no tenant", func(t *testing.T) {
+		explanation, err := cs.Explain(subject("user-b", "tenant-b"), "tenant-b", "/api/v1/reports", "GET")
+		require.NoError(t, err)
+
+		assert.False(t, explanation.Allowed)
+		assert.Empty(t, explanation.Roles)
+	})
+}
+
+func TestCasbinService_Permissions(t *testing.T) {
+	cs, _ := newCasbinService(t, 0)
+
+	t.Run("role do tenant", func(t *testing.T) {
+		permissions, err := cs.Permissions(subject("user-b", "tenant-a"), "tenant-a")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"vendas"}, permissions.Roles)
+		assert.Equal(t, []models.EndpointPermission{
+			{Endpoint: "/api/v1/reports", Actions: []string{"GET"}},
+			{Endpoint: "/api/v1/users/:id", Actions: []string{"GET", "PATCH"}},
+		}, permissions.Permissions)
+	})
+
+	t.Run("role global vale no tenant", func(t *testing.T) {
+		permissions, err := cs.Permissions(subject("user-a", "tenant-a"), "tenant-a")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"admin"}, permissions.Roles)
+		assert.Equal(t, []models.EndpointPermission{
+			{Endpoint: "/api/v1/tenants", Actions: []string{"GET"}},
+			{Endpoint: "/api/v1/tenants/:id", Actions: []string{"GET", "PUT"}},
+			{Endpoint: "/api/v1/users", Actions: []string{"GET", "POST"}},
+		}, permissions.Permissions)
+	})
+
+	t.Run("política alterada depois do login", func(t *testing.T) {
+		cs, watcher := newCasbinService(t, 0)
+		watcher.On("UpdateForSetPolicy", "user-c", "tenant-a", "/api/v1/orders", "GET|POST", "").Return(nil).Once()
+		require.NoError(t, cs.SetPolicy("user-c", "tenant-a", "/api/v1/orders", "GET|POST", ""))
+
+		permissions, err := cs.Permissions(subject("user-c", "tenant-a"), "tenant-a")
+		require.NoError(t, err)
+
+		assert.Empty(t, permissions.Roles)
+		assert.Equal(t, []models.EndpointPermission{{Endpoint: "/api/v1/orders", Actions: []string{"GET", "POST"}}}, permissions.Permissions)
+	})
+
+	t.Run("sem permissões no tenant", func(t *testing.T) {
+		permissions, err := cs.Permissions(subject("user-b", "tenant-b"), "tenant-b")
+		require.NoError(t, err)
+
+		assert.Empty(t, permissions.Roles)
+		assert.Empty(t, permissions.Permissions)
+	})
+}
+
+func TestCasbinService_SetPolicyAppliesAndPublishes(t *testing.T) {
+	cs, watcher := newCasbinService(t, 0)
+	watcher.On("UpdateForSetPolicy", "vendas", "tenant-a", "/api/v1/reports", "GET|DELETE", "").Return(nil).Once()
+
+	assert.NoError(t, cs.SetPolicy("vendas", "tenant-a", "/api/v1/reports", "GET|DELETE", ""))
+
+	assert.True(t, cs.CheckPermission(subject("user-b", "tenant-a"), "tenant-a", "/api/v1/reports", "DELETE"))
+	// O role de mesmo nome em outro tenant não é alterado
+	assert.False(t, cs.CheckPermission(subject("user-d", "tenant-b"), "tenant-b", "/api/v1/reports", "DELETE"))
+	assert.True(t, cs.CheckPermission(subject("user-d", "tenant-b"), "tenant-b", "/api/v1/reports", "POST"))
+}
+
+func TestCasbinService_RemovePolicyAppliesAndPublishes(t *testing.T) {
+	cs, watcher := newCasbinService(t, 0)
+	watcher.On("UpdateForRemovePolicy", "admin", "*", "/api/v1/users").Return(nil).Once()
+
+	assert.NoError(t, cs.RemovePolicy("admin", "*", "/api/v1/users"))
+
+	assert.False(t, cs.CheckPermission(subject("user-a", "tenant-a"), "tenant-a", "/api/v1/users", "GET"))
+	assert.True(t, cs.CheckPermission(subject("user-a", "tenant-a"), "tenant-a", "/api/v1/tenants", "GET"))
+	assert.True(t, cs.CheckPermission(subject("user-d", "tenant-b"), "tenant-b", "/api/v1/reports", "GET"))
+}
+
+func TestCasbinService_RolesForUserApplyAndPublish(t *testing.T) {
+	cs, watcher := newCasbinService(t, 0)
+	watcher.On("UpdateForAddRoleForUser", "user-c", "vendas", "tenant-a").Return(nil).Once()
+	watcher.On("UpdateForDeleteRoleForUser", "user-b", "vendas", "tenant-a").Return(nil).Once()
+
+	assert.NoError(t, cs.AddRoleForUser("user-c", "vendas", "tenant-a"))
+	assert.True(t, cs.CheckPermission(subject("user-c", "tenant-a"), "tenant-a", "/api/v1/reports", "GET"))
+
+	assert.NoError(t, cs.DeleteRoleForUser("user-b", "vendas", "tenant-a"))
+	assert.False(t, cs.CheckPermission(subject("user-b", "tenant-a"), "tenant-a", "/api/v1/reports", "GET"))
+}
+
+func TestCasbinService_PublishFailureKeepsLocalChange(t *testing.T) {
+	cs, watcher := newCasbinService(t, 0)
+	watcher.On("UpdateForSetPolicy", "admin", "*", "/api/v1/reports", "GET", "").Return(errors.New("redis down")).Once()
+
+	assert.NoError(t, cs.SetPolicy("admin", "*", "/api/v1/reports", "GET", ""))
+	assert.True(t, cs.CheckPermission(subject("user-a", "tenant-a"), "tenant-a", "/api/v1/reports", "GET"))
+}
+
+func TestCasbinService_LoadPolicyPublishesReload(t *testing.T) {
+	cs, watcher := newCasbinService(t, 0)
+	watcher.On("Update").Return(nil).Once()
+
+	assert.NoError(t, cs.LoadPolicy())
+}
+
+func TestCasbinService_HandleUpdateIsIncremental(t *testing.T) {
+	cs, watcher := newCasbinService(t, 0)
+
+	cs.HandleUpdate(`{"instance_id":"outra","op":"set","sub":"suporte","dom":"tenant-a","obj":"/api/v1/users/:id","act":"GET"}`)
+	cs.HandleUpdate(`{"instance_id":"outra","op":"add_role","sub":"user-c","role":"suporte","dom":"tenant-a"}`)
+	assert.True(t, cs.CheckPermission(subject("user-c", "tenant-a"), "tenant-a", "/api/v1/users/42", "GET"))
+
+	cs.HandleUpdate(`{"instance_id":"outra","op":"remove","sub":"admin","dom":"*","obj":"/api/v1/users"}`)
+	assert.False(t, cs.CheckPermission(subject("user-a", "tenant-a"), "tenant-a", "/api/v1/users", "GET"))
+
+	cs.HandleUpdate(`{"instance_id":"outra","op":"delete_role","sub":"user-b","role":"vendas","dom":"tenant-a"}`)
+	assert.False(t, cs.CheckPermission(subject("user-b", "tenant-a"), "tenant-a", "/api/v1/reports", "GET"))
+
+	// Alterações recebidas não são publicadas de novo.
+	watcher.AssertNotCalled(t, "UpdateForSetPolicy", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	watcher.AssertNotCalled(t, "UpdateForRemovePolicy", mock.Anything, mock.Anything, mock.Anything)
+	watcher.AssertNotCalled(t, "UpdateForAddRoleForUser", mock.Anything, mock.Anything, mock.Anything)
+	watcher.AssertNotCalled(t, "UpdateForDeleteRoleForUser", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCasbinService_HandleUpdateReloads(t *testing.T) {
+	for name, payload := range map[string]string{
+		"reload":            `{"instance_id":"outra","op":"reload"}`,
+		"operação inválida": `{"instance_id":"outra","op":"truncate"}`,
+		"set sem domínio":   `{"instance_id":"outra","op":"set","sub":"admin","obj":"/api/v1/users","act":"GET"}`,
+		"json inválido":     `reload`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			cs, watcher := newCasbinService(t, 0)
+			cs.HandleUpdate(`{"instance_id":"outra","op":"remove","sub":"admin","dom":"*","obj":"/api/v1/users"}`)
+
+			cs.HandleUpdate(payload)
+
+			assert.True(t, cs.CheckPermission(subject("user-a", "tenant-a"), "tenant-a", "/api/v1/users", "GET"))
+			watcher.AssertNotCalled(t, "Update")
+		})
+	}
+}
+
+func TestCasbinService_PeriodicReload(t *testing.T) {
+	cs, _ := newCasbinService(t, 10*time.Millisecond)
+	cs.HandleUpdate(`{"instance_id":"outra","op":"remove","sub":"admin","dom":"*","obj":"/api/v1/users"}`)
+
+	assert.Eventually(t, func() bool {
+		return cs.CheckPermission(subject("user-a", "tenant-a"), "tenant-a", "/api/v1/users", "GET")
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestCasbinService_WithoutWatcher(t *testing.T) {
+	cs, err := services.NewCasbinServiceFromAdapter(stringadapter.NewAdapter(casbinTestPolicies), nil, 0)
+	require.NoError(t, err)
+	defer cs.Close()
+
+	assert.NoError(t, cs.SetPolicy("admin", "*", "/api/v1/reports", "GET", ""))
+	assert.NoError(t, cs.RemovePolicy("admin", "*", "/api/v1/users"))
+	assert.NoError(t, cs.AddRoleForUser("user-c", "admin", "*"))
+	assert.NoError(t, cs.DeleteRoleForUser("user-c", "admin", "*"))
+	assert.NoError(t, cs.LoadPolicy())
+	assert.True(t, cs.CheckPermission(subject("user-a", "tenant-a"), "tenant-a", "/api/v1/users", "GET"))
+}
@@ -0,0 +1,96 @@
+// tests/internal/services/login_attempt_service_test.go
+
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	autherrors "github.com/jeancarlosdanese/go-base-api/internal/domain/auth_errors"
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+	"github.com/jeancarlosdanese/go-base-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func newLoginAttemptService(redisService *mocks.RedisService) *services.LoginAttemptService {
+	return services.NewLoginAttemptService(redisService, services.LoginAttemptConfig{
+		MaxFailures:       5,
+		FailureWindow:     15 * time.Minute,
+		LockoutDuration:   15 * time.Minute,
+		BaseDelay:         time.Second,
+		MaxDelay:          30 * time.Second,
+		OriginMaxFailures: 100,
+	})
+}
+
+func TestLoginAttemptService_CheckLocked(t *testing.T) {
+	redisService := mocks.NewRedisService(t)
+	service := newLoginAttemptService(redisService)
+
+	redisService.On("TTL", "login_lock:localhost:john@example.com").Return(10*time.Minute, nil)
+
+	err := service.Check(" John@Example.com ", "localhost", "10.0.0.1")
+
+	var blocked *autherrors.LoginBlockedError
+	assert.True(t, errors.As(err, &blocked))
+	assert.ErrorIs(t, err, autherrors.ErrAccountLocked)
+	assert.Equal(t, 10*time.Minute, blocked.RetryAfter)
+}
+
+func TestLoginAttemptService_CheckClientDelay(t *testing.T) {
+	redisService := mocks.NewRedisService(t)
+	service := newLoginAttemptService(redisService)
+
+	redisService.On("TTL", "login_lock:localhost:john@example.com").Return(time.Duration(0), nil)
+	redisService.On("TTL", "login_delay:localhost:john@example.com").Return(time.Duration(0), nil)
+	redisService.On("TTL", "login_delay_client:localhost:10.0.0.1").Return(20*time.Second, nil)
+	redisService.On("TTL", "login_delay_client:localhost:10.0.0.2").Return(time.Duration(0), nil)
+
+	// O cliente que excedeu as falhas aguarda; outro cliente da mesma origem não é afetado
+	assert.ErrorIs(t, service.Check("john@example.com", "localhost", "10.0.0.1"), autherrors.ErrTooManyLoginAttempts)
+	assert.NoError(t, service.Check("john@example.com", "localhost", "10.0.0.2"))
+}
+
+func TestLoginAttemptService_RegisterFailureProgressiveDelay(t *testing.T) {
+	redisService := mocks.NewRedisService(t)
+	service := newLoginAttemptService(redisService)
+
+	redisService.On("Incr", "login_failures_client:localhost:10.0.0.1", 15*time.Minute).Return(int64(3), nil)
+	redisService.On("Incr", "login_failures:localhost:john@example.com", 15*time.Minute).Return(int64(3), nil)
+	redisService.On("Set", "login_delay:localhost:john@example.com", 1, 4*time.Second).Return(nil)
+
+	err := service.RegisterFailure("john@example.com", "localhost", "10.0.0.1")
+
+	assert.NoError(t, err)
+}
+
+func TestLoginAttemptService_RegisterFailureLocksAccount(t *testing.T) {
+	redisService := mocks.NewRedisService(t)
+	service := newLoginAttemptService(redisService)
+
+	redisService.On("Incr", "login_failures_client:localhost:10.0.0.1", 15*time.Minute).Return(int64(100), nil)
+	redisService.On("Set", "login_delay_client:localhost:10.0.0.1", 1, 30*time.Second).Return(nil)
+	redisService.On("Incr", "login_failures:localhost:john@example.com", 15*time.Minute).Return(int64(5), nil)
+	redisService.On("Set", "login_lock:localhost:john@example.com", 1, 15*time.Minute).Return(nil)
+	redisService.On("Delete", "login_failures:localhost:john@example.com", "login_delay:localhost:john@example.com").Return(nil)
+
+	err := service.RegisterFailure("john@example.com", "localhost", "10.0.0.1")
+
+	assert.ErrorIs(t, err, autherrors.ErrAccountLocked)
+}
+
+func TestLoginAttemptService_Reset(t *testing.T) {
+	redisService := mocks.NewRedisService(t)
+	service := newLoginAttemptService(redisService)
+
+	redisService.On("Delete",
+		"login_failures:app.acme.com:john@example.com", "login_delay:app.acme.com:john@example.com", "login_lock:app.acme.com:john@example.com",
+		"login_failures:admin.acme.com:john@example.com", "login_delay:admin.acme.com:john@example.com", "login_lock:admin.acme.com:john@example.com",
+	).Return(nil).Once()
+
+	assert.NoError(t, service.Reset("John@example.com", "app.acme.com", "admin.acme.com"))
+
+	// Sem origens não há o que limpar
+	assert.NoError(t, service.Reset("john@example.com"))
+}
@@ -0,0 +1,84 @@
+// tests/internal/services/password_hasher_test.go
+
+package services_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Parâmetros baixos para manter os testes rápidos.
+var testArgon2idParams = services.Argon2idParams{Memory: 8 * 1024, Iterations: 1, Parallelism: 1}
+
+func TestPasswordHasher_Argon2id(t *testing.T) {
+	hasher := services.NewPasswordHasher(services.PasswordHasherConfig{
+		Algorithm: services.PasswordAlgorithmArgon2id,
+		Argon2id:  testArgon2idParams,
+	})
+
+	hash, err := hasher.Hash("Senha-Forte-123")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=8192,t=1,p=1$"))
+
+	ok, err := hasher.Verify("Senha-Forte-123", hash)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = hasher.Verify("outra-senha", hash)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.False(t, hasher.NeedsRehash(hash))
+}
+
+func TestPasswordHasher_VerifiesBcryptAndAsksForUpgrade(t *testing.T) {
+	hasher := services.NewPasswordHasher(services.PasswordHasherConfig{
+		Algorithm: services.PasswordAlgorithmArgon2id,
+		Argon2id:  testArgon2idParams,
+	})
+	legacy, _ := bcrypt.GenerateFromPassword([]byte("master123"), bcrypt.MinCost)
+
+	ok, err := hasher.Verify("master123", string(legacy))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, hasher.NeedsRehash(string(legacy)))
+}
+
+func TestPasswordHasher_NeedsRehashOnParameterChange(t *testing.T) {
+	weak := services.NewPasswordHasher(services.PasswordHasherConfig{
+		Algorithm: services.PasswordAlgorithmArgon2id,
+		Argon2id:  testArgon2idParams,
+	})
+	hash, err := weak.Hash("Senha-Forte-123")
+	require.NoError(t, err)
+
+	stronger := testArgon2idParams
+	stronger.Iterations = 2
+	current := services.NewPasswordHasher(services.PasswordHasherConfig{
+		Algorithm: services.PasswordAlgorithmArgon2id,
+		Argon2id:  stronger,
+	})
+
+	ok, err := current.Verify("Senha-Forte-123", hash)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, current.NeedsRehash(hash))
+
+	bcryptHasher := services.NewBcryptPasswordHasher(bcrypt.MinCost + 1)
+	lowCost, _ := bcrypt.GenerateFromPassword([]byte("x"), bcrypt.MinCost)
+	assert.True(t, bcryptHasher.NeedsRehash(string(lowCost)))
+}
+
+func TestPasswordHasher_UnknownFormat(t *testing.T) {
+	hasher := services.NewBcryptPasswordHasher(bcrypt.MinCost)
+
+	ok, err := hasher.Verify("senha", "plaintext")
+
+	assert.False(t, ok)
+	assert.ErrorIs(t, err, services.ErrUnknownPasswordHash)
+}
@@ -0,0 +1,77 @@
+// tests/internal/services/password_policy_test.go
+
+package services_test
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func violationCodes(err error) []string {
+	var policyErr *services.PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		return nil
+	}
+	codes := make([]string, 0, len(policyErr.Violations))
+	for _, v := range policyErr.Violations {
+		codes = append(codes, v.Code)
+	}
+	return codes
+}
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	policy := services.NewPasswordPolicy(services.PasswordPolicyConfig{
+		MinLength:     10,
+		RequireUpper:  true,
+		RequireLower:  true,
+		RequireDigit:  true,
+		RequireSymbol: true,
+	}, nil)
+
+	assert.NoError(t, policy.Validate("Correct-Horse-9", "john@example.com", "johndoe"))
+
+	assert.ElementsMatch(t, []string{
+		services.PasswordViolationMinLength,
+		services.PasswordViolationUpper,
+		services.PasswordViolationSymbol,
+		services.PasswordViolationContainsEmail,
+		services.PasswordViolationContainsUsername,
+	}, violationCodes(policy.Validate("master123", "master@domain.local", "master")))
+
+	assert.Contains(t, violationCodes(policy.Validate("Xx-John-2024!", "john@example.com", "jd")), services.PasswordViolationContainsEmail)
+	assert.Contains(t, violationCodes(policy.Validate("Xx-JohnDoe-2024!", "x@example.com", "johndoe")), services.PasswordViolationContainsUsername)
+	assert.Contains(t, violationCodes(policy.Validate("", "", "")), services.PasswordViolationMinLength)
+}
+
+func TestPasswordPolicy_MaxBytes(t *testing.T) {
+	long := strings.Repeat("a", services.BcryptMaxPasswordBytes+1)
+
+	unlimited := services.NewPasswordPolicy(services.PasswordPolicyConfig{MinLength: 8}, nil)
+	assert.NoError(t, unlimited.Validate(long, "", ""))
+
+	bcryptPolicy := services.NewPasswordPolicy(services.PasswordPolicyConfig{MinLength: 8, MaxBytes: services.BcryptMaxPasswordBytes}, nil)
+	assert.Equal(t, []string{services.PasswordViolationMaxLength}, violationCodes(bcryptPolicy.Validate(long, "", "")))
+	assert.NoError(t, bcryptPolicy.Validate(long[:services.BcryptMaxPasswordBytes], "", ""))
+}
+
+func TestPasswordPolicy_Breached(t *testing.T) {
+	dir := t.TempDir()
+	sum := sha1.Sum([]byte("Password123"))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	content := "0018A45C4D1DEF81644B54AB7F969B88D65:3\n" + hash[5:] + ":1024\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, hash[:5]+".txt"), []byte(content), 0o600))
+
+	policy := services.NewPasswordPolicy(services.PasswordPolicyConfig{MinLength: 8}, services.NewPwnedRangeDirChecker(dir))
+
+	assert.Equal(t, []string{services.PasswordViolationBreached}, violationCodes(policy.Validate("Password123", "", "")))
+	assert.NoError(t, policy.Validate("Another-Password-7", "", ""))
+}
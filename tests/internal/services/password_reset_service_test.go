@@ -0,0 +1,147 @@
+// tests/internal/services/password_reset_service_test.go
+
+package services_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/mailer"
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+	"github.com/jeancarlosdanese/go-base-api/tests/mocks"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// capturingMailer guarda as mensagens enviadas para inspeção nos testes.
+type capturingMailer struct {
+	messages []mailer.Message
+}
+
+func (m *capturingMailer) Send(_ context.Context, msg mailer.Message) error {
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+func sha256Hex(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestPasswordResetService_RequestPasswordReset(t *testing.T) {
+	userService := mocks.NewUserService(t)
+	redisService := mocks.NewRedisService(t)
+	tokenRedisService := mocks.NewTokenRedisService(t)
+	mail := &capturingMailer{}
+	service := services.NewPasswordResetService(userService, redisService, tokenRedisService, mail, 30*time.Minute, "https://app.example.com/reset")
+
+	c, _ := gin.CreateTestContext(nil)
+	user := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, Name: "John", Email: "john@example.com"}
+	userKey := "password_reset_user:" + user.ID.String()
+
+	var storedHash string
+	userService.On("FindByEmail", c, "john@example.com", "http://localhost").Return(user, nil)
+	redisService.On("GetDel", userKey).Return("old-hash", nil)
+	redisService.On("Delete", "password_reset:old-hash").Return(nil)
+	redisService.On("Set", mock.MatchedBy(func(key string) bool {
+		if strings.HasPrefix(key, "password_reset:") {
+			storedHash = strings.TrimPrefix(key, "password_reset:")
+			return true
+		}
+		return false
+	}), user.ID.String(), 30*time.Minute).Return(nil)
+	redisService.On("Set", userKey, mock.Anything, 30*time.Minute).Return(nil)
+
+	err := service.RequestPasswordReset(c, "john@example.com", "http://localhost")
+
+	assert.NoError(t, err)
+	assert.Len(t, mail.messages, 1)
+	assert.Equal(t, "john@example.com", mail.messages[0].To)
+
+	// O e-mail leva o token em claro; no Redis fica apenas o hash
+	body := mail.messages[0].Body
+	start := strings.Index(body, "?token=") + len("?token=")
+	token := strings.Fields(body[start:])[0]
+	assert.Equal(t, sha256Hex(token), storedHash)
+	// O token é marcado como segredo, para não ir ao log
+	assert.Equal(t, token, mail.messages[0].Secret)
+}
+
+func TestPasswordResetService_RequestPasswordResetUnknownEmail(t *testing.T) {
+	userService := mocks.NewUserService(t)
+	redisService := mocks.NewRedisService(t)
+	mail := &capturingMailer{}
+	service := services.NewPasswordResetService(userService, redisService, mocks.NewTokenRedisService(t), mail, 30*time.Minute, "")
+
+	c, _ := gin.CreateTestContext(nil)
+	userService.On("FindByEmail", c, "ghost@example.com", "http://localhost").Return(nil, errors.New("usuário ou origem não encontrado"))
+
+	err := service.RequestPasswordReset(c, "ghost@example.com", "http://localhost")
+
+	assert.NoError(t, err)
+	assert.Empty(t, mail.messages)
+}
+
+func TestPasswordResetService_ResetPassword(t *testing.T) {
+	userService := mocks.NewUserService(t)
+	redisService := mocks.NewRedisService(t)
+	tokenRedisService := mocks.NewTokenRedisService(t)
+	service := services.NewPasswordResetService(userService, redisService, tokenRedisService, &capturingMailer{}, 30*time.Minute, "")
+
+	c, _ := gin.CreateTestContext(nil)
+	userID := uuid.New()
+
+	redisService.On("TTL", "password_reset:"+sha256Hex("reset-token")).Return(20*time.Minute, nil)
+	redisService.On("GetDel", "password_reset:"+sha256Hex("reset-token")).Return(userID.String(), nil)
+	redisService.On("Delete", "password_reset_user:"+userID.String()).Return(nil)
+	userService.On("UpdatePassword", c, userID, "nova-senha-123").Return(nil)
+	tokenRedisService.On("RevokeAllSessions", userID.String()).Return(nil)
+
+	err := service.ResetPassword(c, "reset-token", "nova-senha-123")
+
+	assert.NoError(t, err)
+}
+
+func TestPasswordResetService_ResetPasswordTokenAlreadyUsed(t *testing.T) {
+	userService := mocks.NewUserService(t)
+	redisService := mocks.NewRedisService(t)
+	service := services.NewPasswordResetService(userService, redisService, mocks.NewTokenRedisService(t), &capturingMailer{}, 30*time.Minute, "")
+
+	c, _ := gin.CreateTestContext(nil)
+	redisService.On("TTL", "password_reset:"+sha256Hex("used-token")).Return(time.Duration(0), nil)
+	redisService.On("GetDel", "password_reset:"+sha256Hex("used-token")).Return("", redis.Nil)
+
+	err := service.ResetPassword(c, "used-token", "nova-senha-123")
+
+	assert.ErrorIs(t, err, services.ErrPasswordResetTokenInvalid)
+}
+
+func TestPasswordResetService_ResetPasswordRejectedByPolicyKeepsToken(t *testing.T) {
+	userService := mocks.NewUserService(t)
+	redisService := mocks.NewRedisService(t)
+	service := services.NewPasswordResetService(userService, redisService, mocks.NewTokenRedisService(t), &capturingMailer{}, 30*time.Minute, "")
+
+	c, _ := gin.CreateTestContext(nil)
+	userID := uuid.New()
+	key := "password_reset:" + sha256Hex("reset-token")
+	policyErr := &services.PasswordPolicyError{Violations: []models.PasswordViolation{{Code: services.PasswordViolationMinLength}}}
+
+	redisService.On("TTL", key).Return(20*time.Minute, nil)
+	redisService.On("GetDel", key).Return(userID.String(), nil)
+	userService.On("UpdatePassword", c, userID, "fraca").Return(policyErr)
+	redisService.On("Set", key, userID.String(), 20*time.Minute).Return(nil)
+
+	err := service.ResetPassword(c, "reset-token", "fraca")
+
+	var got *services.PasswordPolicyError
+	assert.True(t, errors.As(err, &got))
+}
@@ -0,0 +1,431 @@
+// tests/internal/services/security_service_test.go
+
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	contextkeys "github.com/jeancarlosdanese/go-base-api/internal/domain/context_keys"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+	"github.com/jeancarlosdanese/go-base-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+var (
+	securityTenantID = uuid.MustParse("6f9f1c2e-8d7b-4b52-9a57-4a1e4f0c1a01")
+	securityCallerID = uuid.MustParse("0b8e5a34-2c4f-4a8e-8f1d-5c3b2a1d0e02")
+)
+
+func newSecurityService(t *testing.T) (*services.SecurityService, *mocks.MockSecurityRepository, *mocks.CasbinService, *mocks.TokenRedisService) {
+	repo := new(mocks.MockSecurityRepository)
+	casbinService := mocks.NewCasbinService(t)
+	tokenRedisService := mocks.NewTokenRedisService(t)
+	return services.NewSecurityService(repo, casbinService, tokenRedisService), repo, casbinService, tokenRedisService
+}
+
+// newSecurityContext monta a requisição de um usuário do tenant securityTenantID com os roles informados.
+func newSecurityContext(repo *mocks.MockSecurityRepository, roles ...models.Role) *gin.Context {
+	c, _ := gin.CreateTestContext(nil)
+	c.Set(string(contextkeys.TenantIDKey), securityTenantID.String())
+	c.Set(string(contextkeys.UserDataKey), &models.UserRedis{ID: securityCallerID.String(), TenantID: securityTenantID.String()})
+	repo.On("FindUserRoles", c, securityCallerID).Return(roles, nil).Maybe()
+	return c
+}
+
+// expectTenantUser registra userID como usuário do tenant securityTenantID.
+func expectTenantUser(repo *mocks.MockSecurityRepository, c *gin.Context, userID uuid.UUID) {
+	repo.On("FindTenantUser", c, securityTenantID, userID).Return(&models.User{BaseModel: models.BaseModel{ID: userID}, TenantID: securityTenantID}, nil)
+}
+
+func globalRole(id uint, name string) *models.Role {
+	return &models.Role{ID: id, Name: name}
+}
+
+func tenantRole(id uint, name string, tenantID uuid.UUID) *models.Role {
+	return &models.Role{ID: id, Name: name, TenantID: &tenantID}
+}
+
+func TestSecurityService_ListRolesOfTenant(t *testing.T) {
+	service, repo, _, _ := newSecurityService(t)
+	c := newSecurityContext(repo)
+
+	roles := []models.Role{*globalRole(2, "admin"), *tenantRole(3, "financeiro", securityTenantID)}
+	repo.On("FindRoles", c, securityTenantID).Return(roles, nil)
+
+	result, err := service.ListRoles(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, roles, result)
+}
+
+func TestSecurityService_CreateRoleReloadsPolicies(t *testing.T) {
+	service, repo, casbinService, _ := newSecurityService(t)
+	c := newSecurityContext(repo, *globalRole(2, "admin"))
+
+	repo.On("CreateRole", c, tenantRole(0, "financeiro", securityTenantID)).Return(nil)
+	casbinService.On("LoadPolicy").Return(nil).Once()
+
+	role, err := service.CreateRole(c, models.RoleInput{Name: " financeiro "})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "financeiro", role.Name)
+	assert.Equal(t, securityTenantID.String(), role.Domain())
+	repo.AssertExpectations(t)
+}
+
+func TestSecurityService_CreateGlobalRoleRequiresMaster(t *testing.T) {
+	t.Run("admin", func(t *testing.T) {
+		service, repo, _, _ := newSecurityService(t)
+		c := newSecurityContext(repo, *globalRole(2, "admin"), *tenantRole(4, "master", securityTenantID))
+
+		_, err := service.CreateRole(c, models.RoleInput{Name: "auditoria", Global: true})
+
+		assert.ErrorIs(t, err, services.ErrGlobalRole)
+		repo.AssertNotCalled(t, "CreateRole", mock.Anything, mock.Anything)
+	})
+
+	t.Run("master", func(t *testing.T) {
+		service, repo, casbinService, _ := newSecurityService(t)
+		c := newSecurityContext(repo, *globalRole(1, "master"))
+
+		repo.On("CreateRole", c, globalRole(0, "auditoria")).Return(nil)
+		casbinService.On("LoadPolicy").Return(nil).Once()
+
+		role, err := service.CreateRole(c, models.RoleInput{Name: "auditoria", Global: true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.GlobalDomain, role.Domain())
+	})
+}
+
+func TestSecurityService_CreateRoleDuplicated(t *testing.T) {
+	service, repo, casbinService, _ := newSecurityService(t)
+	c := newSecurityContext(repo)
+
+	repo.On("CreateRole", c, mock.Anything).Return(gorm.ErrDuplicatedKey)
+
+	_, err := service.CreateRole(c, models.RoleInput{Name: "vendas"})
+
+	assert.ErrorIs(t, err, services.ErrSecurityConflict)
+	casbinService.AssertNotCalled(t, "LoadPolicy")
+}
+
+func TestSecurityService_MasterRoleIsProtected(t *testing.T) {
+	service, repo, casbinService, _ := newSecurityService(t)
+	c := newSecurityContext(repo, *globalRole(1, "master"))
+
+	repo.On("FindRoleByID", c, uint(1)).Return(globalRole(1, "master"), nil)
+
+	_, err := service.RenameRole(c, 1, models.RoleInput{Name: "root"})
+	assert.ErrorIs(t, err, services.ErrProtectedRole)
+
+	err = service.DeleteRole(c, 1)
+	assert.ErrorIs(t, err, services.ErrProtectedRole)
+
+	repo.AssertNotCalled(t, "UpdateRoleName", mock.Anything, mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "DeleteRole", mock.Anything, mock.Anything)
+	casbinService.AssertNotCalled(t, "LoadPolicy")
+}
+
+func TestSecurityService_RoleOfAnotherTenantIsNotFound(t *testing.T) {
+	service, repo, _, tokenRedisService := newSecurityService(t)
+	c := newSecurityContext(repo, *globalRole(1, "master"))
+	userID := uuid.New()
+
+	repo.On("FindRoleByID", c, uint(9)).Return(tenantRole(9, "vendas", uuid.New()), nil)
+
+	_, err := service.GetRole(c, 9)
+	assert.ErrorIs(t, err, services.ErrRoleNotFound)
+
+	assert.ErrorIs(t, service.DeleteRole(c, 9), services.ErrRoleNotFound)
+	assert.ErrorIs(t, service.AddUserRole(c, userID, 9), services.ErrRoleNotFound)
+
+	_, err = service.ListRolePolicies(c, 9)
+	assert.ErrorIs(t, err, services.ErrRoleNotFound)
+
+	repo.AssertNotCalled(t, "DeleteRole", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "AddUserRole", mock.Anything, mock.Anything, mock.Anything)
+	tokenRedisService.AssertNotCalled(t, "RevokeAllSessions", mock.Anything)
+}
+
+func TestSecurityService_GlobalRoleRequiresMaster(t *testing.T) {
+	service, repo, casbinService, _ := newSecurityService(t)
+	c := newSecurityContext(repo, *globalRole(2, "admin"))
+	userID := uuid.New()
+
+	repo.On("FindRoleByID", c, uint(2)).Return(globalRole(2, "admin"), nil)
+
+	role, err := service.GetRole(c, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "admin", role.Name)
+
+	_, err = service.RenameRole(c, 2, models.RoleInput{Name: "gerente"})
+	assert.ErrorIs(t, err, services.ErrGlobalRole)
+
+	_, err = service.CreateRolePolicy(c, 2, models.PolicyInput{EndpointID: 7, Actions: "GET"})
+	assert.ErrorIs(t, err, services.ErrGlobalRole)
+
+	assert.ErrorIs(t, service.AddUserRole(c, userID, 2), services.ErrGlobalRole)
+
+	repo.AssertNotCalled(t, "UpdateRoleName", mock.Anything, mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "CreateRolePolicy", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "AddUserRole", mock.Anything, mock.Anything, mock.Anything)
+	casbinService.AssertNotCalled(t, "LoadPolicy")
+}
+
+func TestSecurityService_DeleteRoleInUse(t *testing.T) {
+	service, repo, _, _ := newSecurityService(t)
+	c := newSecurityContext(repo)
+
+	repo.On("FindRoleByID", c, uint(3)).Return(tenantRole(3, "financeiro", securityTenantID), nil)
+	repo.On("DeleteRole", c, uint(3)).Return(gorm.ErrForeignKeyViolated)
+
+	err := service.DeleteRole(c, 3)
+
+	assert.ErrorIs(t, err, services.ErrSecurityInUse)
+}
+
+func TestSecurityService_CreateRolePolicyNormalizesActions(t *testing.T) {
+	service, repo, casbinService, _ := newSecurityService(t)
+	c := newSecurityContext(repo, *globalRole(2, "admin"))
+	domain := securityTenantID.String()
+
+	endpoint := &models.Endpoint{ID: 7, Name: "/api/v1/reports"}
+	repo.On("FindRoleByID", c, uint(3)).Return(tenantRole(3, "financeiro", securityTenantID), nil)
+	repo.On("FindEndpointByID", c, uint(7)).Return(endpoint, nil)
+	repo.On("CreateRolePolicy", c, &models.PolicyRole{RoleID: 3, EndpointID: 7, Actions: "GET|POST|DELETE"}).Return(nil)
+	for _, action := range []string{"GET", "POST", "DELETE"} {
+		casbinService.On("CheckPermission", mock.AnythingOfType("*models.UserRedis"), domain, "/api/v1/reports", action).Return(true).Once()
+	}
+	casbinService.On("SetPolicy", "financeiro", domain, "/api/v1/reports", "GET|POST|DELETE", "").Return(nil).Once()
+
+	policy, err := service.CreateRolePolicy(c, 3, models.PolicyInput{EndpointID: 7, Actions: "delete|GET|post|GET"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "GET|POST|DELETE", policy.Actions)
+	assert.Equal(t, endpoint, policy.Endpoint)
+	repo.AssertExpectations(t)
+	casbinService.AssertNotCalled(t, "LoadPolicy")
+}
+
+func TestSecurityService_CannotGrantMissingActions(t *testing.T) {
+	service, repo, casbinService, _ := newSecurityService(t)
+	c := newSecurityContext(repo, *globalRole(2, "admin"))
+	domain := securityTenantID.String()
+
+	repo.On("FindRoleByID", c, uint(3)).Return(tenantRole(3, "financeiro", securityTenantID), nil)
+	repo.On("FindEndpointByID", c, uint(1)).Return(&models.Endpoint{ID: 1, Name: "/api/v1/tenants"}, nil)
+	casbinService.On("CheckPermission", mock.AnythingOfType("*models.UserRedis"), domain, "/api/v1/tenants", "GET").Return(true)
+	casbinService.On("CheckPermission", mock.AnythingOfType("*models.UserRedis"), domain, "/api/v1/tenants", "POST").Return(false)
+
+	_, err := service.CreateRolePolicy(c, 3, models.PolicyInput{EndpointID: 1, Actions: "GET|POST"})
+	assert.ErrorIs(t, err, services.ErrActionsNotGranted)
+
+	userID := uuid.New()
+	expectTenantUser(repo, c, userID)
+	_, err = service.CreateUserPolicy(c, userID, models.PolicyInput{EndpointID: 1, Actions: "POST"})
+	assert.ErrorIs(t, err, services.ErrActionsNotGranted)
+
+	repo.AssertNotCalled(t, "CreateRolePolicy", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "CreateUserPolicy", mock.Anything, mock.Anything)
+	casbinService.AssertNotCalled(t, "SetPolicy", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSecurityService_InvalidActions(t *testing.T) {
+	service, repo, casbinService, _ := newSecurityService(t)
+	c := newSecurityContext(repo)
+	userID := uuid.New()
+
+	for _, actions := range []string{"GET|HEAD", "GET||POST", ".*", "OPTIONS"} {
+		_, err := service.CreateRolePolicy(c, 2, models.PolicyInput{EndpointID: 7, Actions: actions})
+		assert.ErrorIs(t, err, services.ErrInvalidActions, actions)
+
+		_, err = service.UpdateUserPolicy(c, userID, 7, models.PolicyActionsInput{Actions: actions})
+		assert.ErrorIs(t, err, services.ErrInvalidActions, actions)
+	}
+
+	repo.AssertNotCalled(t, "CreateRolePolicy", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "UpdateUserPolicy", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	casbinService.AssertNotCalled(t, "SetPolicy", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSecurityService_UpdateUserPolicyIsIncremental(t *testing.T) {
+	service, repo, casbinService, _ := newSecurityService(t)
+	c := newSecurityContext(repo, *globalRole(1, "master"))
+	userID := uuid.New()
+
+	expectTenantUser(repo, c, userID)
+	repo.On("FindEndpointByID", c, uint(7)).Return(&models.Endpoint{ID: 7, Name: "/api/v1/reports"}, nil)
+	repo.On("UpdateUserPolicy", c, userID, uint(7), "GET|PUT", "").Return(nil)
+	casbinService.On("SetPolicy", userID.String(), securityTenantID.String(), "/api/v1/reports", "GET|PUT", "").Return(nil).Once()
+
+	policy, err := service.UpdateUserPolicy(c, userID, 7, models.PolicyActionsInput{Actions: "put|get"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "GET|PUT", policy.Actions)
+	casbinService.AssertNotCalled(t, "LoadPolicy")
+	casbinService.AssertNotCalled(t, "CheckPermission", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSecurityService_PolicyCondition(t *testing.T) {
+	service, repo, casbinService, _ := newSecurityService(t)
+	c := newSecurityContext(repo, *globalRole(1, "master"))
+	userID := uuid.New()
+
+	expectTenantUser(repo, c, userID)
+	repo.On("FindRoleByID", c, uint(3)).Return(tenantRole(3, "financeiro", securityTenantID), nil)
+	repo.On("FindEndpointByID", c, uint(8)).Return(&models.Endpoint{ID: 8, Name: "/api/v1/users/:id"}, nil)
+	repo.On("CreateRolePolicy", c, &models.PolicyRole{RoleID: 3, EndpointID: 8, Actions: "GET|PATCH", Condition: "param.id == user.id"}).Return(nil)
+	casbinService.On("SetPolicy", "financeiro", securityTenantID.String(), "/api/v1/users/:id", "GET|PATCH", "param.id == user.id").Return(nil).Once()
+
+	policy, err := service.CreateRolePolicy(c, 3, models.PolicyInput{EndpointID: 8, Actions: "GET|PATCH", Condition: "  param.id==user.id "})
+	assert.NoError(t, err)
+	assert.Equal(t, "param.id == user.id", policy.Condition)
+
+	for _, condition := range []string{
+		"param.tenant == user.tenant_id", // parâmetro que não existe no endpoint
+		"param.id == user.password",      // atributo não exposto
+		"param.id == 'x'",
+		"param.id == user.id ||",
+		"param.id = user.id",
+	} {
+		_, err := service.UpdateUserPolicy(c, userID, 8, models.PolicyActionsInput{Actions: "GET", Condition: condition})
+		assert.ErrorIs(t, err, services.ErrInvalidCondition, condition)
+	}
+	repo.AssertNotCalled(t, "UpdateUserPolicy", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSecurityService_DeleteRolePolicyIsIncremental(t *testing.T) {
+	service, repo, casbinService, _ := newSecurityService(t)
+	c := newSecurityContext(repo)
+
+	repo.On("FindRoleByID", c, uint(3)).Return(tenantRole(3, "financeiro", securityTenantID), nil)
+	repo.On("FindEndpointByID", c, uint(7)).Return(&models.Endpoint{ID: 7, Name: "/api/v1/reports"}, nil)
+	repo.On("DeleteRolePolicy", c, uint(3), uint(7)).Return(nil)
+	casbinService.On("RemovePolicy", "financeiro", securityTenantID.String(), "/api/v1/reports").Return(nil).Once()
+
+	assert.NoError(t, service.DeleteRolePolicy(c, 3, 7))
+	casbinService.AssertNotCalled(t, "LoadPolicy")
+}
+
+func TestSecurityService_UpdateUserPolicyNotFound(t *testing.T) {
+	service, repo, casbinService, _ := newSecurityService(t)
+	c := newSecurityContext(repo, *globalRole(1, "master"))
+	userID := uuid.New()
+
+	expectTenantUser(repo, c, userID)
+	repo.On("FindEndpointByID", c, uint(7)).Return(&models.Endpoint{ID: 7, Name: "/api/v1/reports"}, nil)
+	repo.On("FindEndpointByID", c, uint(8)).Return(nil, gorm.ErrRecordNotFound)
+	repo.On("UpdateUserPolicy", c, userID, uint(7), "GET", "").Return(gorm.ErrRecordNotFound)
+
+	_, err := service.UpdateUserPolicy(c, userID, 7, models.PolicyActionsInput{Actions: "GET"})
+	assert.ErrorIs(t, err, services.ErrPolicyNotFound)
+
+	_, err = service.UpdateUserPolicy(c, userID, 8, models.PolicyActionsInput{Actions: "GET"})
+	assert.ErrorIs(t, err, services.ErrPolicyNotFound)
+
+	casbinService.AssertNotCalled(t, "SetPolicy", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSecurityService_AddUserRoleRevokesSessions(t *testing.T) {
+	service, repo, casbinService, tokenRedisService := newSecurityService(t)
+	c := newSecurityContext(repo)
+	userID := uuid.New()
+
+	expectTenantUser(repo, c, userID)
+	repo.On("FindRoleByID", c, uint(3)).Return(tenantRole(3, "financeiro", securityTenantID), nil)
+	repo.On("AddUserRole", c, userID, uint(3)).Return(nil)
+	casbinService.On("AddRoleForUser", userID.String(), "financeiro", securityTenantID.String()).Return(nil).Once()
+	tokenRedisService.On("RevokeAllSessions", userID.String()).Return(nil).Once()
+
+	assert.NoError(t, service.AddUserRole(c, userID, 3))
+	repo.AssertExpectations(t)
+}
+
+func TestSecurityService_RemoveUserRoleIsIncremental(t *testing.T) {
+	service, repo, casbinService, tokenRedisService := newSecurityService(t)
+	c := newSecurityContext(repo, *globalRole(1, "master"))
+	userID := uuid.New()
+
+	expectTenantUser(repo, c, userID)
+	repo.On("FindRoleByID", c, uint(2)).Return(globalRole(2, "admin"), nil)
+	repo.On("RemoveUserRole", c, userID, uint(2)).Return(nil)
+	casbinService.On("DeleteRoleForUser", userID.String(), "admin", models.GlobalDomain).Return(nil).Once()
+	tokenRedisService.On("RevokeAllSessions", userID.String()).Return(nil).Once()
+
+	assert.NoError(t, service.RemoveUserRole(c, userID, 2))
+	casbinService.AssertNotCalled(t, "LoadPolicy")
+}
+
+func TestSecurityService_UserOfAnotherTenantIsNotFound(t *testing.T) {
+	service, repo, casbinService, tokenRedisService := newSecurityService(t)
+	c := newSecurityContext(repo, *globalRole(2, "admin"))
+	userID := uuid.New()
+
+	// O usuário existe, mas em outro tenant: a busca restrita ao tenant da requisição não o encontra
+	repo.On("FindTenantUser", c, securityTenantID, userID).Return(nil, gorm.ErrRecordNotFound)
+	repo.On("FindRoleByID", c, uint(3)).Return(tenantRole(3, "financeiro", securityTenantID), nil)
+
+	_, err := service.ListUserRoles(c, userID)
+	assert.ErrorIs(t, err, services.ErrUserNotFound)
+	_, err = service.ListUserPolicies(c, userID)
+	assert.ErrorIs(t, err, services.ErrUserNotFound)
+
+	assert.ErrorIs(t, service.AddUserRole(c, userID, 3), services.ErrUserNotFound)
+	assert.ErrorIs(t, service.RemoveUserRole(c, userID, 3), services.ErrUserNotFound)
+
+	_, err = service.CreateUserPolicy(c, userID, models.PolicyInput{EndpointID: 7, Actions: "GET"})
+	assert.ErrorIs(t, err, services.ErrUserNotFound)
+	_, err = service.UpdateUserPolicy(c, userID, 7, models.PolicyActionsInput{Actions: "GET"})
+	assert.ErrorIs(t, err, services.ErrUserNotFound)
+	assert.ErrorIs(t, service.DeleteUserPolicy(c, userID, 7), services.ErrUserNotFound)
+
+	repo.AssertNotCalled(t, "FindUserRoles", c, userID)
+	repo.AssertNotCalled(t, "FindUserPolicies", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "AddUserRole", mock.Anything, mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "RemoveUserRole", mock.Anything, mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "CreateUserPolicy", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "UpdateUserPolicy", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "DeleteUserPolicy", mock.Anything, mock.Anything, mock.Anything)
+	casbinService.AssertNotCalled(t, "SetPolicy", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	casbinService.AssertNotCalled(t, "AddRoleForUser", mock.Anything, mock.Anything, mock.Anything)
+	tokenRedisService.AssertNotCalled(t, "RevokeAllSessions", mock.Anything)
+}
+
+func TestSecurityService_AddUserRoleUnknownRole(t *testing.T) {
+	service, repo, _, tokenRedisService := newSecurityService(t)
+	c := newSecurityContext(repo)
+	userID := uuid.New()
+
+	repo.On("FindRoleByID", c, uint(99)).Return(nil, gorm.ErrRecordNotFound)
+
+	err := service.AddUserRole(c, userID, 99)
+
+	assert.ErrorIs(t, err, services.ErrRoleNotFound)
+	tokenRedisService.AssertNotCalled(t, "RevokeAllSessions", mock.Anything)
+}
+
+func TestSecurityService_ReloadFailureIsReported(t *testing.T) {
+	service, repo, casbinService, _ := newSecurityService(t)
+	c := newSecurityContext(repo)
+
+	repo.On("DeleteEndpoint", c, uint(7)).Return(nil)
+	casbinService.On("LoadPolicy").Return(errors.New("db down")).Once()
+
+	assert.Error(t, service.DeleteEndpoint(c, 7))
+}
+
+func TestSecurityService_RequiresTenant(t *testing.T) {
+	service, _, _, _ := newSecurityService(t)
+	c, _ := gin.CreateTestContext(nil)
+
+	_, err := service.ListRoles(c)
+
+	assert.ErrorIs(t, err, services.ErrSecurityContext)
+}
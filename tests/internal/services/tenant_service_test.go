@@ -7,10 +7,13 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/enums"
 	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
 	"github.com/jeancarlosdanese/go-base-api/internal/services"
+	"github.com/jeancarlosdanese/go-base-api/tests/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 // MockTenantRepository é um repositório mock para testes
@@ -38,9 +41,12 @@ func (m *MockTenantRepository) Delete(c *gin.Context, id uuid.UUID) error {
 	return args.Error(0)
 }
 
-func (m *MockTenantRepository) GetAll(c *gin.Context) ([]models.Tenant, error) {
-	args := m.Called(c)
-	return args.Get(0).([]models.Tenant), args.Error(1)
+func (m *MockTenantRepository) GetAll(c *gin.Context, query models.ListQuery) (*models.Page[models.Tenant], error) {
+	args := m.Called(c, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Page[models.Tenant]), args.Error(1)
 }
 
 func (m *MockTenantRepository) GetByID(c *gin.Context, id uuid.UUID) (*models.Tenant, error) {
@@ -48,14 +54,46 @@ func (m *MockTenantRepository) GetByID(c *gin.Context, id uuid.UUID) (*models.Te
 	return args.Get(0).(*models.Tenant), args.Error(1)
 }
 
-func (m *MockTenantRepository) FindByApiKey(apiKey, origin string) (*models.Tenant, error) {
-	args := m.Called(apiKey, origin)
+func (m *MockTenantRepository) SearchTenants(c *gin.Context, term string, limit int) ([]models.Tenant, error) {
+	args := m.Called(c, term, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Tenant), args.Error(1)
+}
+
+func (m *MockTenantRepository) FindDeletedTenants(c *gin.Context, query models.ListQuery) (*models.Page[models.Tenant], error) {
+	args := m.Called(c, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Page[models.Tenant]), args.Error(1)
+}
+
+func (m *MockTenantRepository) RestoreTenant(c *gin.Context, id uuid.UUID) (*models.Tenant, error) {
+	args := m.Called(c, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
 	return args.Get(0).(*models.Tenant), args.Error(1)
 }
 
+func (m *MockTenantRepository) PurgeTenant(c *gin.Context, id uuid.UUID) error {
+	args := m.Called(c, id)
+	return args.Error(0)
+}
+
+func (m *MockTenantRepository) FindTenantIDsByStatus(status enums.StatusType) ([]uuid.UUID, error) {
+	args := m.Called(status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
 func TestTenantService_Create(t *testing.T) {
 	repo := new(MockTenantRepository)
-	service := services.NewTenantService(repo)
+	service := services.NewTenantService(repo, nil, nil)
 
 	c := &gin.Context{}
 
@@ -81,10 +119,13 @@ func TestTenantService_Create(t *testing.T) {
 
 func TestTenantService_Update(t *testing.T) {
 	repo := new(MockTenantRepository)
-	service := services.NewTenantService(repo)
+	redisService := mocks.NewRedisService(t)
+	tenantStatusService := mocks.NewTenantStatusService(t)
+	service := services.NewTenantService(repo, services.NewApiKeyService(new(mocks.MockApiKeyRepository), redisService), tenantStatusService)
 
 	c := &gin.Context{}
 	tenantID := uuid.New()
+	expectApiKeyCacheInvalidation(redisService, tenantID)
 	email := "tenant@example.com"
 	tenantUpdate := models.Tenant{
 		BaseModel: models.BaseModel{ID: tenantID},
@@ -95,6 +136,7 @@ func TestTenantService_Update(t *testing.T) {
 
 	// Configuração correta do mock para incluir o ID do tenant como argumento
 	repo.On("Update", c, tenantID, &tenantUpdate).Return(&tenantUpdate, nil)
+	tenantStatusService.On("Reactivate", tenantID).Return(nil)
 
 	tenant, err := service.Update(c, tenantID, &tenantUpdate)
 
@@ -109,11 +151,14 @@ func TestTenantService_Update(t *testing.T) {
 
 func TestTenantService_UpdatePartial(t *testing.T) {
 	repo := new(MockTenantRepository)
-	service := services.NewTenantService(repo)
+	redisService := mocks.NewRedisService(t)
+	tenantStatusService := mocks.NewTenantStatusService(t)
+	service := services.NewTenantService(repo, services.NewApiKeyService(new(mocks.MockApiKeyRepository), redisService), tenantStatusService)
 
 	c := &gin.Context{}
 
 	tenantID := uuid.New()
+	expectApiKeyCacheInvalidation(redisService, tenantID)
 	updateData := map[string]interface{}{
 		"name": "Partially Updated Tenant",
 	}
@@ -135,12 +180,16 @@ func TestTenantService_UpdatePartial(t *testing.T) {
 
 func TestTenantService_Delete(t *testing.T) {
 	repo := new(MockTenantRepository)
-	service := services.NewTenantService(repo)
+	redisService := mocks.NewRedisService(t)
+	tenantStatusService := mocks.NewTenantStatusService(t)
+	service := services.NewTenantService(repo, services.NewApiKeyService(new(mocks.MockApiKeyRepository), redisService), tenantStatusService)
 
 	c := &gin.Context{}
 
 	tenantID := uuid.New()
+	expectApiKeyCacheInvalidation(redisService, tenantID)
 	repo.On("Delete", c, tenantID).Return(nil)
+	tenantStatusService.On("Suspend", c, tenantID).Return(nil)
 
 	err := service.Delete(c, tenantID)
 
@@ -151,7 +200,7 @@ func TestTenantService_Delete(t *testing.T) {
 
 func TestTenantService_GetAll(t *testing.T) {
 	repo := new(MockTenantRepository)
-	service := services.NewTenantService(repo)
+	service := services.NewTenantService(repo, nil, nil)
 
 	c := &gin.Context{}
 
@@ -165,20 +214,22 @@ func TestTenantService_GetAll(t *testing.T) {
 			Name:      "Tenant 2",
 		},
 	}
-	repo.On("GetAll", c).Return(tenants, nil)
+	query := models.ListQuery{Sort: []models.ListSort{{Column: "name"}}, Limit: 50}
+	repo.On("GetAll", c, query).Return(&models.Page[models.Tenant]{Items: tenants, Total: 2}, nil)
 
-	allTenants, err := service.GetAll(c)
+	page, err := service.GetAll(c, query)
 
 	assert.NoError(t, err)
-	assert.Len(t, allTenants, 2)
-	assert.Equal(t, tenants, allTenants)
+	assert.Len(t, page.Items, 2)
+	assert.Equal(t, tenants, page.Items)
+	assert.Equal(t, int64(2), page.Total)
 
-	repo.AssertCalled(t, "GetAll", c)
+	repo.AssertCalled(t, "GetAll", c, query)
 }
 
 func TestTenantService_GetByID(t *testing.T) {
 	repo := new(MockTenantRepository)
-	service := services.NewTenantService(repo)
+	service := services.NewTenantService(repo, nil, nil)
 
 	c := &gin.Context{}
 
@@ -198,3 +249,131 @@ func TestTenantService_GetByID(t *testing.T) {
 
 	repo.AssertCalled(t, "GetByID", c, tenantID)
 }
+
+func TestTenantService_DeactivateSuspendsTenant(t *testing.T) {
+	repo := new(MockTenantRepository)
+	redisService := mocks.NewRedisService(t)
+	tenantStatusService := mocks.NewTenantStatusService(t)
+	service := services.NewTenantService(repo, services.NewApiKeyService(new(mocks.MockApiKeyRepository), redisService), tenantStatusService)
+
+	c := &gin.Context{}
+	tenantID := uuid.New()
+	updateData := map[string]interface{}{"status": "INATIVO"}
+
+	repo.On("UpdatePartial", c, tenantID, updateData).Return(&models.Tenant{BaseModel: models.BaseModel{ID: tenantID}, Status: "INATIVO"}, nil)
+	expectApiKeyCacheInvalidation(redisService, tenantID)
+	tenantStatusService.On("Suspend", c, tenantID).Return(nil)
+
+	_, err := service.UpdatePartial(c, tenantID, updateData)
+
+	assert.NoError(t, err)
+	redisService.AssertExpectations(t)
+}
+
+func TestTenantService_ReactivateRemovesSuspension(t *testing.T) {
+	repo := new(MockTenantRepository)
+	redisService := mocks.NewRedisService(t)
+	tenantStatusService := mocks.NewTenantStatusService(t)
+	service := services.NewTenantService(repo, services.NewApiKeyService(new(mocks.MockApiKeyRepository), redisService), tenantStatusService)
+
+	c := &gin.Context{}
+	tenantID := uuid.New()
+	updateData := map[string]interface{}{"status": "ATIVO"}
+
+	repo.On("UpdatePartial", c, tenantID, updateData).Return(&models.Tenant{BaseModel: models.BaseModel{ID: tenantID}, Status: "ATIVO"}, nil)
+	expectApiKeyCacheInvalidation(redisService, tenantID)
+	tenantStatusService.On("Reactivate", tenantID).Return(nil)
+
+	_, err := service.UpdatePartial(c, tenantID, updateData)
+
+	assert.NoError(t, err)
+	tenantStatusService.AssertNotCalled(t, "Suspend", mock.Anything, mock.Anything)
+}
+
+func TestTenantService_Restore(t *testing.T) {
+	c := &gin.Context{}
+	tenantID := uuid.New()
+
+	t.Run("Restaura e desfaz a suspensão", func(t *testing.T) {
+		repo := new(MockTenantRepository)
+		tenantStatusService := mocks.NewTenantStatusService(t)
+		service := services.NewTenantService(repo, nil, tenantStatusService)
+
+		repo.On("RestoreTenant", c, tenantID).Return(&models.Tenant{BaseModel: models.BaseModel{ID: tenantID}, Status: enums.Ativo}, nil)
+		tenantStatusService.On("Reactivate", tenantID).Return(nil)
+
+		restored, err := service.Restore(c, tenantID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, tenantID, restored.ID)
+	})
+
+	t.Run("Tenant inativo continua suspenso", func(t *testing.T) {
+		repo := new(MockTenantRepository)
+		tenantStatusService := mocks.NewTenantStatusService(t)
+		service := services.NewTenantService(repo, nil, tenantStatusService)
+
+		repo.On("RestoreTenant", c, tenantID).Return(&models.Tenant{BaseModel: models.BaseModel{ID: tenantID}, Status: enums.Inativo}, nil)
+		tenantStatusService.On("Suspend", c, tenantID).Return(nil)
+
+		_, err := service.Restore(c, tenantID)
+
+		assert.NoError(t, err)
+		tenantStatusService.AssertNotCalled(t, "Reactivate", mock.Anything)
+	})
+
+	t.Run("Fora da lixeira ou com dados únicos em uso", func(t *testing.T) {
+		repo := new(MockTenantRepository)
+		service := services.NewTenantService(repo, nil, nil)
+
+		repo.On("RestoreTenant", c, tenantID).Return(nil, gorm.ErrRecordNotFound).Once()
+		_, err := service.Restore(c, tenantID)
+		assert.ErrorIs(t, err, services.ErrNotFound)
+
+		repo.On("RestoreTenant", c, tenantID).Return(nil, gorm.ErrDuplicatedKey).Once()
+		_, err = service.Restore(c, tenantID)
+		assert.ErrorIs(t, err, services.ErrRestoreConflict)
+	})
+}
+
+func TestTenantService_Purge(t *testing.T) {
+	c := &gin.Context{}
+	tenantID := uuid.New()
+
+	t.Run("Remove definitivamente e limpa o cache das API Keys e a suspensão", func(t *testing.T) {
+		repo := new(MockTenantRepository)
+		redisService := mocks.NewRedisService(t)
+		tenantStatusService := mocks.NewTenantStatusService(t)
+		service := services.NewTenantService(repo, services.NewApiKeyService(new(mocks.MockApiKeyRepository), redisService), tenantStatusService)
+
+		repo.On("PurgeTenant", c, tenantID).Return(nil)
+		expectApiKeyCacheInvalidation(redisService, tenantID)
+		// A marca de suspensão da remoção lógica não expira e é removida junto com o tenant
+		tenantStatusService.On("Reactivate", tenantID).Return(nil).Once()
+
+		assert.NoError(t, service.Purge(c, tenantID))
+	})
+
+	t.Run("Tenant com usuários ou roles", func(t *testing.T) {
+		repo := new(MockTenantRepository)
+		service := services.NewTenantService(repo, nil, nil)
+
+		repo.On("PurgeTenant", c, tenantID).Return(gorm.ErrForeignKeyViolated)
+
+		assert.ErrorIs(t, service.Purge(c, tenantID), services.ErrTenantHasDependents)
+	})
+}
+
+func TestTenantService_SyncSuspendedTenants(t *testing.T) {
+	repo := new(MockTenantRepository)
+	tenantStatusService := mocks.NewTenantStatusService(t)
+	service := services.NewTenantService(repo, nil, tenantStatusService)
+
+	inactive := []uuid.UUID{uuid.New(), uuid.New()}
+	repo.On("FindTenantIDsByStatus", enums.Inativo).Return(inactive, nil)
+	tenantStatusService.On("MarkSuspended", inactive[0]).Return(nil)
+	tenantStatusService.On("MarkSuspended", inactive[1]).Return(nil)
+
+	assert.NoError(t, service.SyncSuspendedTenants())
+	tenantStatusService.AssertNotCalled(t, "Suspend", mock.Anything, mock.Anything)
+}
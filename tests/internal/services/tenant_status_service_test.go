@@ -0,0 +1,74 @@
+// tests/internal/services/tenant_status_service_test.go
+
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+	"github.com/jeancarlosdanese/go-base-api/tests/mocks"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTenantStatusService_Suspend(t *testing.T) {
+	c := &gin.Context{}
+	tenantID := uuid.New()
+
+	t.Run("Marca o tenant e revoga as sessões de todos os usuários", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		redisService := mocks.NewRedisService(t)
+		tokenRedisService := mocks.NewTokenRedisService(t)
+		service := services.NewTenantStatusService(userRepo, redisService, tokenRedisService)
+
+		userIDs := []uuid.UUID{uuid.New(), uuid.New()}
+		redisService.On("Set", "tenant_suspended:"+tenantID.String(), 1, mock.AnythingOfType("time.Duration")).Return(nil)
+		userRepo.On("FindUserIDsByTenant", c, tenantID).Return(userIDs, nil)
+		tokenRedisService.On("RevokeAllSessions", userIDs[0].String()).Return(nil)
+		tokenRedisService.On("RevokeAllSessions", userIDs[1].String()).Return(nil)
+
+		assert.NoError(t, service.Suspend(c, tenantID))
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("Falha ao marcar não revoga nada", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		redisService := mocks.NewRedisService(t)
+		service := services.NewTenantStatusService(userRepo, redisService, mocks.NewTokenRedisService(t))
+
+		redisService.On("Set", "tenant_suspended:"+tenantID.String(), 1, mock.AnythingOfType("time.Duration")).Return(errors.New("redis indisponível"))
+
+		assert.Error(t, service.Suspend(c, tenantID))
+		userRepo.AssertNotCalled(t, "FindUserIDsByTenant", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTenantStatusService_IsSuspended(t *testing.T) {
+	tenantID := uuid.New().String()
+	redisService := mocks.NewRedisService(t)
+	service := services.NewTenantStatusService(new(mocks.MockUserRepository), redisService, mocks.NewTokenRedisService(t))
+
+	redisService.On("Get", "tenant_suspended:"+tenantID).Return("1", nil).Once()
+	suspended, err := service.IsSuspended(tenantID)
+	assert.NoError(t, err)
+	assert.True(t, suspended)
+
+	redisService.On("Get", "tenant_suspended:"+tenantID).Return("", redis.Nil).Once()
+	suspended, err = service.IsSuspended(tenantID)
+	assert.NoError(t, err)
+	assert.False(t, suspended)
+}
+
+func TestTenantStatusService_Reactivate(t *testing.T) {
+	tenantID := uuid.New()
+	redisService := mocks.NewRedisService(t)
+	service := services.NewTenantStatusService(new(mocks.MockUserRepository), redisService, mocks.NewTokenRedisService(t))
+
+	redisService.On("Delete", "tenant_suspended:"+tenantID.String()).Return(nil)
+
+	assert.NoError(t, service.Reactivate(tenantID))
+}
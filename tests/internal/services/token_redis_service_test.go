@@ -0,0 +1,173 @@
+// tests/internal/services/token_redis_service_test.go
+
+package services_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+	"github.com/jeancarlosdanese/go-base-api/tests/mocks"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTokenRedisService_SaveUserRedis(t *testing.T) {
+	redisService := mocks.NewRedisService(t)
+	service := services.NewTokenRedisService(redisService)
+
+	user := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, TenantID: uuid.New()}
+	session := &models.UserSession{ID: "session-1", AccessToken: "access", RefreshToken: "refresh"}
+	sessionsKey := "user_sessions:" + user.ID.String()
+
+	redisService.On("HGet", sessionsKey, "session-1").Return("", redis.Nil)
+	redisService.On("Set", "token:access", mock.Anything, time.Hour).Return(nil)
+	redisService.On("Set", "refresh_token:refresh", mock.Anything, 90*time.Hour).Return(nil)
+	redisService.On("HSet", sessionsKey, "session-1", mock.Anything, 90*time.Hour).Return(nil)
+
+	err := service.SaveUserRedis(user, session, time.Hour, 90*time.Hour)
+
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID.String(), session.UserID)
+	assert.False(t, session.CreatedAt.IsZero())
+}
+
+func TestTokenRedisService_SaveUserRedisReplacesPreviousTokens(t *testing.T) {
+	redisService := mocks.NewRedisService(t)
+	service := services.NewTokenRedisService(redisService)
+
+	user := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, TenantID: uuid.New()}
+	sessionsKey := "user_sessions:" + user.ID.String()
+	createdAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	previous, _ := json.Marshal(models.UserSession{ID: "session-1", AccessToken: "old-access", RefreshToken: "old-refresh", CreatedAt: createdAt})
+	session := &models.UserSession{ID: "session-1", AccessToken: "new-access", RefreshToken: "new-refresh"}
+
+	redisService.On("HGet", sessionsKey, "session-1").Return(string(previous), nil)
+	redisService.On("Delete", "token:old-access", "refresh_token:old-refresh").Return(nil)
+	redisService.On("Set", "token:new-access", mock.Anything, time.Hour).Return(nil)
+	redisService.On("Set", "refresh_token:new-refresh", mock.Anything, 90*time.Hour).Return(nil)
+	redisService.On("HSet", sessionsKey, "session-1", mock.Anything, 90*time.Hour).Return(nil)
+
+	err := service.SaveUserRedis(user, session, time.Hour, 90*time.Hour)
+
+	assert.NoError(t, err)
+	assert.True(t, createdAt.Equal(session.CreatedAt))
+}
+
+func TestTokenRedisService_ConsumeRefreshToken(t *testing.T) {
+	redisService := mocks.NewRedisService(t)
+	service := services.NewTokenRedisService(redisService)
+
+	session, _ := json.Marshal(models.UserSession{ID: "family-1", AccessToken: "access", RefreshToken: "current"})
+	userRedis, _ := json.Marshal(models.UserRedis{ID: "user-1", SessionID: "family-1"})
+	redisService.On("HGet", "user_sessions:user-1", "family-1").Return(string(session), nil)
+	redisService.On("GetDel", "refresh_token:current").Return(string(userRedis), nil)
+
+	consumed, err := service.ConsumeRefreshToken("user-1", "family-1", "current")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "family-1", consumed.SessionID)
+}
+
+func TestTokenRedisService_ConsumeRefreshTokenRevokedFamily(t *testing.T) {
+	redisService := mocks.NewRedisService(t)
+	service := services.NewTokenRedisService(redisService)
+
+	redisService.On("HGet", "user_sessions:user-1", "family-1").Return("", redis.Nil)
+
+	_, err := service.ConsumeRefreshToken("user-1", "family-1", "any")
+
+	assert.ErrorIs(t, err, services.ErrRefreshTokenRevoked)
+}
+
+func TestTokenRedisService_SessionReadFailure(t *testing.T) {
+	redisService := mocks.NewRedisService(t)
+	service := services.NewTokenRedisService(redisService)
+	readErr := errors.New("redis indisponível")
+
+	// Uma falha do Redis não é tratada como sessão inexistente
+	redisService.On("HGet", "user_sessions:user-1", "family-1").Return("", readErr)
+
+	_, err := service.ConsumeRefreshToken("user-1", "family-1", "current")
+	assert.ErrorIs(t, err, readErr)
+	assert.NotErrorIs(t, err, services.ErrRefreshTokenRevoked)
+
+	assert.ErrorIs(t, service.RevokeSession("user-1", "family-1"), readErr)
+	redisService.AssertNotCalled(t, "HDel", mock.Anything, mock.Anything)
+
+	user := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}}
+	redisService.On("HGet", "user_sessions:"+user.ID.String(), "family-1").Return("", readErr)
+	err = service.SaveUserRedis(user, &models.UserSession{ID: "family-1", AccessToken: "access", RefreshToken: "refresh"}, time.Hour, 90*time.Hour)
+	assert.ErrorIs(t, err, readErr)
+	redisService.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTokenRedisService_ConsumeRefreshTokenReuseRevokesFamily(t *testing.T) {
+	redisService := mocks.NewRedisService(t)
+	service := services.NewTokenRedisService(redisService)
+
+	session, _ := json.Marshal(models.UserSession{ID: "family-1", AccessToken: "access", RefreshToken: "rotated"})
+	redisService.On("HGet", "user_sessions:user-1", "family-1").Return(string(session), nil)
+	redisService.On("Delete", "token:access", "refresh_token:rotated").Return(nil).Once()
+	redisService.On("HDel", "user_sessions:user-1", "family-1").Return(nil).Once()
+
+	_, err := service.ConsumeRefreshToken("user-1", "family-1", "already-consumed")
+
+	assert.ErrorIs(t, err, services.ErrRefreshTokenReused)
+	redisService.AssertNotCalled(t, "GetDel", mock.Anything)
+}
+
+func TestTokenRedisService_RevokeAllSessions(t *testing.T) {
+	redisService := mocks.NewRedisService(t)
+	service := services.NewTokenRedisService(redisService)
+
+	first, _ := json.Marshal(models.UserSession{ID: "s1", AccessToken: "a1", RefreshToken: "r1"})
+	redisService.On("HGetAll", "user_sessions:user-1").Return(map[string]string{"s1": string(first)}, nil)
+	redisService.On("Delete", "token:a1", "refresh_token:r1", "user_sessions:user-1").Return(nil)
+
+	assert.NoError(t, service.RevokeAllSessions("user-1"))
+}
+
+func TestTokenRedisService_ListSessionsPrunesExpired(t *testing.T) {
+	redisService := mocks.NewRedisService(t)
+	service := services.NewTokenRedisService(redisService)
+
+	userID := uuid.New().String()
+	sessionsKey := "user_sessions:" + userID
+	active, _ := json.Marshal(models.UserSession{ID: "active", RefreshedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)})
+	expired, _ := json.Marshal(models.UserSession{ID: "expired", RefreshedAt: time.Now().Add(-2 * time.Hour), ExpiresAt: time.Now().Add(-time.Hour)})
+
+	redisService.On("HGetAll", sessionsKey).Return(map[string]string{"active": string(active), "expired": string(expired)}, nil)
+	redisService.On("HDel", sessionsKey, "expired").Return(nil)
+
+	sessions, err := service.ListSessions(userID)
+
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 1)
+	assert.Equal(t, "active", sessions[0].ID)
+}
+
+func TestTokenRedisService_RevokeOtherSessions(t *testing.T) {
+	redisService := mocks.NewRedisService(t)
+	service := services.NewTokenRedisService(redisService)
+
+	userID := uuid.New().String()
+	sessionsKey := "user_sessions:" + userID
+	current, _ := json.Marshal(models.UserSession{ID: "current", AccessToken: "a1", RefreshToken: "r1", ExpiresAt: time.Now().Add(time.Hour)})
+	other, _ := json.Marshal(models.UserSession{ID: "other", AccessToken: "a2", RefreshToken: "r2", ExpiresAt: time.Now().Add(time.Hour)})
+
+	redisService.On("HGetAll", sessionsKey).Return(map[string]string{"current": string(current), "other": string(other)}, nil)
+	redisService.On("HGet", sessionsKey, "other").Return(string(other), nil)
+	redisService.On("Delete", "token:a2", "refresh_token:r2").Return(nil)
+	redisService.On("HDel", sessionsKey, "other").Return(nil)
+
+	err := service.RevokeOtherSessions(userID, "current")
+
+	assert.NoError(t, err)
+	redisService.AssertNotCalled(t, "HGet", sessionsKey, "current")
+}
@@ -0,0 +1,123 @@
+// tests/internal/services/token_service_test.go
+
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenService_RefreshTokensCarriesFamily(t *testing.T) {
+	service := services.NewTokenService(services.NewHMACSigner("test-secret"), time.Hour, 24*time.Hour, services.TokenClaimsConfig{})
+	userID := uuid.New()
+
+	_, refreshToken, err := service.CreateTokens(services.TokenSubject{UserID: userID, FamilyID: "family-1"})
+	assert.NoError(t, err)
+
+	claims, err := service.RefreshTokens(refreshToken)
+
+	assert.NoError(t, err)
+	assert.Equal(t, userID, claims.UserID)
+	assert.Equal(t, "family-1", claims.FamilyID)
+	assert.NotEmpty(t, claims.TokenID)
+}
+
+func TestTokenService_RotatedRefreshTokensAreUnique(t *testing.T) {
+	service := services.NewTokenService(services.NewHMACSigner("test-secret"), time.Hour, 24*time.Hour, services.TokenClaimsConfig{})
+	subject := services.TokenSubject{UserID: uuid.New(), FamilyID: "family-1"}
+
+	_, first, err := service.CreateTokens(subject)
+	assert.NoError(t, err)
+	_, second, err := service.CreateTokens(subject)
+	assert.NoError(t, err)
+
+	assert.NotEqual(t, first, second)
+}
+
+func TestTokenService_RefreshTokensInvalidSignature(t *testing.T) {
+	issuer := services.NewTokenService(services.NewHMACSigner("secret-a"), time.Hour, 24*time.Hour, services.TokenClaimsConfig{})
+	verifier := services.NewTokenService(services.NewHMACSigner("secret-b"), time.Hour, 24*time.Hour, services.TokenClaimsConfig{})
+
+	_, refreshToken, err := issuer.CreateTokens(services.TokenSubject{UserID: uuid.New(), FamilyID: "family-1"})
+	assert.NoError(t, err)
+
+	_, err = verifier.RefreshTokens(refreshToken)
+
+	assert.Error(t, err)
+}
+
+func TestTokenService_EmitsStandardClaims(t *testing.T) {
+	cfg := services.TokenClaimsConfig{Issuer: "go-base-api", Audience: "clients"}
+	service := services.NewTokenService(services.NewHMACSigner("test-secret"), time.Hour, 24*time.Hour, cfg)
+	subject := services.TokenSubject{UserID: uuid.New(), TenantID: uuid.New(), FamilyID: "family-1"}
+
+	accessToken, refreshToken, err := service.CreateTokens(subject)
+	assert.NoError(t, err)
+
+	token, err := service.ValidateToken(accessToken)
+	assert.NoError(t, err)
+
+	claims := token.Claims.(*services.AccessTokenClaims)
+	assert.Equal(t, subject.UserID.String(), claims.Subject)
+	assert.Equal(t, subject.TenantID.String(), claims.TenantID)
+	assert.Equal(t, "go-base-api", claims.Issuer)
+	assert.Equal(t, []string{"clients"}, []string(claims.Audience))
+	assert.NotEmpty(t, claims.ID)
+	assert.NotNil(t, claims.IssuedAt)
+	assert.NotNil(t, claims.NotBefore)
+
+	refreshClaims, err := service.RefreshTokens(refreshToken)
+	assert.NoError(t, err)
+	assert.Equal(t, subject.TenantID, refreshClaims.TenantID)
+}
+
+func TestTokenService_RejectsWrongTokenUse(t *testing.T) {
+	service := services.NewTokenService(services.NewHMACSigner("test-secret"), time.Hour, 24*time.Hour, services.TokenClaimsConfig{})
+	subject := services.TokenSubject{UserID: uuid.New(), TenantID: uuid.New(), FamilyID: "family-1"}
+
+	accessToken, refreshToken, err := service.CreateTokens(subject)
+	assert.NoError(t, err)
+
+	_, err = service.ValidateToken(refreshToken)
+	assert.ErrorIs(t, err, services.ErrWrongTokenUse)
+
+	_, err = service.RefreshTokens(accessToken)
+	assert.ErrorIs(t, err, services.ErrWrongTokenUse)
+}
+
+func TestTokenService_RejectsWrongIssuerAndAudience(t *testing.T) {
+	signer := services.NewHMACSigner("test-secret")
+	issuer := services.NewTokenService(signer, time.Hour, 24*time.Hour, services.TokenClaimsConfig{Issuer: "other", Audience: "clients"})
+	subject := services.TokenSubject{UserID: uuid.New(), TenantID: uuid.New(), FamilyID: "family-1"}
+
+	accessToken, _, err := issuer.CreateTokens(subject)
+	assert.NoError(t, err)
+
+	wrongIssuer := services.NewTokenService(signer, time.Hour, 24*time.Hour, services.TokenClaimsConfig{Issuer: "go-base-api", Audience: "clients"})
+	_, err = wrongIssuer.ValidateToken(accessToken)
+	assert.ErrorIs(t, err, jwt.ErrTokenInvalidIssuer)
+
+	wrongAudience := services.NewTokenService(signer, time.Hour, 24*time.Hour, services.TokenClaimsConfig{Issuer: "other", Audience: "admin"})
+	_, err = wrongAudience.ValidateToken(accessToken)
+	assert.ErrorIs(t, err, jwt.ErrTokenInvalidAudience)
+}
+
+func TestTokenService_LeewayToleratesClockSkew(t *testing.T) {
+	signer := services.NewHMACSigner("test-secret")
+	expired := services.NewTokenService(signer, -time.Second, time.Hour, services.TokenClaimsConfig{})
+
+	accessToken, _, err := expired.CreateTokens(services.TokenSubject{UserID: uuid.New(), TenantID: uuid.New(), FamilyID: "family-1"})
+	assert.NoError(t, err)
+
+	_, err = expired.ValidateToken(accessToken)
+	assert.ErrorIs(t, err, jwt.ErrTokenExpired)
+
+	tolerant := services.NewTokenService(signer, time.Hour, time.Hour, services.TokenClaimsConfig{Leeway: time.Minute})
+	_, err = tolerant.ValidateToken(accessToken)
+	assert.NoError(t, err)
+}
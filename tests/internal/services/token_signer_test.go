@@ -0,0 +1,131 @@
+// tests/internal/services/token_signer_test.go
+
+package services_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePrivateKeyPEM(t *testing.T, dir, name string, key interface{}) string {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+	return path
+}
+
+func TestTokenSigner_RSASignsWithKid(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	path := writePrivateKeyPEM(t, t.TempDir(), "rsa.pem", rsaKey)
+
+	signer, err := services.NewTokenSignerFromFiles("", path, "rsa-2024", "")
+	require.NoError(t, err)
+	service := services.NewTokenService(signer, time.Hour, 24*time.Hour, services.TokenClaimsConfig{})
+
+	accessToken, _, err := service.CreateTokens(services.TokenSubject{UserID: uuid.New(), FamilyID: "family-1"})
+	require.NoError(t, err)
+
+	token, err := service.ValidateToken(accessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "rsa-2024", token.Header["kid"])
+	assert.Equal(t, "RS256", token.Header["alg"])
+
+	jwks := service.JWKS()
+	require.Len(t, jwks.Keys, 1)
+	assert.Equal(t, "RSA", jwks.Keys[0].Kty)
+	assert.Equal(t, "rsa-2024", jwks.Keys[0].Kid)
+	assert.NotEmpty(t, jwks.Keys[0].N)
+	assert.Equal(t, "AQAB", jwks.Keys[0].E)
+}
+
+func TestTokenSigner_RotationKeepsOldKeyForVerification(t *testing.T) {
+	dir := t.TempDir()
+	_, oldKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, newKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	oldPath := writePrivateKeyPEM(t, dir, "old.pem", oldKey)
+	newPath := writePrivateKeyPEM(t, dir, "new.pem", newKey)
+
+	oldSigner, err := services.NewTokenSignerFromFiles("", oldPath, "ed-1", "")
+	require.NoError(t, err)
+	oldToken, _, err := services.NewTokenService(oldSigner, time.Hour, time.Hour, services.TokenClaimsConfig{}).CreateTokens(services.TokenSubject{UserID: uuid.New(), FamilyID: "f"})
+	require.NoError(t, err)
+
+	rotated, err := services.NewTokenSignerFromFiles("", newPath, "ed-2", "ed-1="+oldPath)
+	require.NoError(t, err)
+	service := services.NewTokenService(rotated, time.Hour, time.Hour, services.TokenClaimsConfig{})
+
+	_, err = service.ValidateToken(oldToken)
+	assert.NoError(t, err, "tokens assinados com a chave anterior continuam válidos")
+
+	newToken, _, err := service.CreateTokens(services.TokenSubject{UserID: uuid.New(), FamilyID: "f"})
+	require.NoError(t, err)
+	parsed, err := service.ValidateToken(newToken)
+	require.NoError(t, err)
+	assert.Equal(t, "ed-2", parsed.Header["kid"])
+
+	jwks := service.JWKS()
+	assert.Len(t, jwks.Keys, 2)
+	for _, key := range jwks.Keys {
+		assert.Equal(t, "OKP", key.Kty)
+		assert.Equal(t, "Ed25519", key.Crv)
+	}
+}
+
+func TestTokenSigner_RejectsUnknownKidAndAlgorithmConfusion(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	path := writePrivateKeyPEM(t, t.TempDir(), "ed.pem", edKey)
+	signer, err := services.NewTokenSignerFromFiles("", path, "ed-1", "")
+	require.NoError(t, err)
+	service := services.NewTokenService(signer, time.Hour, time.Hour, services.TokenClaimsConfig{})
+
+	// Token HS256 com kid desconhecido
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": uuid.NewString()})
+	forged.Header["kid"] = "other"
+	forgedString, err := forged.SignedString([]byte("secret"))
+	require.NoError(t, err)
+	_, err = service.ValidateToken(forgedString)
+	assert.Error(t, err)
+
+	// Token HS256 usando o kid da chave Ed25519
+	forged.Header["kid"] = "ed-1"
+	forgedString, err = forged.SignedString([]byte("secret"))
+	require.NoError(t, err)
+	_, err = service.ValidateToken(forgedString)
+	assert.Error(t, err)
+}
+
+func TestTokenSigner_HMACIsNotPublished(t *testing.T) {
+	service := services.NewTokenService(services.NewHMACSigner("secret"), time.Hour, time.Hour, services.TokenClaimsConfig{})
+
+	assert.Empty(t, service.JWKS().Keys)
+}
+
+func TestTokenSigner_InvalidVerificationKeyFormat(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	path := writePrivateKeyPEM(t, t.TempDir(), "ed.pem", edKey)
+
+	_, err = services.NewTokenSignerFromFiles("", path, "ed-1", strings.TrimSuffix(path, ".pem"))
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,65 @@
+// tests/internal/services/totp_test.go
+
+package services_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+	"github.com/stretchr/testify/assert"
+)
+
+// Segredo ASCII "12345678901234567890" da RFC 6238 em base32.
+const rfcTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode_RFC6238Vectors(t *testing.T) {
+	// Vetores SHA1 do apêndice B da RFC 6238, truncados para 6 dígitos.
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+
+	for unix, expected := range vectors {
+		code, err := services.TOTPCode(rfcTOTPSecret, services.TOTPStep(time.Unix(unix, 0)))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, code, "T=%d", unix)
+	}
+}
+
+func TestValidateTOTP_Skew(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+	step := services.TOTPStep(now)
+
+	previous, _ := services.TOTPCode(rfcTOTPSecret, step-1)
+	got, ok := services.ValidateTOTP(rfcTOTPSecret, previous, now)
+	assert.True(t, ok)
+	assert.Equal(t, step-1, got)
+
+	tooOld, _ := services.TOTPCode(rfcTOTPSecret, step-2)
+	_, ok = services.ValidateTOTP(rfcTOTPSecret, tooOld, now)
+	assert.False(t, ok)
+
+	_, ok = services.ValidateTOTP(rfcTOTPSecret, "12345", now)
+	assert.False(t, ok)
+}
+
+func TestGenerateTOTPSecretAndProvisioningURI(t *testing.T) {
+	secret, err := services.GenerateTOTPSecret()
+	assert.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	uri := services.TOTPProvisioningURI("Go Base API", "john@example.com", secret)
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Go%20Base%20API:john@example.com?"))
+
+	parsed, err := url.Parse(uri)
+	assert.NoError(t, err)
+	assert.Equal(t, secret, parsed.Query().Get("secret"))
+	assert.Equal(t, "Go Base API", parsed.Query().Get("issuer"))
+}
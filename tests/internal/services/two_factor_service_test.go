@@ -0,0 +1,221 @@
+// tests/internal/services/two_factor_service_test.go
+
+package services_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	autherrors "github.com/jeancarlosdanese/go-base-api/internal/domain/auth_errors"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/enums"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+	"github.com/jeancarlosdanese/go-base-api/tests/mocks"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func currentTOTP(t *testing.T, secret string) string {
+	code, err := services.TOTPCode(secret, services.TOTPStep(time.Now()))
+	assert.NoError(t, err)
+	return code
+}
+
+func TestTwoFactorService_BeginEnrollment(t *testing.T) {
+	repo := new(MockUserRepository)
+	service := services.NewTwoFactorService(repo, mocks.NewRedisService(t), "Go Base API", 5*time.Minute)
+
+	c, _ := gin.CreateTestContext(nil)
+	user := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, Email: "john@example.com"}
+	repo.On("GetOnlyByID", c, user.ID).Return(user, nil)
+	repo.On("UpdateTOTP", c, user.ID, mock.AnythingOfType("*string"), false).Return(nil)
+
+	enrollment, err := service.BeginEnrollment(c, user.ID)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, enrollment.Secret)
+	assert.Contains(t, enrollment.ProvisioningURI, "secret="+enrollment.Secret)
+	repo.AssertExpectations(t)
+}
+
+func TestTwoFactorService_BeginEnrollmentAlreadyEnabled(t *testing.T) {
+	repo := new(MockUserRepository)
+	service := services.NewTwoFactorService(repo, mocks.NewRedisService(t), "Go Base API", 5*time.Minute)
+
+	c, _ := gin.CreateTestContext(nil)
+	user := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, TOTPEnabled: true}
+	repo.On("GetOnlyByID", c, user.ID).Return(user, nil)
+
+	_, err := service.BeginEnrollment(c, user.ID)
+
+	assert.ErrorIs(t, err, services.ErrTwoFactorAlreadyEnabled)
+}
+
+func TestTwoFactorService_ConfirmEnrollment(t *testing.T) {
+	repo := new(MockUserRepository)
+	redisService := mocks.NewRedisService(t)
+	service := services.NewTwoFactorService(repo, redisService, "Go Base API", 5*time.Minute)
+
+	c, _ := gin.CreateTestContext(nil)
+	secret := rfcTOTPSecret
+	user := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, TOTPSecret: &secret}
+	repo.On("GetOnlyByID", c, user.ID).Return(user, nil)
+	repo.On("UpdateTOTP", c, user.ID, &secret, true).Return(nil)
+	repo.On("ReplaceRecoveryCodes", c, user.ID, mock.MatchedBy(func(hashes []string) bool { return len(hashes) == 10 })).Return(nil)
+	redisService.On("SetNX", mock.MatchedBy(func(key string) bool { return strings.HasPrefix(key, "totp_used:"+user.ID.String()) }), 1, mock.Anything).Return(true, nil)
+
+	codes, err := service.ConfirmEnrollment(c, user.ID, currentTOTP(t, secret))
+
+	assert.NoError(t, err)
+	assert.Len(t, codes, 10)
+	assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, codes[0])
+	repo.AssertExpectations(t)
+}
+
+func TestTwoFactorService_ConfirmEnrollmentReusedCode(t *testing.T) {
+	repo := new(MockUserRepository)
+	redisService := mocks.NewRedisService(t)
+	service := services.NewTwoFactorService(repo, redisService, "Go Base API", 5*time.Minute)
+
+	c, _ := gin.CreateTestContext(nil)
+	secret := rfcTOTPSecret
+	user := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, TOTPSecret: &secret}
+	repo.On("GetOnlyByID", c, user.ID).Return(user, nil)
+	redisService.On("SetNX", mock.Anything, 1, mock.Anything).Return(false, nil)
+
+	_, err := service.ConfirmEnrollment(c, user.ID, currentTOTP(t, secret))
+
+	assert.ErrorIs(t, err, services.ErrInvalidTwoFactorCode)
+	repo.AssertNotCalled(t, "UpdateTOTP", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTwoFactorService_CompleteChallengeWithRecoveryCode(t *testing.T) {
+	repo := new(MockUserRepository)
+	redisService := mocks.NewRedisService(t)
+	service := services.NewTwoFactorService(repo, redisService, "Go Base API", 5*time.Minute)
+
+	c, _ := gin.CreateTestContext(nil)
+	secret := rfcTOTPSecret
+	user := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, TOTPSecret: &secret, TOTPEnabled: true}
+
+	var stored string
+	redisService.On("Set", mock.MatchedBy(func(key string) bool { return strings.HasPrefix(key, "2fa_challenge:") }), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { stored = string(args.Get(1).([]byte)) }).Return(nil)
+
+	challenge, err := service.CreateChallenge(user, "http://localhost")
+	assert.NoError(t, err)
+	assert.True(t, challenge.TwoFactorRequired)
+	assert.Equal(t, 300, challenge.ExpiresIn)
+
+	key := "2fa_challenge:" + sha256Hex(challenge.ChallengeToken)
+	redisService.On("Get", key).Return(stored, nil)
+	redisService.On("Incr", "2fa_challenge_attempts:"+sha256Hex(challenge.ChallengeToken), 5*time.Minute).Return(int64(1), nil)
+	redisService.On("GetDel", key).Return(stored, nil)
+	repo.On("FindByIDWithPolicies", c, user.ID).Return(user, nil)
+	repo.On("UseRecoveryCode", c, user.ID, sha256Hex("abcdeffghij")).Return(true, nil)
+
+	got, err := service.CompleteChallenge(c, challenge.ChallengeToken, "ABCDE-FFGHIJ", "http://localhost")
+
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID, got.ID)
+}
+
+func TestTwoFactorService_CompleteChallengeInvalid(t *testing.T) {
+	repo := new(MockUserRepository)
+	redisService := mocks.NewRedisService(t)
+	service := services.NewTwoFactorService(repo, redisService, "Go Base API", 5*time.Minute)
+
+	c, _ := gin.CreateTestContext(nil)
+	redisService.On("Get", "2fa_challenge:"+sha256Hex("unknown")).Return("", redis.Nil)
+
+	_, err := service.CompleteChallenge(c, "unknown", "123456", "http://localhost")
+
+	assert.ErrorIs(t, err, services.ErrTwoFactorChallengeInvalid)
+}
+
+// challengeState é o desafio guardado no Redis para o usuário.
+func challengeState(user *models.User) string {
+	state, _ := json.Marshal(map[string]interface{}{
+		"user_id":    user.ID.String(),
+		"origin":     "http://localhost",
+		"expires_at": time.Now().Add(time.Minute),
+	})
+	return string(state)
+}
+
+func TestTwoFactorService_CompleteChallengeWrongCodeCountsAttempt(t *testing.T) {
+	repo := new(MockUserRepository)
+	redisService := mocks.NewRedisService(t)
+	service := services.NewTwoFactorService(repo, redisService, "Go Base API", 5*time.Minute)
+
+	c, _ := gin.CreateTestContext(nil)
+	secret := rfcTOTPSecret
+	user := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, TOTPSecret: &secret, TOTPEnabled: true}
+	key := "2fa_challenge:" + sha256Hex("token")
+	attemptsKey := "2fa_challenge_attempts:" + sha256Hex("token")
+
+	redisService.On("Get", key).Return(challengeState(user), nil)
+	repo.On("FindByIDWithPolicies", c, user.ID).Return(user, nil)
+
+	t.Run("tentativa abaixo do limite mantém o desafio", func(t *testing.T) {
+		redisService.On("Incr", attemptsKey, 5*time.Minute).Return(int64(1), nil).Once()
+
+		got, err := service.CompleteChallenge(c, "token", "abcdef", "http://localhost")
+
+		assert.ErrorIs(t, err, services.ErrInvalidTwoFactorCode)
+		// O usuário volta com o erro, para a falha ser contada na conta
+		assert.Equal(t, user.ID, got.ID)
+		redisService.AssertNotCalled(t, "Delete", key, attemptsKey)
+	})
+
+	t.Run("última tentativa descarta o desafio", func(t *testing.T) {
+		redisService.On("Incr", attemptsKey, 5*time.Minute).Return(int64(5), nil).Once()
+		redisService.On("Delete", key, attemptsKey).Return(nil).Once()
+
+		_, err := service.CompleteChallenge(c, "token", "abcdef", "http://localhost")
+
+		assert.ErrorIs(t, err, services.ErrInvalidTwoFactorCode)
+	})
+
+	t.Run("tentativas simultâneas além do limite não verificam o código", func(t *testing.T) {
+		redisService.On("Incr", attemptsKey, 5*time.Minute).Return(int64(6), nil).Once()
+		redisService.On("Delete", key, attemptsKey).Return(nil).Once()
+
+		_, err := service.CompleteChallenge(c, "token", currentTOTP(t, secret), "http://localhost")
+
+		assert.ErrorIs(t, err, services.ErrTwoFactorChallengeInvalid)
+		redisService.AssertNotCalled(t, "GetDel", key)
+	})
+}
+
+func TestTwoFactorService_CompleteChallengeSuspendedTenant(t *testing.T) {
+	repo := new(MockUserRepository)
+	redisService := mocks.NewRedisService(t)
+	service := services.NewTwoFactorService(repo, redisService, "Go Base API", 5*time.Minute)
+
+	c, _ := gin.CreateTestContext(nil)
+	secret := rfcTOTPSecret
+	user := &models.User{
+		BaseModel:   models.BaseModel{ID: uuid.New()},
+		TOTPSecret:  &secret,
+		TOTPEnabled: true,
+		Tenant:      &models.Tenant{Status: enums.Inativo},
+	}
+	key := "2fa_challenge:" + sha256Hex("token")
+	attemptsKey := "2fa_challenge_attempts:" + sha256Hex("token")
+
+	redisService.On("Get", key).Return(challengeState(user), nil)
+	redisService.On("Incr", attemptsKey, 5*time.Minute).Return(int64(1), nil)
+	repo.On("FindByIDWithPolicies", c, user.ID).Return(user, nil)
+	redisService.On("Delete", key, attemptsKey).Return(nil)
+
+	_, err := service.CompleteChallenge(c, "token", currentTOTP(t, secret), "http://localhost")
+
+	assert.ErrorIs(t, err, autherrors.ErrTenantSuspended)
+	repo.AssertNotCalled(t, "UseRecoveryCode", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -3,14 +3,21 @@
 package services_test
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	autherrors "github.com/jeancarlosdanese/go-base-api/internal/domain/auth_errors"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/enums"
 	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
 	"github.com/jeancarlosdanese/go-base-api/internal/services"
+	"github.com/jeancarlosdanese/go-base-api/tests/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
 // MockUserRepository é um repositório mock para testes
@@ -38,9 +45,12 @@ func (m *MockUserRepository) Delete(c *gin.Context, id uuid.UUID) error {
 	return args.Error(0)
 }
 
-func (m *MockUserRepository) GetAll(c *gin.Context) ([]models.User, error) {
-	args := m.Called(c)
-	return args.Get(0).([]models.User), args.Error(1)
+func (m *MockUserRepository) GetAll(c *gin.Context, query models.ListQuery) (*models.Page[models.User], error) {
+	args := m.Called(c, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Page[models.User]), args.Error(1)
 }
 
 func (m *MockUserRepository) GetByID(c *gin.Context, id uuid.UUID) (*models.User, error) {
@@ -48,6 +58,35 @@ func (m *MockUserRepository) GetByID(c *gin.Context, id uuid.UUID) (*models.User
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
+func (m *MockUserRepository) SearchUsers(c *gin.Context, term string, limit int) ([]models.User, error) {
+	args := m.Called(c, term, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) FindDeletedUsers(c *gin.Context, query models.ListQuery) (*models.Page[models.User], error) {
+	args := m.Called(c, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Page[models.User]), args.Error(1)
+}
+
+func (m *MockUserRepository) RestoreUser(c *gin.Context, id uuid.UUID) (*models.User, error) {
+	args := m.Called(c, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) PurgeUser(c *gin.Context, id uuid.UUID) error {
+	args := m.Called(c, id)
+	return args.Error(0)
+}
+
 func (m *MockUserRepository) FindByEmail(c *gin.Context, email, origin string) (*models.User, error) {
 	args := m.Called(c, email, origin)
 	return args.Get(0).(*models.User), args.Error(1)
@@ -58,9 +97,52 @@ func (m *MockUserRepository) GetOnlyByID(c *gin.Context, id uuid.UUID) (*models.
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
+func (m *MockUserRepository) UpdatePassword(c *gin.Context, id uuid.UUID, hashedPassword string) error {
+	args := m.Called(c, id, hashedPassword)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) FindByIDWithPolicies(c *gin.Context, id uuid.UUID) (*models.User, error) {
+	args := m.Called(c, id)
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateTOTP(c *gin.Context, id uuid.UUID, secret *string, enabled bool) error {
+	args := m.Called(c, id, secret, enabled)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ReplaceRecoveryCodes(c *gin.Context, userID uuid.UUID, codeHashes []string) error {
+	args := m.Called(c, userID, codeHashes)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UseRecoveryCode(c *gin.Context, userID uuid.UUID, codeHash string) (bool, error) {
+	args := m.Called(c, userID, codeHash)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUserRepository) FindUserIDsByTenant(c *gin.Context, tenantID uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(c, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateUserStatus(c *gin.Context, id uuid.UUID, status enums.UserStatus) error {
+	args := m.Called(c, id, status)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) MarkEmailVerified(c *gin.Context, id uuid.UUID, email string) error {
+	args := m.Called(c, id, email)
+	return args.Error(0)
+}
+
 func TestUserService_Create(t *testing.T) {
 	repo := new(MockUserRepository)
-	service := services.NewUserService(repo)
+	service := services.NewUserService(repo, services.NewBcryptPasswordHasher(bcrypt.MinCost), nil, mocks.NewCasbinService(t), mocks.NewTokenRedisService(t))
 
 	c := &gin.Context{}
 
@@ -96,7 +178,7 @@ func TestUserService_Create(t *testing.T) {
 
 func TestUserService_Update(t *testing.T) {
 	repo := new(MockUserRepository)
-	service := services.NewUserService(repo)
+	service := services.NewUserService(repo, services.NewBcryptPasswordHasher(bcrypt.MinCost), nil, mocks.NewCasbinService(t), mocks.NewTokenRedisService(t))
 
 	c := &gin.Context{}
 
@@ -123,7 +205,7 @@ func TestUserService_Update(t *testing.T) {
 
 func TestUserService_UpdatePartial(t *testing.T) {
 	repo := new(MockUserRepository)
-	service := services.NewUserService(repo)
+	service := services.NewUserService(repo, services.NewBcryptPasswordHasher(bcrypt.MinCost), nil, mocks.NewCasbinService(t), mocks.NewTokenRedisService(t))
 
 	c := &gin.Context{}
 
@@ -149,7 +231,7 @@ func TestUserService_UpdatePartial(t *testing.T) {
 
 func TestUserService_Delete(t *testing.T) {
 	repo := new(MockUserRepository)
-	service := services.NewUserService(repo)
+	service := services.NewUserService(repo, services.NewBcryptPasswordHasher(bcrypt.MinCost), nil, mocks.NewCasbinService(t), mocks.NewTokenRedisService(t))
 
 	c := &gin.Context{}
 
@@ -163,9 +245,49 @@ func TestUserService_Delete(t *testing.T) {
 	repo.AssertCalled(t, "Delete", c, userID)
 }
 
+func TestUserService_Restore(t *testing.T) {
+	c := &gin.Context{}
+	userID := uuid.New()
+
+	repo := new(MockUserRepository)
+	service := services.NewUserService(repo, services.NewBcryptPasswordHasher(bcrypt.MinCost), nil, mocks.NewCasbinService(t), mocks.NewTokenRedisService(t))
+
+	repo.On("RestoreUser", c, userID).Return(&models.User{BaseModel: models.BaseModel{ID: userID}}, nil).Once()
+	restored, err := service.Restore(c, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, userID, restored.ID)
+
+	repo.On("RestoreUser", c, userID).Return(nil, gorm.ErrDuplicatedKey).Once()
+	_, err = service.Restore(c, userID)
+	assert.ErrorIs(t, err, services.ErrRestoreConflict)
+
+	repo.On("RestoreUser", c, userID).Return(nil, gorm.ErrRecordNotFound).Once()
+	_, err = service.Restore(c, userID)
+	assert.ErrorIs(t, err, services.ErrNotFound)
+}
+
+func TestUserService_Purge(t *testing.T) {
+	c := &gin.Context{}
+	userID := uuid.New()
+
+	repo := new(MockUserRepository)
+	casbinService := mocks.NewCasbinService(t)
+	tokenRedisService := mocks.NewTokenRedisService(t)
+	service := services.NewUserService(repo, services.NewBcryptPasswordHasher(bcrypt.MinCost), nil, casbinService, tokenRedisService)
+
+	// Após a remoção, as sessões são revogadas e as políticas do usuário saem do Casbin
+	repo.On("PurgeUser", c, userID).Return(nil).Once()
+	tokenRedisService.On("RevokeAllSessions", userID.String()).Return(nil).Once()
+	casbinService.On("LoadPolicy").Return(nil).Once()
+	assert.NoError(t, service.Purge(c, userID))
+
+	repo.On("PurgeUser", c, userID).Return(gorm.ErrRecordNotFound).Once()
+	assert.ErrorIs(t, service.Purge(c, userID), services.ErrNotFound)
+}
+
 func TestUserService_GetAll(t *testing.T) {
 	repo := new(MockUserRepository)
-	service := services.NewUserService(repo)
+	service := services.NewUserService(repo, services.NewBcryptPasswordHasher(bcrypt.MinCost), nil, mocks.NewCasbinService(t), mocks.NewTokenRedisService(t))
 
 	c := &gin.Context{}
 
@@ -179,20 +301,22 @@ func TestUserService_GetAll(t *testing.T) {
 			Username:  "user2",
 		},
 	}
-	repo.On("GetAll", c).Return(users, nil)
+	query := models.ListQuery{Sort: []models.ListSort{{Column: "name"}}, Limit: 50}
+	repo.On("GetAll", c, query).Return(&models.Page[models.User]{Items: users, Total: 2}, nil)
 
-	allUsers, err := service.GetAll(c)
+	page, err := service.GetAll(c, query)
 
 	assert.NoError(t, err)
-	assert.Len(t, allUsers, 2)
-	assert.Equal(t, users, allUsers)
+	assert.Len(t, page.Items, 2)
+	assert.Equal(t, users, page.Items)
+	assert.Equal(t, int64(2), page.Total)
 
-	repo.AssertCalled(t, "GetAll", c)
+	repo.AssertCalled(t, "GetAll", c, query)
 }
 
 func TestUserService_GetByID(t *testing.T) {
 	repo := new(MockUserRepository)
-	service := services.NewUserService(repo)
+	service := services.NewUserService(repo, services.NewBcryptPasswordHasher(bcrypt.MinCost), nil, mocks.NewCasbinService(t), mocks.NewTokenRedisService(t))
 
 	c := &gin.Context{}
 
@@ -212,3 +336,173 @@ func TestUserService_GetByID(t *testing.T) {
 
 	repo.AssertCalled(t, "GetByID", c, userID)
 }
+
+func TestUserService_UpdatePassword(t *testing.T) {
+	repo := new(MockUserRepository)
+	service := services.NewUserService(repo, services.NewBcryptPasswordHasher(bcrypt.MinCost), nil, mocks.NewCasbinService(t), mocks.NewTokenRedisService(t))
+
+	c := &gin.Context{}
+
+	userID := uuid.New()
+	repo.On("GetOnlyByID", c, userID).Return(&models.User{BaseModel: models.BaseModel{ID: userID}}, nil)
+	repo.On("UpdatePassword", c, userID, mock.MatchedBy(func(hash string) bool {
+		return bcrypt.CompareHashAndPassword([]byte(hash), []byte("nova-senha-123")) == nil
+	})).Return(nil)
+
+	err := service.UpdatePassword(c, userID, "nova-senha-123")
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestUserService_ChangePassword(t *testing.T) {
+	repo := new(MockUserRepository)
+	service := services.NewUserService(repo, services.NewBcryptPasswordHasher(bcrypt.MinCost), nil, mocks.NewCasbinService(t), mocks.NewTokenRedisService(t))
+
+	c := &gin.Context{}
+
+	userID := uuid.New()
+	hash, _ := bcrypt.GenerateFromPassword([]byte("senha-atual"), bcrypt.MinCost)
+	repo.On("GetOnlyByID", c, userID).Return(&models.User{BaseModel: models.BaseModel{ID: userID}, Password: string(hash)}, nil)
+
+	err := service.ChangePassword(c, userID, "senha-errada", "nova-senha-123")
+	assert.ErrorIs(t, err, services.ErrInvalidCurrentPassword)
+	repo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+
+	repo.On("UpdatePassword", c, userID, mock.AnythingOfType("string")).Return(nil)
+	err = service.ChangePassword(c, userID, "senha-atual", "nova-senha-123")
+	assert.NoError(t, err)
+}
+
+func TestUserService_CreateRejectedByPasswordPolicy(t *testing.T) {
+	repo := new(MockUserRepository)
+	policy := services.NewPasswordPolicy(services.PasswordPolicyConfig{MinLength: 8, RequireUpper: true, RequireLower: true, RequireDigit: true}, nil)
+	service := services.NewUserService(repo, services.NewBcryptPasswordHasher(bcrypt.MinCost), policy, mocks.NewCasbinService(t), mocks.NewTokenRedisService(t))
+
+	c := &gin.Context{}
+
+	_, err := service.CreateUserWithPassword(c, &models.UserCreate{
+		Username: "testuser",
+		Email:    "testuser@example.com",
+		Password: "",
+	})
+
+	var policyErr *services.PasswordPolicyError
+	assert.True(t, errors.As(err, &policyErr))
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestUserService_ChangePasswordRejectedByPasswordPolicy(t *testing.T) {
+	repo := new(MockUserRepository)
+	policy := services.NewPasswordPolicy(services.PasswordPolicyConfig{MinLength: 8}, nil)
+	service := services.NewUserService(repo, services.NewBcryptPasswordHasher(bcrypt.MinCost), policy, mocks.NewCasbinService(t), mocks.NewTokenRedisService(t))
+
+	c := &gin.Context{}
+
+	userID := uuid.New()
+	hash, _ := bcrypt.GenerateFromPassword([]byte("senha-atual"), bcrypt.MinCost)
+	repo.On("GetOnlyByID", c, userID).Return(&models.User{BaseModel: models.BaseModel{ID: userID}, Username: "johndoe", Password: string(hash)}, nil)
+
+	err := service.ChangePassword(c, userID, "senha-atual", "JohnDoe2024!")
+
+	var policyErr *services.PasswordPolicyError
+	assert.True(t, errors.As(err, &policyErr))
+	assert.Equal(t, services.PasswordViolationContainsUsername, policyErr.Violations[0].Code)
+	repo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUserService_AuthenticateUpgradesLegacyHash(t *testing.T) {
+	repo := new(MockUserRepository)
+	hasher := services.NewPasswordHasher(services.PasswordHasherConfig{
+		Algorithm: services.PasswordAlgorithmArgon2id,
+		Argon2id:  testArgon2idParams,
+	})
+	service := services.NewUserService(repo, hasher, nil, mocks.NewCasbinService(t), mocks.NewTokenRedisService(t))
+
+	c := &gin.Context{}
+
+	legacy, _ := bcrypt.GenerateFromPassword([]byte("master123"), bcrypt.MinCost)
+	user := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, Email: "master@domain.local", Password: string(legacy)}
+	repo.On("FindByEmail", c, "master@domain.local", "localhost").Return(user, nil)
+	repo.On("UpdatePassword", c, user.ID, mock.MatchedBy(func(hash string) bool {
+		return strings.HasPrefix(hash, "$argon2id$")
+	})).Return(nil)
+
+	authenticated, err := service.Authenticate(c, "master@domain.local", "master123", "localhost")
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(authenticated.Password, "$argon2id$"))
+	repo.AssertExpectations(t)
+}
+
+func TestUserService_AuthenticateInvalidPasswordDoesNotRehash(t *testing.T) {
+	repo := new(MockUserRepository)
+	service := services.NewUserService(repo, services.NewBcryptPasswordHasher(bcrypt.MinCost+1), nil, mocks.NewCasbinService(t), mocks.NewTokenRedisService(t))
+
+	c := &gin.Context{}
+
+	legacy, _ := bcrypt.GenerateFromPassword([]byte("master123"), bcrypt.MinCost)
+	user := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, Password: string(legacy)}
+	repo.On("FindByEmail", c, "master@domain.local", "localhost").Return(user, nil)
+
+	_, err := service.Authenticate(c, "master@domain.local", "errada", "localhost")
+
+	assert.ErrorIs(t, err, autherrors.ErrInvalidPassword)
+	repo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUserService_AuthenticateSuspendedTenant(t *testing.T) {
+	repo := new(MockUserRepository)
+	service := services.NewUserService(repo, services.NewBcryptPasswordHasher(bcrypt.MinCost), nil, mocks.NewCasbinService(t), mocks.NewTokenRedisService(t))
+
+	c := &gin.Context{}
+
+	hash, _ := bcrypt.GenerateFromPassword([]byte("master123"), bcrypt.MinCost)
+	user := &models.User{
+		BaseModel: models.BaseModel{ID: uuid.New()},
+		Password:  string(hash),
+		Tenant:    &models.Tenant{Status: enums.Inativo},
+	}
+	repo.On("FindByEmail", c, "master@domain.local", "localhost").Return(user, nil)
+
+	t.Run("Senha correta revela a suspensão", func(t *testing.T) {
+		_, err := service.Authenticate(c, "master@domain.local", "master123", "localhost")
+		assert.ErrorIs(t, err, autherrors.ErrTenantSuspended)
+	})
+
+	t.Run("Senha errada não revela o status do tenant", func(t *testing.T) {
+		_, err := service.Authenticate(c, "master@domain.local", "errada", "localhost")
+		assert.ErrorIs(t, err, autherrors.ErrInvalidPassword)
+	})
+}
+
+func TestUserService_AuthenticateUserStatus(t *testing.T) {
+	repo := new(MockUserRepository)
+	service := services.NewUserService(repo, services.NewBcryptPasswordHasher(bcrypt.MinCost), nil, mocks.NewCasbinService(t), mocks.NewTokenRedisService(t))
+
+	c := &gin.Context{}
+
+	hash, _ := bcrypt.GenerateFromPassword([]byte("master123"), bcrypt.MinCost)
+	cases := map[enums.UserStatus]error{
+		enums.UserPendente:   autherrors.ErrUserPending,
+		enums.UserDesativado: autherrors.ErrUserDisabled,
+		enums.UserAtivo:      nil,
+	}
+	for status, expected := range cases {
+		email := strings.ToLower(string(status)) + "@domain.local"
+		user := &models.User{
+			BaseModel: models.BaseModel{ID: uuid.New()},
+			Password:  string(hash),
+			Status:    status,
+			Tenant:    &models.Tenant{Status: enums.Ativo},
+		}
+		repo.On("FindByEmail", c, email, "localhost").Return(user, nil)
+
+		_, err := service.Authenticate(c, email, "master123", "localhost")
+		if expected == nil {
+			assert.NoError(t, err, status)
+		} else {
+			assert.ErrorIs(t, err, expected, status)
+		}
+	}
+}
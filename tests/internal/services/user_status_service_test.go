@@ -0,0 +1,171 @@
+// tests/internal/services/user_status_service_test.go
+
+package services_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/enums"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/jeancarlosdanese/go-base-api/internal/services"
+	"github.com/jeancarlosdanese/go-base-api/tests/mocks"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestUserStatusService_SendVerificationEmail(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	redisService := mocks.NewRedisService(t)
+	mail := &capturingMailer{}
+	service := services.NewUserStatusService(userRepo, redisService, mocks.NewTokenRedisService(t), mail, 48*time.Hour, "https://app.example.com/verify")
+
+	c, _ := gin.CreateTestContext(nil)
+	user := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, Name: "John", Email: "john@example.com", Status: enums.UserPendente}
+	userKey := "email_verification_user:" + user.ID.String()
+
+	var storedHash string
+	redisService.On("GetDel", userKey).Return("old-hash", nil)
+	redisService.On("Delete", "email_verification:old-hash").Return(nil)
+	redisService.On("Set", mock.MatchedBy(func(key string) bool {
+		if strings.HasPrefix(key, "email_verification:") {
+			storedHash = strings.TrimPrefix(key, "email_verification:")
+			return true
+		}
+		return false
+	}), user.ID.String()+":john@example.com", 48*time.Hour).Return(nil)
+	redisService.On("Set", userKey, mock.Anything, 48*time.Hour).Return(nil)
+
+	err := service.SendVerificationEmail(c, user)
+
+	assert.NoError(t, err)
+	assert.Len(t, mail.messages, 1)
+	assert.Equal(t, "john@example.com", mail.messages[0].To)
+
+	// O e-mail leva o token em claro; no Redis fica apenas o hash
+	body := mail.messages[0].Body
+	start := strings.Index(body, "?token=") + len("?token=")
+	token := strings.Fields(body[start:])[0]
+	assert.Equal(t, sha256Hex(token), storedHash)
+}
+
+func TestUserStatusService_SendVerificationEmailAlreadyVerified(t *testing.T) {
+	mail := &capturingMailer{}
+	service := services.NewUserStatusService(new(mocks.MockUserRepository), mocks.NewRedisService(t), mocks.NewTokenRedisService(t), mail, 48*time.Hour, "")
+
+	c, _ := gin.CreateTestContext(nil)
+	verifiedAt := time.Now()
+	user := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, EmailVerifiedAt: &verifiedAt}
+
+	err := service.SendVerificationEmail(c, user)
+
+	assert.ErrorIs(t, err, services.ErrEmailAlreadyVerified)
+	assert.Empty(t, mail.messages)
+}
+
+func TestUserStatusService_VerifyEmail(t *testing.T) {
+	c, _ := gin.CreateTestContext(nil)
+	userID := uuid.New()
+	tokenKey := "email_verification:" + sha256Hex("valid-token")
+
+	t.Run("token válido ativa o usuário", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		redisService := mocks.NewRedisService(t)
+		service := services.NewUserStatusService(userRepo, redisService, mocks.NewTokenRedisService(t), &capturingMailer{}, 48*time.Hour, "")
+
+		redisService.On("GetDel", tokenKey).Return(userID.String()+":john@example.com", nil)
+		userRepo.On("MarkEmailVerified", c, userID, "john@example.com").Return(nil)
+		redisService.On("Delete", "email_verification_user:"+userID.String()).Return(nil)
+
+		assert.NoError(t, service.VerifyEmail(c, "valid-token"))
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("token de um e-mail que o usuário não usa mais", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		redisService := mocks.NewRedisService(t)
+		service := services.NewUserStatusService(userRepo, redisService, mocks.NewTokenRedisService(t), &capturingMailer{}, 48*time.Hour, "")
+
+		redisService.On("GetDel", tokenKey).Return(userID.String()+":antigo@example.com", nil)
+		userRepo.On("MarkEmailVerified", c, userID, "antigo@example.com").Return(gorm.ErrRecordNotFound)
+
+		assert.ErrorIs(t, service.VerifyEmail(c, "valid-token"), services.ErrEmailVerificationTokenInvalid)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("token já usado ou expirado", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		redisService := mocks.NewRedisService(t)
+		service := services.NewUserStatusService(userRepo, redisService, mocks.NewTokenRedisService(t), &capturingMailer{}, 48*time.Hour, "")
+
+		redisService.On("GetDel", tokenKey).Return("", redis.Nil)
+
+		assert.ErrorIs(t, service.VerifyEmail(c, "valid-token"), services.ErrEmailVerificationTokenInvalid)
+		userRepo.AssertNotCalled(t, "MarkEmailVerified", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserStatusService_Disable(t *testing.T) {
+	c, _ := gin.CreateTestContext(nil)
+
+	t.Run("desativa e revoga as sessões", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		tokenRedisService := mocks.NewTokenRedisService(t)
+		service := services.NewUserStatusService(userRepo, mocks.NewRedisService(t), tokenRedisService, &capturingMailer{}, 48*time.Hour, "")
+
+		user := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, Status: enums.UserAtivo}
+		userRepo.On("UpdateUserStatus", c, user.ID, enums.UserDesativado).Return(nil)
+		tokenRedisService.On("RevokeAllSessions", user.ID.String()).Return(nil)
+
+		assert.NoError(t, service.Disable(c, user))
+		assert.Equal(t, enums.UserDesativado, user.Status)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("falha no banco não revoga as sessões", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		tokenRedisService := mocks.NewTokenRedisService(t)
+		service := services.NewUserStatusService(userRepo, mocks.NewRedisService(t), tokenRedisService, &capturingMailer{}, 48*time.Hour, "")
+
+		user := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, Status: enums.UserAtivo}
+		userRepo.On("UpdateUserStatus", c, user.ID, enums.UserDesativado).Return(errors.New("db down"))
+
+		assert.Error(t, service.Disable(c, user))
+		assert.Equal(t, enums.UserAtivo, user.Status)
+		tokenRedisService.AssertNotCalled(t, "RevokeAllSessions", mock.Anything)
+	})
+}
+
+func TestUserStatusService_Enable(t *testing.T) {
+	c, _ := gin.CreateTestContext(nil)
+	userRepo := new(mocks.MockUserRepository)
+	service := services.NewUserStatusService(userRepo, mocks.NewRedisService(t), mocks.NewTokenRedisService(t), &capturingMailer{}, 48*time.Hour, "")
+
+	user := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, Status: enums.UserDesativado}
+	userRepo.On("UpdateUserStatus", c, user.ID, enums.UserAtivo).Return(nil)
+
+	assert.NoError(t, service.Enable(c, user))
+	assert.Equal(t, enums.UserAtivo, user.Status)
+	userRepo.AssertExpectations(t)
+}
+
+func TestUserStatusService_CancelEmailVerification(t *testing.T) {
+	redisService := mocks.NewRedisService(t)
+	service := services.NewUserStatusService(new(mocks.MockUserRepository), redisService, mocks.NewTokenRedisService(t), &capturingMailer{}, 48*time.Hour, "")
+	userID := uuid.New()
+	userKey := "email_verification_user:" + userID.String()
+
+	redisService.On("GetDel", userKey).Return("pending-hash", nil).Once()
+	redisService.On("Delete", "email_verification:pending-hash").Return(nil).Once()
+	assert.NoError(t, service.CancelEmailVerification(userID))
+
+	// Sem link pendente, não há o que remover
+	redisService.On("GetDel", userKey).Return("", redis.Nil).Once()
+	assert.NoError(t, service.CancelEmailVerification(userID))
+}
@@ -0,0 +1,153 @@
+// tests/internal/settings/settings_test.go
+
+package settings_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jeancarlosdanese/go-base-api/internal/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	t.Setenv(settings.ConfigFileEnv, "")
+	t.Setenv("JWT_SECRET_KEY", "secret")
+
+	cfg, err := settings.Load()
+
+	require.NoError(t, err)
+	assert.Equal(t, "0.0.0.0:5001", cfg.Server.Addr)
+	assert.Equal(t, "America/Sao_Paulo", cfg.Database.TimeZone)
+	assert.Equal(t, 10, cfg.Redis.PoolSize)
+	assert.Equal(t, time.Hour*24, cfg.Auth.AccessTokenTTL.Duration)
+	assert.Equal(t, time.Hour*24*90, cfg.Auth.RefreshTokenTTL.Duration)
+	assert.Equal(t, "Go Base API", cfg.Auth.TOTPIssuer)
+	assert.Equal(t, time.Minute*5, cfg.Auth.TwoFactorChallengeTTL.Duration)
+	assert.Equal(t, 5, cfg.Auth.Login.MaxFailures)
+	assert.Equal(t, time.Second*30, cfg.Auth.Login.MaxDelay.Duration)
+	assert.Equal(t, 8, cfg.Auth.PasswordPolicy.MinLength)
+	assert.Equal(t, "argon2id", cfg.Auth.PasswordHashAlgorithm)
+	assert.True(t, cfg.Auth.PasswordPolicy.RequireDigit)
+	assert.Equal(t, "casbin:policy_updates", cfg.Casbin.WatcherChannel)
+	assert.Equal(t, time.Minute*5, cfg.Casbin.ReloadInterval.Duration)
+}
+
+func TestLoad_YAMLFileWithEnvOverride(t *testing.T) {
+	path := writeConfigFile(t, "config.yaml", `
+server:
+  addr: ":8080"
+redis:
+  pool_size: 32
+auth:
+  access_token_ttl: 15m
+  refresh_token_ttl: 720h
+  bcrypt_cost: 12
+  jwt:
+    secret_key: from-file
+`)
+	t.Setenv(settings.ConfigFileEnv, path)
+	t.Setenv("JWT_SECRET_KEY", "")
+	t.Setenv("REDIS_POOL_SIZE", "64")
+
+	cfg, err := settings.Load()
+
+	require.NoError(t, err)
+	assert.Equal(t, ":8080", cfg.Server.Addr)
+	assert.Equal(t, 64, cfg.Redis.PoolSize)
+	assert.Equal(t, 15*time.Minute, cfg.Auth.AccessTokenTTL.Duration)
+	assert.Equal(t, 720*time.Hour, cfg.Auth.RefreshTokenTTL.Duration)
+	assert.Equal(t, 12, cfg.Auth.BcryptCost)
+	assert.Equal(t, "from-file", cfg.Auth.JWT.SecretKey)
+}
+
+func TestLoad_TOMLFile(t *testing.T) {
+	path := writeConfigFile(t, "config.toml", `
+[database]
+time_zone = "UTC"
+
+[auth]
+api_key_cache_ttl = "1h"
+
+[auth.jwt]
+secret_key = "from-file"
+leeway = "5s"
+`)
+	t.Setenv(settings.ConfigFileEnv, path)
+	t.Setenv("JWT_SECRET_KEY", "")
+
+	cfg, err := settings.Load()
+
+	require.NoError(t, err)
+	assert.Equal(t, "UTC", cfg.Database.TimeZone)
+	assert.Equal(t, time.Hour, cfg.Auth.ApiKeyCacheTTL.Duration)
+	assert.Equal(t, 5*time.Second, cfg.Auth.JWT.Leeway.Duration)
+}
+
+func TestLoad_LongMinLengthWithArgon2id(t *testing.T) {
+	t.Setenv(settings.ConfigFileEnv, "")
+	t.Setenv("JWT_SECRET_KEY", "secret")
+	t.Setenv("PASSWORD_MIN_LENGTH", "80")
+
+	cfg, err := settings.Load()
+	require.NoError(t, err)
+	assert.Equal(t, 80, cfg.Auth.PasswordPolicy.MinLength)
+}
+
+func TestLoad_InvalidValues(t *testing.T) {
+	t.Setenv(settings.ConfigFileEnv, "")
+	t.Setenv("JWT_SECRET_KEY", "secret")
+
+	t.Run("duração mal formatada", func(t *testing.T) {
+		t.Setenv("JWT_ACCESS_DURATION", "quinze minutos")
+		_, err := settings.Load()
+		assert.ErrorContains(t, err, "JWT_ACCESS_DURATION")
+	})
+
+	t.Run("refresh menor que access", func(t *testing.T) {
+		t.Setenv("JWT_ACCESS_DURATION", "2h")
+		t.Setenv("JWT_REFRESH_DURATION", "1h")
+		_, err := settings.Load()
+		assert.ErrorContains(t, err, "refresh_token_ttl")
+	})
+
+	t.Run("custo do bcrypt fora do intervalo", func(t *testing.T) {
+		t.Setenv("BCRYPT_COST", "40")
+		_, err := settings.Load()
+		assert.ErrorContains(t, err, "bcrypt_cost")
+	})
+
+	t.Run("tamanho mínimo de senha acima do limite do bcrypt", func(t *testing.T) {
+		t.Setenv("PASSWORD_HASH_ALGORITHM", "bcrypt")
+		t.Setenv("PASSWORD_MIN_LENGTH", "80")
+		_, err := settings.Load()
+		assert.ErrorContains(t, err, "min_length")
+	})
+
+	t.Run("fuso horário desconhecido", func(t *testing.T) {
+		t.Setenv("DB_TIMEZONE", "Terra/Media")
+		_, err := settings.Load()
+		assert.ErrorContains(t, err, "time_zone")
+	})
+
+	t.Run("recarga periódica do casbin negativa", func(t *testing.T) {
+		t.Setenv("CASBIN_RELOAD_INTERVAL", "-1m")
+		_, err := settings.Load()
+		assert.ErrorContains(t, err, "casbin.reload_interval")
+	})
+
+	t.Run("formato de arquivo não suportado", func(t *testing.T) {
+		t.Setenv(settings.ConfigFileEnv, writeConfigFile(t, "config.json", "{}"))
+		_, err := settings.Load()
+		assert.ErrorContains(t, err, "não suportado")
+	})
+}
@@ -0,0 +1,97 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockApiKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockApiKeyRepository) Create(c *gin.Context, entity *models.ApiKey) (*models.ApiKey, error) {
+	args := m.Called(c, entity)
+	if fn, ok := args.Get(0).(func(*gin.Context, *models.ApiKey) *models.ApiKey); ok {
+		return fn(c, entity), args.Error(1)
+	}
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ApiKey), args.Error(1)
+}
+
+func (m *MockApiKeyRepository) Update(c *gin.Context, id uuid.UUID, entity *models.ApiKey) (*models.ApiKey, error) {
+	args := m.Called(c, id, entity)
+	return args.Get(0).(*models.ApiKey), args.Error(1)
+}
+
+func (m *MockApiKeyRepository) UpdatePartial(c *gin.Context, id uuid.UUID, updateData map[string]interface{}) (*models.ApiKey, error) {
+	args := m.Called(c, id, updateData)
+	return args.Get(0).(*models.ApiKey), args.Error(1)
+}
+
+func (m *MockApiKeyRepository) Delete(c *gin.Context, id uuid.UUID) error {
+	args := m.Called(c, id)
+	return args.Error(0)
+}
+
+func (m *MockApiKeyRepository) GetAll(c *gin.Context, query models.ListQuery) (*models.Page[models.ApiKey], error) {
+	args := m.Called(c, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Page[models.ApiKey]), args.Error(1)
+}
+
+func (m *MockApiKeyRepository) GetByID(c *gin.Context, id uuid.UUID) (*models.ApiKey, error) {
+	args := m.Called(c, id)
+	return args.Get(0).(*models.ApiKey), args.Error(1)
+}
+
+func (m *MockApiKeyRepository) FindApiKeysByTenant(c *gin.Context, tenantID uuid.UUID) ([]models.ApiKey, error) {
+	args := m.Called(c, tenantID)
+	return args.Get(0).([]models.ApiKey), args.Error(1)
+}
+
+func (m *MockApiKeyRepository) FindApiKeyByTenant(c *gin.Context, tenantID, id uuid.UUID) (*models.ApiKey, error) {
+	args := m.Called(c, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ApiKey), args.Error(1)
+}
+
+func (m *MockApiKeyRepository) FindActiveApiKeyByHash(keyHash, origin string) (*models.ApiKey, error) {
+	args := m.Called(keyHash, origin)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ApiKey), args.Error(1)
+}
+
+func (m *MockApiKeyRepository) UpdateApiKey(c *gin.Context, tenantID, id uuid.UUID, updateData map[string]interface{}) (*models.ApiKey, error) {
+	args := m.Called(c, tenantID, id, updateData)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ApiKey), args.Error(1)
+}
+
+func (m *MockApiKeyRepository) RevokeApiKey(c *gin.Context, tenantID, id uuid.UUID) error {
+	args := m.Called(c, tenantID, id)
+	return args.Error(0)
+}
+
+func (m *MockApiKeyRepository) RotateApiKey(c *gin.Context, oldKey *models.ApiKey, newKey *models.ApiKey, oldValidUntil time.Time) error {
+	args := m.Called(c, oldKey, newKey, oldValidUntil)
+	return args.Error(0)
+}
+
+func (m *MockApiKeyRepository) TouchApiKeyLastUsed(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
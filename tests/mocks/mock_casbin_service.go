@@ -4,24 +4,45 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	models "github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // CasbinService is an autogenerated mock type for the CasbinService type
 type CasbinService struct {
 	mock.Mock
 }
 
-// CheckPermission provides a mock function with given fields: sub, obj, act
-func (_m *CasbinService) CheckPermission(sub string, obj string, act string) bool {
-	ret := _m.Called(sub, obj, act)
+// AddRoleForUser provides a mock function with given fields: user, role, dom
+func (_m *CasbinService) AddRoleForUser(user string, role string, dom string) error {
+	ret := _m.Called(user, role, dom)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddRoleForUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(user, role, dom)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CheckPermission provides a mock function with given fields: user, dom, obj, act
+func (_m *CasbinService) CheckPermission(user *models.UserRedis, dom string, obj string, act string) bool {
+	ret := _m.Called(user, dom, obj, act)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CheckPermission")
 	}
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(string, string, string) bool); ok {
-		r0 = rf(sub, obj, act)
+	if rf, ok := ret.Get(0).(func(*models.UserRedis, string, string, string) bool); ok {
+		r0 = rf(user, dom, obj, act)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
@@ -29,6 +50,138 @@ func (_m *CasbinService) CheckPermission(sub string, obj string, act string) boo
 	return r0
 }
 
+// DeleteRoleForUser provides a mock function with given fields: user, role, dom
+func (_m *CasbinService) DeleteRoleForUser(user string, role string, dom string) error {
+	ret := _m.Called(user, role, dom)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRoleForUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(user, role, dom)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Explain provides a mock function with given fields: user, dom, obj, act
+func (_m *CasbinService) Explain(user *models.UserRedis, dom string, obj string, act string) (*models.AuthzExplanation, error) {
+	ret := _m.Called(user, dom, obj, act)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Explain")
+	}
+
+	var r0 *models.AuthzExplanation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*models.UserRedis, string, string, string) (*models.AuthzExplanation, error)); ok {
+		return rf(user, dom, obj, act)
+	}
+	if rf, ok := ret.Get(0).(func(*models.UserRedis, string, string, string) *models.AuthzExplanation); ok {
+		r0 = rf(user, dom, obj, act)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuthzExplanation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*models.UserRedis, string, string, string) error); ok {
+		r1 = rf(user, dom, obj, act)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoadPolicy provides a mock function with given fields:
+func (_m *CasbinService) LoadPolicy() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadPolicy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Permissions provides a mock function with given fields: user, dom
+func (_m *CasbinService) Permissions(user *models.UserRedis, dom string) (*models.UserPermissions, error) {
+	ret := _m.Called(user, dom)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Permissions")
+	}
+
+	var r0 *models.UserPermissions
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*models.UserRedis, string) (*models.UserPermissions, error)); ok {
+		return rf(user, dom)
+	}
+	if rf, ok := ret.Get(0).(func(*models.UserRedis, string) *models.UserPermissions); ok {
+		r0 = rf(user, dom)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserPermissions)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*models.UserRedis, string) error); ok {
+		r1 = rf(user, dom)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemovePolicy provides a mock function with given fields: sub, dom, obj
+func (_m *CasbinService) RemovePolicy(sub string, dom string, obj string) error {
+	ret := _m.Called(sub, dom, obj)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemovePolicy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(sub, dom, obj)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetPolicy provides a mock function with given fields: sub, dom, obj, act, cond
+func (_m *CasbinService) SetPolicy(sub string, dom string, obj string, act string, cond string) error {
+	ret := _m.Called(sub, dom, obj, act, cond)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPolicy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string, string, string) error); ok {
+		r0 = rf(sub, dom, obj, act, cond)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewCasbinService creates a new instance of CasbinService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewCasbinService(t interface {
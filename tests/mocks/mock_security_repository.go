@@ -0,0 +1,152 @@
+package mocks
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSecurityRepository struct {
+	mock.Mock
+}
+
+func (m *MockSecurityRepository) FindRoles(c *gin.Context, tenantID uuid.UUID) ([]models.Role, error) {
+	args := m.Called(c, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Role), args.Error(1)
+}
+
+func (m *MockSecurityRepository) FindRoleByID(c *gin.Context, id uint) (*models.Role, error) {
+	args := m.Called(c, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Role), args.Error(1)
+}
+
+func (m *MockSecurityRepository) CreateRole(c *gin.Context, role *models.Role) error {
+	args := m.Called(c, role)
+	return args.Error(0)
+}
+
+func (m *MockSecurityRepository) UpdateRoleName(c *gin.Context, id uint, name string) (*models.Role, error) {
+	args := m.Called(c, id, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Role), args.Error(1)
+}
+
+func (m *MockSecurityRepository) DeleteRole(c *gin.Context, id uint) error {
+	args := m.Called(c, id)
+	return args.Error(0)
+}
+
+func (m *MockSecurityRepository) FindEndpoints(c *gin.Context) ([]models.Endpoint, error) {
+	args := m.Called(c)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Endpoint), args.Error(1)
+}
+
+func (m *MockSecurityRepository) FindEndpointByID(c *gin.Context, id uint) (*models.Endpoint, error) {
+	args := m.Called(c, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Endpoint), args.Error(1)
+}
+
+func (m *MockSecurityRepository) CreateEndpoint(c *gin.Context, endpoint *models.Endpoint) error {
+	args := m.Called(c, endpoint)
+	return args.Error(0)
+}
+
+func (m *MockSecurityRepository) UpdateEndpointName(c *gin.Context, id uint, name string) (*models.Endpoint, error) {
+	args := m.Called(c, id, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Endpoint), args.Error(1)
+}
+
+func (m *MockSecurityRepository) DeleteEndpoint(c *gin.Context, id uint) error {
+	args := m.Called(c, id)
+	return args.Error(0)
+}
+
+func (m *MockSecurityRepository) FindRolePolicies(c *gin.Context, roleID uint) ([]models.PolicyRole, error) {
+	args := m.Called(c, roleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.PolicyRole), args.Error(1)
+}
+
+func (m *MockSecurityRepository) CreateRolePolicy(c *gin.Context, policy *models.PolicyRole) error {
+	args := m.Called(c, policy)
+	return args.Error(0)
+}
+
+func (m *MockSecurityRepository) UpdateRolePolicy(c *gin.Context, roleID, endpointID uint, actions, condition string) error {
+	args := m.Called(c, roleID, endpointID, actions, condition)
+	return args.Error(0)
+}
+
+func (m *MockSecurityRepository) DeleteRolePolicy(c *gin.Context, roleID, endpointID uint) error {
+	args := m.Called(c, roleID, endpointID)
+	return args.Error(0)
+}
+
+func (m *MockSecurityRepository) FindTenantUser(c *gin.Context, tenantID, userID uuid.UUID) (*models.User, error) {
+	args := m.Called(c, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockSecurityRepository) FindUserPolicies(c *gin.Context, userID uuid.UUID) ([]models.PolicyUser, error) {
+	args := m.Called(c, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.PolicyUser), args.Error(1)
+}
+
+func (m *MockSecurityRepository) CreateUserPolicy(c *gin.Context, policy *models.PolicyUser) error {
+	args := m.Called(c, policy)
+	return args.Error(0)
+}
+
+func (m *MockSecurityRepository) UpdateUserPolicy(c *gin.Context, userID uuid.UUID, endpointID uint, actions, condition string) error {
+	args := m.Called(c, userID, endpointID, actions, condition)
+	return args.Error(0)
+}
+
+func (m *MockSecurityRepository) DeleteUserPolicy(c *gin.Context, userID uuid.UUID, endpointID uint) error {
+	args := m.Called(c, userID, endpointID)
+	return args.Error(0)
+}
+
+func (m *MockSecurityRepository) FindUserRoles(c *gin.Context, userID uuid.UUID) ([]models.Role, error) {
+	args := m.Called(c, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Role), args.Error(1)
+}
+
+func (m *MockSecurityRepository) AddUserRole(c *gin.Context, userID uuid.UUID, roleID uint) error {
+	args := m.Called(c, userID, roleID)
+	return args.Error(0)
+}
+
+func (m *MockSecurityRepository) RemoveUserRole(c *gin.Context, userID uuid.UUID, roleID uint) error {
+	args := m.Called(c, userID, roleID)
+	return args.Error(0)
+}
@@ -3,6 +3,7 @@ package mocks
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/enums"
 	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
 	"github.com/stretchr/testify/mock"
 )
@@ -31,9 +32,12 @@ func (m *MockTenantRepository) Delete(c *gin.Context, id uuid.UUID) error {
 	return args.Error(0)
 }
 
-func (m *MockTenantRepository) GetAll(c *gin.Context) ([]models.Tenant, error) {
-	args := m.Called(c)
-	return args.Get(0).([]models.Tenant), args.Error(1)
+func (m *MockTenantRepository) GetAll(c *gin.Context, query models.ListQuery) (*models.Page[models.Tenant], error) {
+	args := m.Called(c, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Page[models.Tenant]), args.Error(1)
 }
 
 func (m *MockTenantRepository) GetByID(c *gin.Context, id uuid.UUID) (*models.Tenant, error) {
@@ -41,7 +45,39 @@ func (m *MockTenantRepository) GetByID(c *gin.Context, id uuid.UUID) (*models.Te
 	return args.Get(0).(*models.Tenant), args.Error(1)
 }
 
-func (m *MockTenantRepository) FindByApiKey(apiKey, origin string) (*models.Tenant, error) {
-	args := m.Called(apiKey, origin)
+func (m *MockTenantRepository) SearchTenants(c *gin.Context, term string, limit int) ([]models.Tenant, error) {
+	args := m.Called(c, term, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Tenant), args.Error(1)
+}
+
+func (m *MockTenantRepository) FindDeletedTenants(c *gin.Context, query models.ListQuery) (*models.Page[models.Tenant], error) {
+	args := m.Called(c, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Page[models.Tenant]), args.Error(1)
+}
+
+func (m *MockTenantRepository) RestoreTenant(c *gin.Context, id uuid.UUID) (*models.Tenant, error) {
+	args := m.Called(c, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
 	return args.Get(0).(*models.Tenant), args.Error(1)
 }
+
+func (m *MockTenantRepository) PurgeTenant(c *gin.Context, id uuid.UUID) error {
+	args := m.Called(c, id)
+	return args.Error(0)
+}
+
+func (m *MockTenantRepository) FindTenantIDsByStatus(status enums.StatusType) ([]uuid.UUID, error) {
+	args := m.Called(status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
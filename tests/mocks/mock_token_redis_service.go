@@ -16,6 +16,36 @@ type TokenRedisService struct {
 	mock.Mock
 }
 
+// ConsumeRefreshToken provides a mock function with given fields: userID, familyID, refreshToken
+func (_m *TokenRedisService) ConsumeRefreshToken(userID string, familyID string, refreshToken string) (*models.UserRedis, error) {
+	ret := _m.Called(userID, familyID, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeRefreshToken")
+	}
+
+	var r0 *models.UserRedis
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (*models.UserRedis, error)); ok {
+		return rf(userID, familyID, refreshToken)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) *models.UserRedis); ok {
+		r0 = rf(userID, familyID, refreshToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserRedis)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(userID, familyID, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserRedisFromToken provides a mock function with given fields: token
 func (_m *TokenRedisService) GetUserRedisFromToken(token string) (*models.UserRedis, error) {
 	ret := _m.Called(token)
@@ -46,17 +76,101 @@ func (_m *TokenRedisService) GetUserRedisFromToken(token string) (*models.UserRe
 	return r0, r1
 }
 
-// SaveUserRedis provides a mock function with given fields: user, token, refreshToken, accessDuration
-func (_m *TokenRedisService) SaveUserRedis(user *models.User, token string, refreshToken string, accessDuration time.Duration) error {
-	ret := _m.Called(user, token, refreshToken, accessDuration)
+// ListSessions provides a mock function with given fields: userID
+func (_m *TokenRedisService) ListSessions(userID string) ([]models.UserSession, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSessions")
+	}
+
+	var r0 []models.UserSession
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]models.UserSession, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []models.UserSession); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.UserSession)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeAllSessions provides a mock function with given fields: userID
+func (_m *TokenRedisService) RevokeAllSessions(userID string) error {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAllSessions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeOtherSessions provides a mock function with given fields: userID, keepSessionID
+func (_m *TokenRedisService) RevokeOtherSessions(userID string, keepSessionID string) error {
+	ret := _m.Called(userID, keepSessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeOtherSessions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(userID, keepSessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeSession provides a mock function with given fields: userID, sessionID
+func (_m *TokenRedisService) RevokeSession(userID string, sessionID string) error {
+	ret := _m.Called(userID, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(userID, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveUserRedis provides a mock function with given fields: user, session, accessDuration, refreshDuration
+func (_m *TokenRedisService) SaveUserRedis(user *models.User, session *models.UserSession, accessDuration time.Duration, refreshDuration time.Duration) error {
+	ret := _m.Called(user, session, accessDuration, refreshDuration)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SaveUserRedis")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.User, string, string, time.Duration) error); ok {
-		r0 = rf(user, token, refreshToken, accessDuration)
+	if rf, ok := ret.Get(0).(func(*models.User, *models.UserSession, time.Duration, time.Duration) error); ok {
+		r0 = rf(user, session, accessDuration, refreshDuration)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -8,9 +8,11 @@ import (
 	jwt "github.com/golang-jwt/jwt/v5"
 	mock "github.com/stretchr/testify/mock"
 
-	time "time"
+	models "github.com/jeancarlosdanese/go-base-api/internal/domain/models"
+
+	services "github.com/jeancarlosdanese/go-base-api/internal/services"
 
-	uuid "github.com/google/uuid"
+	time "time"
 )
 
 // TokenService is an autogenerated mock type for the TokenService type
@@ -18,9 +20,9 @@ type TokenService struct {
 	mock.Mock
 }
 
-// CreateTokens provides a mock function with given fields: userID, roles, permissions
-func (_m *TokenService) CreateTokens(userID uuid.UUID, roles []string, permissions []string) (string, string, error) {
-	ret := _m.Called(userID, roles, permissions)
+// CreateTokens provides a mock function with given fields: subject
+func (_m *TokenService) CreateTokens(subject services.TokenSubject) (string, string, error) {
+	ret := _m.Called(subject)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateTokens")
@@ -29,23 +31,23 @@ func (_m *TokenService) CreateTokens(userID uuid.UUID, roles []string, permissio
 	var r0 string
 	var r1 string
 	var r2 error
-	if rf, ok := ret.Get(0).(func(uuid.UUID, []string, []string) (string, string, error)); ok {
-		return rf(userID, roles, permissions)
+	if rf, ok := ret.Get(0).(func(services.TokenSubject) (string, string, error)); ok {
+		return rf(subject)
 	}
-	if rf, ok := ret.Get(0).(func(uuid.UUID, []string, []string) string); ok {
-		r0 = rf(userID, roles, permissions)
+	if rf, ok := ret.Get(0).(func(services.TokenSubject) string); ok {
+		r0 = rf(subject)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(uuid.UUID, []string, []string) string); ok {
-		r1 = rf(userID, roles, permissions)
+	if rf, ok := ret.Get(1).(func(services.TokenSubject) string); ok {
+		r1 = rf(subject)
 	} else {
 		r1 = ret.Get(1).(string)
 	}
 
-	if rf, ok := ret.Get(2).(func(uuid.UUID, []string, []string) error); ok {
-		r2 = rf(userID, roles, permissions)
+	if rf, ok := ret.Get(2).(func(services.TokenSubject) error); ok {
+		r2 = rf(subject)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -71,24 +73,60 @@ func (_m *TokenService) GetAccessDuration() time.Duration {
 	return r0
 }
 
+// GetRefreshDuration provides a mock function with given fields:
+func (_m *TokenService) GetRefreshDuration() time.Duration {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRefreshDuration")
+	}
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// JWKS provides a mock function with given fields:
+func (_m *TokenService) JWKS() models.JWKS {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for JWKS")
+	}
+
+	var r0 models.JWKS
+	if rf, ok := ret.Get(0).(func() models.JWKS); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(models.JWKS)
+	}
+
+	return r0
+}
+
 // RefreshTokens provides a mock function with given fields: refreshToken
-func (_m *TokenService) RefreshTokens(refreshToken string) (uuid.UUID, error) {
+func (_m *TokenService) RefreshTokens(refreshToken string) (*services.RefreshClaims, error) {
 	ret := _m.Called(refreshToken)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RefreshTokens")
 	}
 
-	var r0 uuid.UUID
+	var r0 *services.RefreshClaims
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (uuid.UUID, error)); ok {
+	if rf, ok := ret.Get(0).(func(string) (*services.RefreshClaims, error)); ok {
 		return rf(refreshToken)
 	}
-	if rf, ok := ret.Get(0).(func(string) uuid.UUID); ok {
+	if rf, ok := ret.Get(0).(func(string) *services.RefreshClaims); ok {
 		r0 = rf(refreshToken)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(uuid.UUID)
+			r0 = ret.Get(0).(*services.RefreshClaims)
 		}
 	}
 
@@ -3,6 +3,7 @@ package mocks
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-base-api/internal/domain/enums"
 	"github.com/jeancarlosdanese/go-base-api/internal/domain/models"
 	"github.com/stretchr/testify/mock"
 )
@@ -31,9 +32,12 @@ func (m *MockUserRepository) Delete(c *gin.Context, id uuid.UUID) error {
 	return args.Error(0)
 }
 
-func (m *MockUserRepository) GetAll(c *gin.Context) ([]models.User, error) {
-	args := m.Called(c)
-	return args.Get(0).([]models.User), args.Error(1)
+func (m *MockUserRepository) GetAll(c *gin.Context, query models.ListQuery) (*models.Page[models.User], error) {
+	args := m.Called(c, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Page[models.User]), args.Error(1)
 }
 
 func (m *MockUserRepository) GetByID(c *gin.Context, id uuid.UUID) (*models.User, error) {
@@ -41,6 +45,35 @@ func (m *MockUserRepository) GetByID(c *gin.Context, id uuid.UUID) (*models.User
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
+func (m *MockUserRepository) SearchUsers(c *gin.Context, term string, limit int) ([]models.User, error) {
+	args := m.Called(c, term, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) FindDeletedUsers(c *gin.Context, query models.ListQuery) (*models.Page[models.User], error) {
+	args := m.Called(c, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Page[models.User]), args.Error(1)
+}
+
+func (m *MockUserRepository) RestoreUser(c *gin.Context, id uuid.UUID) (*models.User, error) {
+	args := m.Called(c, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) PurgeUser(c *gin.Context, id uuid.UUID) error {
+	args := m.Called(c, id)
+	return args.Error(0)
+}
+
 func (m *MockUserRepository) FindByEmail(c *gin.Context, email, origin string) (*models.User, error) {
 	args := m.Called(c, email, origin)
 	return args.Get(0).(*models.User), args.Error(1)
@@ -50,3 +83,46 @@ func (m *MockUserRepository) GetOnlyByID(c *gin.Context, id uuid.UUID) (*models.
 	args := m.Called(c, id)
 	return args.Get(0).(*models.User), args.Error(1)
 }
+
+func (m *MockUserRepository) UpdatePassword(c *gin.Context, id uuid.UUID, hashedPassword string) error {
+	args := m.Called(c, id, hashedPassword)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) FindByIDWithPolicies(c *gin.Context, id uuid.UUID) (*models.User, error) {
+	args := m.Called(c, id)
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateTOTP(c *gin.Context, id uuid.UUID, secret *string, enabled bool) error {
+	args := m.Called(c, id, secret, enabled)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ReplaceRecoveryCodes(c *gin.Context, userID uuid.UUID, codeHashes []string) error {
+	args := m.Called(c, userID, codeHashes)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UseRecoveryCode(c *gin.Context, userID uuid.UUID, codeHash string) (bool, error) {
+	args := m.Called(c, userID, codeHash)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUserRepository) FindUserIDsByTenant(c *gin.Context, tenantID uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(c, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateUserStatus(c *gin.Context, id uuid.UUID, status enums.UserStatus) error {
+	args := m.Called(c, id, status)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) MarkEmailVerified(c *gin.Context, id uuid.UUID, email string) error {
+	args := m.Called(c, id, email)
+	return args.Error(0)
+}
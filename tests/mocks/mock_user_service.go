@@ -46,6 +46,24 @@ func (_m *UserService) Authenticate(c *gin.Context, email string, password strin
 	return r0, r1
 }
 
+// ChangePassword provides a mock function with given fields: c, id, currentPassword, newPassword
+func (_m *UserService) ChangePassword(c *gin.Context, id uuid.UUID, currentPassword string, newPassword string) error {
+	ret := _m.Called(c, id, currentPassword, newPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangePassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gin.Context, uuid.UUID, string, string) error); ok {
+		r0 = rf(c, id, currentPassword, newPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Create provides a mock function with given fields: c, entity
 func (_m *UserService) Create(c *gin.Context, entity *models.User) (*models.User, error) {
 	ret := _m.Called(c, entity)
@@ -124,29 +142,59 @@ func (_m *UserService) Delete(c *gin.Context, id uuid.UUID) error {
 	return r0
 }
 
-// GetAll provides a mock function with given fields: c
-func (_m *UserService) GetAll(c *gin.Context) ([]models.User, error) {
-	ret := _m.Called(c)
+// FindByEmail provides a mock function with given fields: c, email, origin
+func (_m *UserService) FindByEmail(c *gin.Context, email string, origin string) (*models.User, error) {
+	ret := _m.Called(c, email, origin)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByEmail")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gin.Context, string, string) (*models.User, error)); ok {
+		return rf(c, email, origin)
+	}
+	if rf, ok := ret.Get(0).(func(*gin.Context, string, string) *models.User); ok {
+		r0 = rf(c, email, origin)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gin.Context, string, string) error); ok {
+		r1 = rf(c, email, origin)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: c, query
+func (_m *UserService) GetAll(c *gin.Context, query models.ListQuery) (*models.Page[models.User], error) {
+	ret := _m.Called(c, query)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAll")
 	}
 
-	var r0 []models.User
+	var r0 *models.Page[models.User]
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gin.Context) ([]models.User, error)); ok {
-		return rf(c)
+	if rf, ok := ret.Get(0).(func(*gin.Context, models.ListQuery) (*models.Page[models.User], error)); ok {
+		return rf(c, query)
 	}
-	if rf, ok := ret.Get(0).(func(*gin.Context) []models.User); ok {
-		r0 = rf(c)
+	if rf, ok := ret.Get(0).(func(*gin.Context, models.ListQuery) *models.Page[models.User]); ok {
+		r0 = rf(c, query)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.User)
+			r0 = ret.Get(0).(*models.Page[models.User])
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*gin.Context) error); ok {
-		r1 = rf(c)
+	if rf, ok := ret.Get(1).(func(*gin.Context, models.ListQuery) error); ok {
+		r1 = rf(c, query)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -184,6 +232,36 @@ func (_m *UserService) GetByID(c *gin.Context, id uuid.UUID) (*models.User, erro
 	return r0, r1
 }
 
+// GetLoginOrigins provides a mock function with given fields: c, id
+func (_m *UserService) GetLoginOrigins(c *gin.Context, id uuid.UUID) ([]string, error) {
+	ret := _m.Called(c, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoginOrigins")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gin.Context, uuid.UUID) ([]string, error)); ok {
+		return rf(c, id)
+	}
+	if rf, ok := ret.Get(0).(func(*gin.Context, uuid.UUID) []string); ok {
+		r0 = rf(c, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gin.Context, uuid.UUID) error); ok {
+		r1 = rf(c, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetOnlyByID provides a mock function with given fields: c, id
 func (_m *UserService) GetOnlyByID(c *gin.Context, id uuid.UUID) (*models.User, error) {
 	ret := _m.Called(c, id)
@@ -214,6 +292,114 @@ func (_m *UserService) GetOnlyByID(c *gin.Context, id uuid.UUID) (*models.User,
 	return r0, r1
 }
 
+// GetTrash provides a mock function with given fields: c, query
+func (_m *UserService) GetTrash(c *gin.Context, query models.ListQuery) (*models.Page[models.User], error) {
+	ret := _m.Called(c, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrash")
+	}
+
+	var r0 *models.Page[models.User]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gin.Context, models.ListQuery) (*models.Page[models.User], error)); ok {
+		return rf(c, query)
+	}
+	if rf, ok := ret.Get(0).(func(*gin.Context, models.ListQuery) *models.Page[models.User]); ok {
+		r0 = rf(c, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Page[models.User])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gin.Context, models.ListQuery) error); ok {
+		r1 = rf(c, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Purge provides a mock function with given fields: c, id
+func (_m *UserService) Purge(c *gin.Context, id uuid.UUID) error {
+	ret := _m.Called(c, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gin.Context, uuid.UUID) error); ok {
+		r0 = rf(c, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Restore provides a mock function with given fields: c, id
+func (_m *UserService) Restore(c *gin.Context, id uuid.UUID) (*models.User, error) {
+	ret := _m.Called(c, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gin.Context, uuid.UUID) (*models.User, error)); ok {
+		return rf(c, id)
+	}
+	if rf, ok := ret.Get(0).(func(*gin.Context, uuid.UUID) *models.User); ok {
+		r0 = rf(c, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gin.Context, uuid.UUID) error); ok {
+		r1 = rf(c, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Search provides a mock function with given fields: c, term, limit
+func (_m *UserService) Search(c *gin.Context, term string, limit int) ([]models.User, error) {
+	ret := _m.Called(c, term, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gin.Context, string, int) ([]models.User, error)); ok {
+		return rf(c, term, limit)
+	}
+	if rf, ok := ret.Get(0).(func(*gin.Context, string, int) []models.User); ok {
+		r0 = rf(c, term, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gin.Context, string, int) error); ok {
+		r1 = rf(c, term, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: c, entity
 func (_m *UserService) Update(c *gin.Context, id uuid.UUID, entity *models.User) (*models.User, error) {
 	ret := _m.Called(c, id, entity)
@@ -274,6 +460,54 @@ func (_m *UserService) UpdatePartial(c *gin.Context, id uuid.UUID, updateData ma
 	return r0, r1
 }
 
+// UpdatePassword provides a mock function with given fields: c, id, newPassword
+func (_m *UserService) UpdatePassword(c *gin.Context, id uuid.UUID, newPassword string) error {
+	ret := _m.Called(c, id, newPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gin.Context, uuid.UUID, string) error); ok {
+		r0 = rf(c, id, newPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateProfile provides a mock function with given fields: c, id, profile
+func (_m *UserService) UpdateProfile(c *gin.Context, id uuid.UUID, profile *models.UserProfileUpdate) (*models.User, error) {
+	ret := _m.Called(c, id, profile)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProfile")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gin.Context, uuid.UUID, *models.UserProfileUpdate) (*models.User, error)); ok {
+		return rf(c, id, profile)
+	}
+	if rf, ok := ret.Get(0).(func(*gin.Context, uuid.UUID, *models.UserProfileUpdate) *models.User); ok {
+		r0 = rf(c, id, profile)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gin.Context, uuid.UUID, *models.UserProfileUpdate) error); ok {
+		r1 = rf(c, id, profile)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewUserService creates a new instance of UserService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserService(t interface {